@@ -0,0 +1,337 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ADFToMarkdown converts an Atlassian Document Format document to Markdown.
+// It is the counterpart of MarkdownToADF: converting the result back yields
+// an equivalent document for the node types MarkdownToADF produces. Nodes
+// without a Markdown equivalent (mentions, emoji, cards, panels) are rendered
+// as their text.
+func ADFToMarkdown(doc *ADFDocument) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimRight(renderBlocks(doc.Content, ""), "\n") + "\n"
+}
+
+// renderBlocks renders block nodes separated by blank lines. indent is
+// prepended to every line but the first, for content nested in list items.
+func renderBlocks(nodes []ADFNode, indent string) string {
+	var blocks []string
+	for _, node := range nodes {
+		if block := renderBlock(node, indent); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, "\n\n"+indent)
+}
+
+func renderBlock(node ADFNode, indent string) string {
+	switch node.Type {
+	case "paragraph":
+		return escapeLineStart(renderInline(node.Content, indent))
+
+	case "heading":
+		level := intAttr(node.Attrs, "level", 1)
+		return strings.Repeat("#", level) + " " + renderInline(node.Content, indent)
+
+	case "bulletList", "orderedList":
+		return renderList(node, indent)
+
+	case "codeBlock":
+		lang, _ := node.Attrs["language"].(string)
+		code := plainText(node.Content)
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return indentLines(fence+lang+"\n"+code+"\n"+fence, indent)
+
+	case "blockquote", "panel":
+		inner := renderBlocks(node.Content, "")
+		return indentLines(prefixLines(inner, "> "), indent)
+
+	case "rule":
+		return "---"
+
+	case "table":
+		return indentLines(renderTable(node), indent)
+
+	case "mediaSingle", "mediaGroup", "media":
+		return ""
+
+	default:
+		if len(node.Content) > 0 && isInlineNode(node.Content[0]) {
+			return renderInline(node.Content, indent)
+		}
+		return renderBlocks(node.Content, indent)
+	}
+}
+
+func renderList(list ADFNode, indent string) string {
+	ordered := list.Type == "orderedList"
+	n := intAttr(list.Attrs, "order", 1)
+
+	var items []string
+	for _, item := range list.Content {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", n)
+			n++
+		}
+		childIndent := indent + strings.Repeat(" ", len(marker))
+
+		var parts []string
+		for _, child := range item.Content {
+			if child.Type == "bulletList" || child.Type == "orderedList" {
+				parts = append(parts, "\n"+childIndent+renderList(child, childIndent))
+				continue
+			}
+			block := renderBlock(child, childIndent)
+			if len(parts) > 0 {
+				block = "\n\n" + childIndent + block
+			}
+			parts = append(parts, block)
+		}
+		items = append(items, marker+strings.Join(parts, ""))
+	}
+
+	return strings.Join(items, "\n"+indent)
+}
+
+func renderTable(table ADFNode) string {
+	var lines []string
+	for i, row := range table.Content {
+		cells := make([]string, len(row.Content))
+		for j, cell := range row.Content {
+			var parts []string
+			for _, block := range cell.Content {
+				parts = append(parts, renderInline(block.Content, ""))
+			}
+			cells[j] = strings.ReplaceAll(strings.Join(parts, " "), "|", `\|`)
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		// Markdown tables always have a header row; the first row serves as one
+		if i == 0 {
+			sep := make([]string, len(cells))
+			for j := range sep {
+				sep[j] = "---"
+			}
+			lines = append(lines, "| "+strings.Join(sep, " | ")+" |")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderInline renders inline nodes; hard breaks continue at indent
+func renderInline(nodes []ADFNode, indent string) string {
+	var sb strings.Builder
+	for _, node := range mergeTextNodes(nodes) {
+		switch node.Type {
+		case "text":
+			sb.WriteString(applyMarkdownMarks(node.Text, node.Marks))
+		case "hardBreak":
+			sb.WriteString("\\\n" + indent)
+		case "mention", "status":
+			text, _ := node.Attrs["text"].(string)
+			sb.WriteString(escapeMarkdown(text))
+		case "emoji":
+			text, _ := node.Attrs["text"].(string)
+			if text == "" {
+				text, _ = node.Attrs["shortName"].(string)
+			}
+			sb.WriteString(text)
+		case "inlineCard":
+			href, _ := node.Attrs["url"].(string)
+			sb.WriteString("<" + href + ">")
+		case "date":
+			sb.WriteString(fmt.Sprint(node.Attrs["timestamp"]))
+		default:
+			sb.WriteString(renderInline(node.Content, indent))
+		}
+	}
+	return sb.String()
+}
+
+// applyMarkdownMarks wraps text in the Markdown syntax for its marks. Code
+// spans are emitted verbatim since Markdown does not allow escapes in them.
+func applyMarkdownMarks(text string, marks []ADFMark) string {
+	isCode := false
+	for _, m := range marks {
+		if m.Type == "code" {
+			isCode = true
+		}
+	}
+
+	if isCode {
+		tick := "`"
+		for strings.Contains(text, tick) {
+			tick += "`"
+		}
+		text = tick + text + tick
+	} else {
+		text = escapeMarkdown(text)
+	}
+
+	for _, m := range marks {
+		switch m.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			href, _ := m.Attrs["href"].(string)
+			text = "[" + text + "](" + href + ")"
+		}
+	}
+	return text
+}
+
+// escapeMarkdown escapes characters that would otherwise start inline
+// Markdown syntax. Underscores inside words cannot start emphasis and are
+// left alone so identifiers like snake_case stay readable.
+func escapeMarkdown(text string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+	for i, r := range runes {
+		switch r {
+		case '\\', '`', '*', '[', ']', '~', '<':
+			sb.WriteRune('\\')
+		case '_':
+			if i == 0 || i == len(runes)-1 || !isWordRune(runes[i-1]) || !isWordRune(runes[i+1]) {
+				sb.WriteRune('\\')
+			}
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// escapeLineStart escapes characters at the start of a paragraph that would
+// turn it into a heading, list, quote or rule
+func escapeLineStart(text string) string {
+	trimmed := strings.TrimLeft(text, " ")
+	if trimmed == "" {
+		return text
+	}
+
+	switch trimmed[0] {
+	case '#', '>', '-', '+', '=', '|':
+		return `\` + trimmed
+	}
+
+	// Ordered list marker such as "1. " or "2) "
+	i := 0
+	for i < len(trimmed) && trimmed[i] >= '0' && trimmed[i] <= '9' {
+		i++
+	}
+	if i > 0 && i < len(trimmed) && (trimmed[i] == '.' || trimmed[i] == ')') {
+		return trimmed[:i] + `\` + trimmed[i:]
+	}
+	return trimmed
+}
+
+// mergeTextNodes joins adjacent text nodes with the same marks, so escaping
+// sees whole words rather than the fragments a parser may have produced
+func mergeTextNodes(nodes []ADFNode) []ADFNode {
+	merged := make([]ADFNode, 0, len(nodes))
+	for _, node := range nodes {
+		if last := len(merged) - 1; last >= 0 && node.Type == "text" && merged[last].Type == "text" &&
+			reflect.DeepEqual(node.Marks, merged[last].Marks) {
+			merged[last].Text += node.Text
+			continue
+		}
+		merged = append(merged, node)
+	}
+	return merged
+}
+
+func isInlineNode(node ADFNode) bool {
+	switch node.Type {
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "date", "status":
+		return true
+	}
+	return false
+}
+
+// plainText concatenates text without any Markdown syntax
+func plainText(nodes []ADFNode) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		sb.WriteString(n.Text)
+		sb.WriteString(plainText(n.Content))
+	}
+	return sb.String()
+}
+
+func intAttr(attrs map[string]interface{}, key string, def int) int {
+	switch v := attrs[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines indents every line but the first, which the caller positions
+func indentLines(s, indent string) string {
+	if indent == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, "\n", "\n"+indent)
+}
+
+// lossyNodeTypes are the node types ADFToMarkdown cannot render back to an
+// equivalent node: media is dropped and the rest are flattened to text
+var lossyNodeTypes = map[string]bool{
+	"media": true, "mediaSingle": true, "mediaGroup": true, "mediaInline": true,
+	"mention": true, "status": true, "emoji": true, "date": true,
+	"inlineCard": true, "blockCard": true, "embedCard": true,
+	"panel": true, "expand": true, "nestedExpand": true,
+	"extension": true, "bodiedExtension": true, "inlineExtension": true,
+	"taskList": true, "taskItem": true, "decisionList": true, "decisionItem": true,
+}
+
+// LossyADFNodes returns the distinct types of the nodes in a document that
+// would be lost or flattened by converting it to Markdown and back, in the
+// order they first appear
+func LossyADFNodes(doc *ADFDocument) []string {
+	if doc == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var types []string
+	var walk func([]ADFNode)
+	walk = func(nodes []ADFNode) {
+		for _, node := range nodes {
+			if lossyNodeTypes[node.Type] && !seen[node.Type] {
+				seen[node.Type] = true
+				types = append(types, node.Type)
+			}
+			walk(node.Content)
+		}
+	}
+	walk(doc.Content)
+	return types
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestADFToMarkdown_Nil(t *testing.T) {
+	assert.Equal(t, "", ADFToMarkdown(nil))
+}
+
+func TestADFToMarkdown_Blocks(t *testing.T) {
+	doc := &ADFDocument{Type: "doc", Version: 1, Content: []ADFNode{
+		{Type: "heading", Attrs: map[string]interface{}{"level": float64(2)}, Content: []ADFNode{{Type: "text", Text: "Steps"}}},
+		{Type: "paragraph", Content: []ADFNode{
+			{Type: "text", Text: "Run "},
+			{Type: "text", Text: "make test", Marks: []ADFMark{{Type: "code"}}},
+			{Type: "text", Text: " then "},
+			{Type: "text", Text: "check", Marks: []ADFMark{{Type: "strong"}}},
+			{Type: "hardBreak"},
+			{Type: "text", Text: "docs", Marks: []ADFMark{{Type: "link", Attrs: map[string]interface{}{"href": "https://example.com"}}}},
+			{Type: "text", Text: " cc "},
+			{Type: "mention", Attrs: map[string]interface{}{"id": "abc", "text": "@Jane"}},
+		}},
+		{Type: "orderedList", Content: []ADFNode{
+			{Type: "listItem", Content: []ADFNode{
+				{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "first"}}},
+				{Type: "bulletList", Content: []ADFNode{
+					{Type: "listItem", Content: []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "nested"}}}}},
+				}},
+			}},
+			{Type: "listItem", Content: []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "second"}}}}},
+		}},
+		{Type: "codeBlock", Attrs: map[string]interface{}{"language": "sh"}, Content: []ADFNode{{Type: "text", Text: "echo hi"}}},
+		{Type: "blockquote", Content: []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "quoted"}}}}},
+		{Type: "rule"},
+	}}
+
+	expected := "## Steps\n\n" +
+		"Run `make test` then **check**\\\n[docs](https://example.com) cc @Jane\n\n" +
+		"1. first\n   - nested\n2. second\n\n" +
+		"```sh\necho hi\n```\n\n" +
+		"> quoted\n\n" +
+		"---\n"
+	assert.Equal(t, expected, ADFToMarkdown(doc))
+}
+
+func TestADFToMarkdown_Escaping(t *testing.T) {
+	doc := &ADFDocument{Type: "doc", Version: 1, Content: []ADFNode{
+		{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "# not a heading"}}},
+		{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: "1. not a list, *not em*, snake_case and _x_"}}},
+	}}
+
+	assert.Equal(t, "\\# not a heading\n\n1\\. not a list, \\*not em\\*, snake_case and \\_x\\_\n", ADFToMarkdown(doc))
+}
+
+func TestADFToMarkdown_RoundTrip(t *testing.T) {
+	markdown := "# Title\n\n" +
+		"Some **bold**, *em*, `co*de`, ~~gone~~ and [a link](https://x.io) with snake_case and 2 * 3.\n\n" +
+		"- one\n- two\n  - nested\n\n" +
+		"1. a\n2. b\n\n" +
+		"> quoted\n\n" +
+		"```go\nfmt.Println(\"*\")\n```\n\n" +
+		"| A | B |\n| --- | --- |\n| 1 | x\\|y |\n\n" +
+		"\\# literal hash\n"
+
+	first := MarkdownToADF(markdown)
+	rendered := ADFToMarkdown(first)
+	second := MarkdownToADF(rendered)
+
+	assert.Equal(t, first.ToPlainText(), second.ToPlainText())
+	assert.Equal(t, rendered, ADFToMarkdown(second))
+	assert.Contains(t, rendered, "snake_case and 2 \\* 3.")
+}
+
+func TestMarkdownToADF_BackslashEscapes(t *testing.T) {
+	doc := MarkdownToADF(`2 \* 3 is not \*emphasis\*`)
+	assert.Equal(t, "2 * 3 is not *emphasis*\n", doc.ToPlainText())
+}
+
+func TestLossyADFNodes(t *testing.T) {
+	assert.Nil(t, LossyADFNodes(nil))
+	assert.Nil(t, LossyADFNodes(MarkdownToADF("# Title\n\n- **bold** [link](https://x.io)\n\n> quote")))
+
+	doc := &ADFDocument{Type: "doc", Version: 1, Content: []ADFNode{
+		{Type: "paragraph", Content: []ADFNode{
+			{Type: "mention", Attrs: map[string]interface{}{"id": "abc", "text": "@Jane"}},
+			{Type: "mention", Attrs: map[string]interface{}{"id": "def", "text": "@Joe"}},
+		}},
+		{Type: "panel", Content: []ADFNode{
+			{Type: "mediaSingle", Content: []ADFNode{{Type: "media", Attrs: map[string]interface{}{"id": "1", "type": "file"}}}},
+		}},
+	}}
+	assert.Equal(t, []string{"mention", "panel", "mediaSingle", "media"}, LossyADFNodes(doc))
+}
@@ -15,7 +15,7 @@ type Attachment struct {
 	ID       FlexibleID `json:"id"`
 	Filename string     `json:"filename"`
 	Author   User       `json:"author"`
-	Created  string     `json:"created"`
+	Created  Time       `json:"created"`
 	Size     int64      `json:"size"`
 	MimeType string     `json:"mimeType"`
 	Content  string     `json:"content"` // URL to download the attachment
@@ -108,6 +108,20 @@ func (c *Client) AddAttachment(issueKey, filePath string) ([]Attachment, error)
 	}
 	defer file.Close()
 
+	return c.AddAttachmentReader(issueKey, filepath.Base(filePath), file)
+}
+
+// AddAttachmentReader uploads the contents of r as an attachment named filename.
+// The multipart body is streamed to the server as it is read, so arbitrarily
+// large inputs (including pipes) are never buffered in memory.
+func (c *Client) AddAttachmentReader(issueKey, filename string, r io.Reader) ([]Attachment, error) {
+	if issueKey == "" {
+		return nil, fmt.Errorf("issue key is required")
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("file name is required")
+	}
+
 	// Create multipart form
 	pr, pw := io.Pipe()
 	writer := multipart.NewWriter(pw)
@@ -115,26 +129,34 @@ func (c *Client) AddAttachment(issueKey, filePath string) ([]Attachment, error)
 	// Write the file in a goroutine to avoid blocking
 	errChan := make(chan error, 1)
 	go func() {
-		defer pw.Close()
-		defer writer.Close()
-
-		part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+		part, err := writer.CreateFormFile("file", filename)
 		if err != nil {
-			errChan <- fmt.Errorf("failed to create form file: %w", err)
+			err = fmt.Errorf("failed to create form file: %w", err)
+			pw.CloseWithError(err)
+			errChan <- err
+			return
+		}
+
+		if _, err := io.Copy(part, r); err != nil {
+			err = fmt.Errorf("failed to copy file content: %w", err)
+			pw.CloseWithError(err)
+			errChan <- err
 			return
 		}
 
-		if _, err := io.Copy(part, file); err != nil {
-			errChan <- fmt.Errorf("failed to copy file content: %w", err)
+		if err := writer.Close(); err != nil {
+			pw.CloseWithError(err)
+			errChan <- err
 			return
 		}
-		errChan <- nil
+		errChan <- pw.Close()
 	}()
 
 	urlStr := fmt.Sprintf("%s/issue/%s/attachments", c.BaseURL, issueKey)
 
 	req, err := http.NewRequest(http.MethodPost, urlStr, pr)
 	if err != nil {
+		pr.Close()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -144,34 +166,37 @@ func (c *Client) AddAttachment(issueKey, filePath string) ([]Attachment, error)
 	// Required header for attachment uploads
 	req.Header.Set("X-Atlassian-Token", "no-check")
 
-	if c.Verbose {
-		fmt.Printf("→ POST %s\n", urlStr)
-	}
+	c.logf("→ POST %s", urlStr)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.transferClient().Do(req)
 	if err != nil {
+		// Unblock the writer goroutine if the request never consumed the body
+		pr.CloseWithError(err)
+		<-errChan
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Wait for the write goroutine to finish
-	if writeErr := <-errChan; writeErr != nil {
-		return nil, writeErr
-	}
-
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if c.Verbose {
-		fmt.Printf("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
+	// The server may answer before reading the whole body (e.g. a 413), so
+	// close the reader side before waiting for the writer to finish
+	pr.Close()
+	writeErr := <-errChan
+
+	c.logf("← %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 
 	if resp.StatusCode >= 400 {
 		return nil, ParseAPIError(resp, respBody)
 	}
 
+	if writeErr != nil {
+		return nil, writeErr
+	}
+
 	var attachments []Attachment
 	if err := json.Unmarshal(respBody, &attachments); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
@@ -193,59 +218,100 @@ func (c *Client) DeleteAttachment(attachmentID string) error {
 
 // DownloadAttachment downloads an attachment to the specified output path
 func (c *Client) DownloadAttachment(attachment *Attachment, outputPath string) error {
-	if attachment == nil {
-		return fmt.Errorf("attachment is required")
+	body, err := c.openAttachment(attachment)
+	if err != nil {
+		return err
 	}
-	if attachment.Content == "" {
-		return fmt.Errorf("attachment has no content URL")
+	defer body.Close()
+
+	// Determine output file path
+	outFile := outputPath
+	if isDirectory(outputPath) {
+		outFile = filepath.Join(outputPath, attachment.Filename)
 	}
 
-	// Create the request
-	req, err := http.NewRequest(http.MethodGet, attachment.Content, nil)
+	// Create the output file
+	file, err := os.Create(outFile)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create output file: %w", err)
 	}
+	defer file.Close()
 
-	req.Header.Set("Authorization", c.authHeader())
+	// Copy the content
+	if _, err := io.Copy(file, body); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
 
-	if c.Verbose {
-		fmt.Printf("→ GET %s\n", attachment.Content)
+	return nil
+}
+
+// DownloadAttachmentTo streams an attachment's content to w. It returns an
+// error if the number of bytes received does not match the attachment's
+// reported size, which indicates a truncated transfer.
+func (c *Client) DownloadAttachmentTo(attachment *Attachment, w io.Writer) error {
+	body, err := c.openAttachment(attachment)
+	if err != nil {
+		return err
 	}
+	defer body.Close()
 
-	resp, err := c.HTTPClient.Do(req)
+	n, err := io.Copy(w, body)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("failed to write attachment: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if c.Verbose {
-		fmt.Printf("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if attachment.Size > 0 && n != attachment.Size {
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", attachment.Filename, attachment.Size, n)
 	}
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return ParseAPIError(resp, body)
+	return nil
+}
+
+// openAttachment requests an attachment's content and returns the response body
+func (c *Client) openAttachment(attachment *Attachment) (io.ReadCloser, error) {
+	if attachment == nil {
+		return nil, fmt.Errorf("attachment is required")
+	}
+	if attachment.Content == "" {
+		return nil, fmt.Errorf("attachment has no content URL")
 	}
 
-	// Determine output file path
-	outFile := outputPath
-	if isDirectory(outputPath) {
-		outFile = filepath.Join(outputPath, attachment.Filename)
+	// Create the request
+	req, err := http.NewRequest(http.MethodGet, attachment.Content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Create the output file
-	file, err := os.Create(outFile)
+	req.Header.Set("Authorization", c.authHeader())
+
+	c.logf("→ GET %s", attachment.Content)
+
+	resp, err := c.transferClient().Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer file.Close()
 
-	// Copy the content
-	if _, err := io.Copy(file, resp.Body); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	c.logf("← %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, ParseAPIError(resp, body)
 	}
 
-	return nil
+	return resp.Body, nil
+}
+
+// transferClient returns an HTTP client suitable for long-running file
+// transfers. The regular client's overall timeout would abort large uploads
+// and downloads part way through, so it is dropped here.
+func (c *Client) transferClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	client := *c.HTTPClient
+	client.Timeout = 0
+	return &client
 }
 
 // isDirectory checks if a path is a directory
@@ -270,3 +336,25 @@ func FormatFileSize(bytes int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
+
+// AttachmentSettings describes the instance's attachment configuration
+type AttachmentSettings struct {
+	Enabled     bool  `json:"enabled"`
+	UploadLimit int64 `json:"uploadLimit"` // Maximum size of a single attachment in bytes
+}
+
+// GetAttachmentSettings returns whether attachments are enabled and the maximum upload size
+func (c *Client) GetAttachmentSettings() (*AttachmentSettings, error) {
+	urlStr := fmt.Sprintf("%s/attachment/meta", c.BaseURL)
+	body, err := c.get(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings AttachmentSettings
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse attachment settings: %w", err)
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DownloadAttachmentTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		w.Write([]byte("file content"))
+	}))
+	defer server.Close()
+
+	client := &Client{Email: "user@example.com", APIToken: "token", HTTPClient: server.Client()}
+
+	t.Run("streams content", func(t *testing.T) {
+		var buf bytes.Buffer
+		att := &Attachment{Filename: "a.txt", Size: 12, Content: server.URL + "/content/1"}
+		require.NoError(t, client.DownloadAttachmentTo(att, &buf))
+		assert.Equal(t, "file content", buf.String())
+	})
+
+	t.Run("verbose output stays out of the content", func(t *testing.T) {
+		var buf, log bytes.Buffer
+		verbose := &Client{Email: "user@example.com", APIToken: "token", HTTPClient: server.Client(), Verbose: true, Log: &log}
+		att := &Attachment{Filename: "a.txt", Size: 12, Content: server.URL + "/content/1"}
+		require.NoError(t, verbose.DownloadAttachmentTo(att, &buf))
+		assert.Equal(t, "file content", buf.String())
+		assert.Equal(t, "→ GET "+att.Content+"\n← 200 OK\n", log.String())
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		var buf bytes.Buffer
+		att := &Attachment{Filename: "a.txt", Size: 100, Content: server.URL + "/content/1"}
+		err := client.DownloadAttachmentTo(att, &buf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "size mismatch")
+	})
+
+	t.Run("missing content URL", func(t *testing.T) {
+		err := client.DownloadAttachmentTo(&Attachment{Filename: "a.txt"}, io.Discard)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_AddAttachmentReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/PROJ-1/attachments", r.URL.Path)
+		assert.Equal(t, "no-check", r.Header.Get("X-Atlassian-Token"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+
+		assert.Equal(t, "build.log", header.Filename)
+		assert.Equal(t, "log output", string(content))
+
+		w.Write([]byte(`[{"id":"10001","filename":"build.log","size":10}]`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	attachments, err := client.AddAttachmentReader("PROJ-1", "build.log", strings.NewReader("log output"))
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "10001", attachments[0].ID.String())
+}
+
+func TestClient_AddAttachmentReader_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errorMessages":["Attachments are disabled"]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	_, err := client.AddAttachmentReader("PROJ-1", "big.bin", strings.NewReader(strings.Repeat("x", 1<<20)))
+	require.Error(t, err)
+	assert.True(t, IsForbidden(err))
+}
+
+func TestClient_GetAttachmentSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/attachment/meta", r.URL.Path)
+		w.Write([]byte(`{"enabled":true,"uploadLimit":10485760}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	settings, err := client.GetAttachmentSettings()
+	require.NoError(t, err)
+	assert.True(t, settings.Enabled)
+	assert.Equal(t, int64(10485760), settings.UploadLimit)
+}
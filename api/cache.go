@@ -0,0 +1,34 @@
+package api
+
+// Cache stores raw responses of metadata endpoints (fields, issue types,
+// statuses, ...) that rarely change. Implementations decide how long entries
+// remain valid; a Get after expiry must report a miss.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte) error
+}
+
+// getCached performs a GET request, serving the response from the client's
+// cache when one is configured and holds a fresh copy
+func (c *Client) getCached(urlStr string) ([]byte, error) {
+	if c.Cache == nil {
+		return c.get(urlStr)
+	}
+
+	if data, ok := c.Cache.Get(urlStr); ok {
+		c.logf("→ GET %s (cached)", urlStr)
+		return data, nil
+	}
+
+	body, err := c.get(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// A cache write failure only costs a future request, so it is not fatal
+	if err := c.Cache.Set(urlStr, body); err != nil {
+		c.logf("cache write failed: %v", err)
+	}
+
+	return body, nil
+}
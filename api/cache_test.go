@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory Cache for tests
+type memoryCache map[string][]byte
+
+func (m memoryCache) Get(key string) ([]byte, bool) {
+	data, ok := m[key]
+	return data, ok
+}
+
+func (m memoryCache) Set(key string, data []byte) error {
+	m[key] = data
+	return nil
+}
+
+func TestClient_GetFields_UsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"id":"summary","name":"Summary"}]`))
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+		Cache:      cache,
+	}
+
+	for i := 0; i < 3; i++ {
+		fields, err := client.GetFields()
+		require.NoError(t, err)
+		require.Len(t, fields, 1)
+		assert.Equal(t, "summary", fields[0].ID)
+	}
+
+	assert.Equal(t, 1, requests)
+	assert.Contains(t, cache, server.URL+"/rest/api/3/field")
+}
+
+func TestClient_GetFields_NoCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	_, err := client.GetFields()
+	require.NoError(t, err)
+	_, err = client.GetFields()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_ErrorsAreNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+		Cache:      cache,
+	}
+
+	_, err := client.GetPriorities()
+	assert.Error(t, err)
+	assert.Empty(t, cache)
+}
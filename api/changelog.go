@@ -0,0 +1,291 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// changelogPageSize is the page size used when reading a changelog; 100 is the API maximum
+const changelogPageSize = 100
+
+// ChangelogEntry is a set of field changes made to an issue at once
+type ChangelogEntry struct {
+	ID      string          `json:"id"`
+	Author  *User           `json:"author,omitempty"`
+	Created Time            `json:"created"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// ChangelogItem is the change of one field. From and To hold IDs where the
+// field has them; FromString and ToString are the display values.
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype,omitempty"`
+	FieldID    string `json:"fieldId,omitempty"`
+	From       string `json:"from,omitempty"`
+	FromString string `json:"fromString,omitempty"`
+	To         string `json:"to,omitempty"`
+	ToString   string `json:"toString,omitempty"`
+}
+
+// Matches reports whether the item changes the named field. The name is
+// compared with the field's name and ID, ignoring case.
+func (i ChangelogItem) Matches(field string) bool {
+	return strings.EqualFold(i.Field, field) || (i.FieldID != "" && strings.EqualFold(i.FieldID, field))
+}
+
+// GetChangelog returns the full changelog of an issue, oldest first
+func (c *Client) GetChangelog(issueKey string) ([]ChangelogEntry, error) {
+	if issueKey == "" {
+		return nil, ErrIssueKeyRequired
+	}
+
+	base := fmt.Sprintf("%s/issue/%s/changelog", c.BaseURL, url.PathEscape(issueKey))
+
+	var all []ChangelogEntry
+	for startAt := 0; ; {
+		urlStr := buildURL(base, map[string]string{
+			"startAt":    strconv.Itoa(startAt),
+			"maxResults": strconv.Itoa(changelogPageSize),
+		})
+		body, err := c.get(urlStr)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Total  int              `json:"total"`
+			IsLast bool             `json:"isLast"`
+			Values []ChangelogEntry `json:"values"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse changelog: %w", err)
+		}
+		all = append(all, page.Values...)
+
+		startAt += len(page.Values)
+		if len(page.Values) == 0 || page.IsLast || startAt >= page.Total {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// IssueChangelog returns the full changelog of an issue from search results,
+// oldest first. The embedded changelog is used when it is complete; otherwise
+// the changelog is fetched.
+func (c *Client) IssueChangelog(issue *Issue) ([]ChangelogEntry, error) {
+	if !issue.HasFullChangelog() {
+		return c.GetChangelog(issue.Key)
+	}
+
+	entries := append([]ChangelogEntry(nil), issue.Changelog.Histories...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Created.Before(entries[j].Created.Time)
+	})
+	return entries, nil
+}
+
+// HasFullChangelog reports whether search results embedded the issue's
+// whole changelog, so IssueChangelog needs no request
+func (i *Issue) HasFullChangelog() bool {
+	return i.Changelog != nil && i.Changelog.Total <= len(i.Changelog.Histories)
+}
+
+// IssueChangelogs returns the full changelog of each issue, like
+// IssueChangelog. Changelogs that are not embedded in full are fetched with
+// up to concurrency requests at a time; fetched, when not nil, is called
+// after each.
+func (c *Client) IssueChangelogs(issues []Issue, concurrency int, fetched func()) ([][]ChangelogEntry, error) {
+	changelogs := make([][]ChangelogEntry, len(issues))
+
+	var missing []int
+	for i := range issues {
+		if !issues[i].HasFullChangelog() {
+			missing = append(missing, i)
+			continue
+		}
+		changelogs[i], _ = c.IssueChangelog(&issues[i])
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, len(issues))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(missing); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				changelogs[i], errs[i] = c.IssueChangelog(&issues[i])
+				if fetched != nil {
+					fetched()
+				}
+			}
+		}()
+	}
+	for _, i := range missing {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get changelog of %s: %w", issues[i].Key, err)
+		}
+	}
+	return changelogs, nil
+}
+
+// StatusTime is the time an issue spent in one status
+type StatusTime struct {
+	Status   string
+	Duration time.Duration
+	// Visits counts how often the issue entered the status
+	Visits int
+}
+
+// StatusChange is an issue moving between statuses. The IDs are status IDs;
+// From and To are status names.
+type StatusChange struct {
+	At     time.Time
+	From   string
+	FromID string
+	To     string
+	ToID   string
+}
+
+// StatusChanges extracts the status transitions from a changelog, oldest first
+func StatusChanges(changelog []ChangelogEntry) []StatusChange {
+	var changes []StatusChange
+	for _, entry := range changelog {
+		for _, item := range entry.Items {
+			if item.Matches("status") {
+				changes = append(changes, StatusChange{
+					At:     entry.Created.Time,
+					From:   item.FromString,
+					FromID: item.From,
+					To:     item.ToString,
+					ToID:   item.To,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// TimeInStatus breaks down how long an issue has spent in each status, from
+// its creation until now, in the order the statuses were first entered.
+// current is the issue's status, used when the changelog has no status changes.
+func TimeInStatus(created time.Time, current string, changelog []ChangelogEntry, now time.Time) []StatusTime {
+	changes := StatusChanges(changelog)
+
+	status := current
+	if len(changes) > 0 {
+		status = changes[0].From
+	}
+
+	var result []StatusTime
+	index := map[string]int{}
+	add := func(status string, from, to time.Time) {
+		i, ok := index[status]
+		if !ok {
+			i = len(result)
+			index[status] = i
+			result = append(result, StatusTime{Status: status})
+		}
+		result[i].Visits++
+		if to.After(from) {
+			result[i].Duration += to.Sub(from)
+		}
+	}
+
+	since := created
+	for _, change := range changes {
+		add(status, since, change.At)
+		status, since = change.To, change.At
+	}
+	add(status, since, now)
+
+	return result
+}
+
+// FlowTimes are the lead and cycle time of a completed issue. Lead time runs
+// from creation to completion, cycle time from when work started.
+type FlowTimes struct {
+	Created   time.Time
+	Started   time.Time // zero when the issue never entered a start status
+	Completed time.Time
+	Lead      time.Duration
+	Cycle     time.Duration
+}
+
+// ComputeFlowTimes measures an issue from its status changes. Work starts on
+// the first move into a status isStart accepts, and completes on the last
+// move into a status isEnd accepts. It returns false when the issue is not
+// completed: never done, or moved out of done again.
+func ComputeFlowTimes(created time.Time, changes []StatusChange, isStart, isEnd func(StatusChange) bool) (FlowTimes, bool) {
+	times := FlowTimes{Created: created}
+
+	done := false
+	for _, change := range changes {
+		if times.Started.IsZero() && isStart(change) {
+			times.Started = change.At
+		}
+		done = isEnd(change)
+		if done {
+			times.Completed = change.At
+		}
+	}
+	if !done {
+		return times, false
+	}
+
+	times.Lead = times.Completed.Sub(created)
+	if !times.Started.IsZero() && !times.Started.After(times.Completed) {
+		times.Cycle = times.Completed.Sub(times.Started)
+	} else {
+		times.Started = time.Time{}
+	}
+	return times, true
+}
+
+// FieldValue is the value of a field in a changelog: Raw holds IDs where
+// the field has them, Text the display value
+type FieldValue struct {
+	Raw  string
+	Text string
+}
+
+// FieldAt returns the value a field had at a point in time: the value set by
+// the last change before at or, when the field only changed later, the value
+// the first later change replaced. current is returned when the field never
+// changed. field matches item names and IDs as ChangelogItem.Matches does.
+func FieldAt(changelog []ChangelogEntry, field string, at time.Time, current FieldValue) FieldValue {
+	value, found := current, false
+	for _, entry := range changelog {
+		for _, item := range entry.Items {
+			if !item.Matches(field) {
+				continue
+			}
+			if entry.Created.After(at) {
+				if !found {
+					return FieldValue{Raw: item.From, Text: item.FromString}
+				}
+				return value
+			}
+			value, found = FieldValue{Raw: item.To, Text: item.ToString}, true
+		}
+	}
+	return value
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChangelog_Paginates(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/issue/PROJ-1/changelog", r.URL.Path)
+		start := r.URL.Query().Get("startAt")
+		starts = append(starts, start)
+
+		last := start == "2"
+		fmt.Fprintf(w, `{"startAt":%s,"total":3,"isLast":%t,"values":[`, start, last)
+		if last {
+			w.Write([]byte(`{"id":"3","created":"2024-03-03T09:00:00.000+0000","items":[]}`))
+		} else {
+			w.Write([]byte(`{"id":"1","created":"2024-03-01T09:00:00.000+0000","items":[]},
+				{"id":"2","created":"2024-03-02T09:00:00.000+0000","items":[]}`))
+		}
+		w.Write([]byte(`]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	changelog, err := client.GetChangelog("PROJ-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"0", "2"}, starts)
+	require.Len(t, changelog, 3)
+	assert.Equal(t, "3", changelog[2].ID)
+
+	_, err = client.GetChangelog("")
+	assert.ErrorIs(t, err, ErrIssueKeyRequired)
+}
+
+func TestTimeInStatus(t *testing.T) {
+	var changelog []ChangelogEntry
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id":"1","created":"2024-03-01T12:00:00.000+0000","items":[
+			{"field":"assignee","fieldId":"assignee","toString":"Ada"},
+			{"field":"status","fieldId":"status","fromString":"To Do","toString":"In Progress"}
+		]},
+		{"id":"2","created":"2024-03-02T12:00:00.000+0000","items":[
+			{"field":"status","fieldId":"status","fromString":"In Progress","toString":"In Review"}
+		]},
+		{"id":"3","created":"2024-03-02T18:00:00.000+0000","items":[
+			{"field":"status","fieldId":"status","fromString":"In Review","toString":"In Progress"}
+		]},
+		{"id":"4","created":"2024-03-03T00:00:00.000+0000","items":[
+			{"field":"status","fieldId":"status","fromString":"In Progress","toString":"Done"}
+		]}
+	]`), &changelog))
+
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []StatusTime{
+		{Status: "To Do", Duration: 3 * time.Hour, Visits: 1},
+		{Status: "In Progress", Duration: 30 * time.Hour, Visits: 2},
+		{Status: "In Review", Duration: 6 * time.Hour, Visits: 1},
+		{Status: "Done", Duration: 24 * time.Hour, Visits: 1},
+	}, TimeInStatus(created, "Done", changelog, now))
+
+	// Without status changes the whole lifetime is in the current status
+	assert.Equal(t, []StatusTime{{Status: "Open", Duration: 63 * time.Hour, Visits: 1}},
+		TimeInStatus(created, "Open", nil, now))
+}
+
+func TestComputeFlowTimes(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 9, 0, 0, 0, time.UTC) }
+	isStart := func(c StatusChange) bool { return c.To == "In Progress" }
+	isEnd := func(c StatusChange) bool { return c.To == "Done" }
+
+	t.Run("completed", func(t *testing.T) {
+		changes := []StatusChange{
+			{At: day(2), From: "To Do", To: "In Progress"},
+			{At: day(4), From: "In Progress", To: "Done"},
+			{At: day(5), From: "Done", To: "In Progress"},
+			{At: day(6), From: "In Progress", To: "Done"},
+		}
+		times, ok := ComputeFlowTimes(day(1), changes, isStart, isEnd)
+		require.True(t, ok)
+		assert.Equal(t, day(2), times.Started)
+		assert.Equal(t, day(6), times.Completed)
+		assert.Equal(t, 5*24*time.Hour, times.Lead)
+		assert.Equal(t, 4*24*time.Hour, times.Cycle)
+	})
+
+	t.Run("never started", func(t *testing.T) {
+		times, ok := ComputeFlowTimes(day(1), []StatusChange{{At: day(3), From: "To Do", To: "Done"}}, isStart, isEnd)
+		require.True(t, ok)
+		assert.True(t, times.Started.IsZero())
+		assert.Equal(t, 2*24*time.Hour, times.Lead)
+		assert.Zero(t, times.Cycle)
+	})
+
+	t.Run("reopened", func(t *testing.T) {
+		changes := []StatusChange{
+			{At: day(2), From: "To Do", To: "Done"},
+			{At: day(3), From: "Done", To: "In Progress"},
+		}
+		_, ok := ComputeFlowTimes(day(1), changes, isStart, isEnd)
+		assert.False(t, ok)
+	})
+}
+
+func TestIssueChangelog(t *testing.T) {
+	var fetched bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		w.Write([]byte(`{"total":1,"isLast":true,"values":[{"id":"1","created":"2024-03-01T09:00:00.000+0000","items":[]}]}`))
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	// A complete embedded changelog is used as is, oldest first
+	var issue Issue
+	require.NoError(t, json.Unmarshal([]byte(`{"key":"PROJ-1","fields":{},"changelog":{"total":2,"histories":[
+		{"id":"2","created":"2024-03-02T09:00:00.000+0000","items":[]},
+		{"id":"1","created":"2024-03-01T09:00:00.000+0000","items":[]}
+	]}}`), &issue))
+	entries, err := client.IssueChangelog(&issue)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "1", entries[0].ID)
+	assert.False(t, fetched)
+
+	// A truncated one is fetched
+	issue.Changelog.Total = 150
+	entries, err = client.IssueChangelog(&issue)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.True(t, fetched)
+}
+
+func TestIssueChangelogs(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/issue/PROJ-3/changelog" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"total":1,"isLast":true,"values":[{"id":"9","created":"2024-03-01T09:00:00.000+0000","items":[]}]}`))
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	issues := []Issue{
+		{Key: "PROJ-1", Changelog: &IssueChangelog{Total: 0}},
+		{Key: "PROJ-2"},
+		{Key: "PROJ-4", Changelog: &IssueChangelog{Total: 150}},
+	}
+	fetched := 0
+	changelogs, err := client.IssueChangelogs(issues, 2, func() { fetched++ })
+	require.NoError(t, err)
+	require.Len(t, changelogs, 3)
+	assert.Empty(t, changelogs[0])
+	assert.Equal(t, "9", changelogs[1][0].ID)
+	assert.Equal(t, "9", changelogs[2][0].ID)
+	assert.Equal(t, 2, fetched)
+	assert.ElementsMatch(t, []string{"/issue/PROJ-2/changelog", "/issue/PROJ-4/changelog"}, paths)
+
+	_, err = client.IssueChangelogs([]Issue{{Key: "PROJ-3"}}, 2, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get changelog of PROJ-3")
+}
+
+func TestFieldAt(t *testing.T) {
+	var changelog []ChangelogEntry
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id":"1","created":"2024-03-02T00:00:00.000+0000","items":[
+			{"field":"Story Points","fieldId":"customfield_10016","fromString":"2","toString":"3"}
+		]},
+		{"id":"2","created":"2024-03-04T00:00:00.000+0000","items":[
+			{"field":"Story Points","fieldId":"customfield_10016","fromString":"3","toString":"5"}
+		]}
+	]`), &changelog))
+
+	current := FieldValue{Text: "5"}
+	at := func(day int) string {
+		return FieldAt(changelog, "customfield_10016", time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC), current).Text
+	}
+	assert.Equal(t, "2", at(1))
+	assert.Equal(t, "3", at(3))
+	assert.Equal(t, "5", at(5))
+
+	// A field that never changed keeps its current value
+	assert.Equal(t, "Open", FieldAt(changelog, "status", time.Now(), FieldValue{Text: "Open"}).Text)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"time"
 )
 
@@ -20,6 +21,8 @@ type Client struct {
 	AgileURL   string // Agile API URL
 	HTTPClient *http.Client
 	Verbose    bool
+	Log        io.Writer // Verbose output; os.Stderr when nil
+	Cache      Cache     // Optional cache for metadata requests
 }
 
 // ClientConfig contains configuration for creating a new client
@@ -28,6 +31,7 @@ type ClientConfig struct {
 	Email    string
 	APIToken string
 	Verbose  bool
+	Cache    Cache
 }
 
 // New creates a new Jira API client from config
@@ -59,6 +63,7 @@ func New(cfg ClientConfig) (*Client, error) {
 			Timeout: 30 * time.Second,
 		},
 		Verbose: cfg.Verbose,
+		Cache:   cfg.Cache,
 	}, nil
 }
 
@@ -83,48 +88,68 @@ func (c *Client) authHeader() string {
 
 // doRequest performs an HTTP request with authentication
 func (c *Client) doRequest(method, urlStr string, body interface{}) ([]byte, error) {
+	resp, respBody, err := c.doRawRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, ParseAPIError(resp, respBody)
+	}
+
+	return respBody, nil
+}
+
+// doRawRequest performs an HTTP request with authentication and returns the
+// response whatever its status, for endpoints that report errors in their own format
+func (c *Client) doRawRequest(method, urlStr string, body interface{}) (*http.Response, []byte, error) {
 	var reqBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequest(method, urlStr, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", c.authHeader())
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	if c.Verbose {
-		fmt.Printf("→ %s %s\n", method, urlStr)
-	}
+	c.logf("→ %s %s", method, urlStr)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if c.Verbose {
-		fmt.Printf("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
+	c.logf("← %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 
-	if resp.StatusCode >= 400 {
-		return nil, ParseAPIError(resp, respBody)
-	}
+	return resp, respBody, nil
+}
 
-	return respBody, nil
+// logf writes a line of verbose output. It goes to stderr so that it never
+// mixes with command output, such as an attachment streamed to stdout.
+func (c *Client) logf(format string, args ...interface{}) {
+	if !c.Verbose {
+		return
+	}
+	w := c.Log
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format+"\n", args...)
 }
 
 // get performs a GET request
@@ -0,0 +1,413 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// textareaCustomType is the schema of multi-line text custom fields
+const textareaCustomType = "com.atlassian.jira.plugin.system.customfieldtypes:textarea"
+
+// richTextSystemFields are the system fields that hold rich text
+var richTextSystemFields = map[string]bool{"description": true, "environment": true}
+
+// commentField is the field ID that comment+=text adds a comment through
+const commentField = "comment"
+
+// FieldOperation is the kind of change a field argument makes
+type FieldOperation int
+
+const (
+	// FieldSet replaces the field value (key=value)
+	FieldSet FieldOperation = iota
+	// FieldAdd adds a value to a multi-value field (key+=value)
+	FieldAdd
+	// FieldRemove removes a value from a multi-value field (key-=value)
+	FieldRemove
+	// FieldJSON sets the field to a raw JSON value (key:=json)
+	FieldJSON
+)
+
+// cascadeSeparator separates parent and child values of cascading select fields
+const cascadeSeparator = "->"
+
+// FieldArg is a parsed field argument such as "labels+=urgent"
+type FieldArg struct {
+	Key   string
+	Op    FieldOperation
+	Value string
+}
+
+// ParseFieldArg parses a field argument in one of the forms
+// key=value, key+=value, key-=value or key:=json
+func ParseFieldArg(arg string) (FieldArg, error) {
+	idx := strings.Index(arg, "=")
+	if idx <= 0 {
+		return FieldArg{}, fmt.Errorf("invalid field format: %s (expected key=value)", arg)
+	}
+
+	key := arg[:idx]
+	value := arg[idx+1:]
+	op := FieldSet
+
+	switch key[len(key)-1] {
+	case '+':
+		op = FieldAdd
+	case '-':
+		op = FieldRemove
+	case ':':
+		op = FieldJSON
+	}
+	if op != FieldSet {
+		key = key[:len(key)-1]
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return FieldArg{}, fmt.Errorf("invalid field format: %s (missing field name)", arg)
+	}
+
+	return FieldArg{Key: key, Op: op, Value: value}, nil
+}
+
+// FieldChanges holds the "fields" and "update" sections built from field arguments
+type FieldChanges struct {
+	Fields map[string]interface{}
+	Update map[string]interface{}
+}
+
+// AddComment adds a comment to the update operations, after any comment
+// operations already collected
+func (fc *FieldChanges) AddComment(body string) {
+	ops, _ := fc.Update["comment"].([]map[string]interface{})
+	fc.Update["comment"] = append(ops, map[string]interface{}{"add": map[string]interface{}{"body": NewADFDocument(body)}})
+}
+
+// IsEmpty reports whether no changes were collected
+func (fc *FieldChanges) IsEmpty() bool {
+	return len(fc.Fields) == 0 && len(fc.Update) == 0
+}
+
+// UserResolver resolves an email address to an account ID
+type UserResolver func(email string) (string, error)
+
+// BuildFieldChanges resolves field arguments against field metadata and
+// converts their values to the shapes the Jira API expects. Field names are
+// matched case-insensitively; unknown keys are passed through unchanged.
+// resolveUser may be nil, in which case user values must be account IDs.
+func BuildFieldChanges(fields []Field, args []string, resolveUser UserResolver) (*FieldChanges, error) {
+	changes := &FieldChanges{
+		Fields: make(map[string]interface{}),
+		Update: make(map[string]interface{}),
+	}
+
+	for _, raw := range args {
+		arg, err := ParseFieldArg(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldID := arg.Key
+		if strings.EqualFold(fieldID, commentField) {
+			fieldID = commentField
+		}
+		field := FindFieldByID(fields, arg.Key)
+		if field == nil {
+			field = FindFieldByName(fields, arg.Key)
+		}
+		if field != nil {
+			fieldID = field.ID
+		}
+
+		switch arg.Op {
+		case FieldJSON:
+			var v interface{}
+			if err := json.Unmarshal([]byte(arg.Value), &v); err != nil {
+				return nil, fmt.Errorf("invalid JSON for field %s: %w", arg.Key, err)
+			}
+			changes.Fields[fieldID] = v
+
+		case FieldSet:
+			v, err := convertFieldValue(field, arg.Value, resolveUser)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", arg.Key, err)
+			}
+			changes.Fields[fieldID] = v
+
+		case FieldAdd, FieldRemove:
+			if fieldID == commentField {
+				if arg.Op == FieldRemove {
+					return nil, fmt.Errorf("comments cannot be removed with %s-=", arg.Key)
+				}
+				changes.AddComment(arg.Value)
+				continue
+			}
+			if field != nil && field.Schema.Type != "array" {
+				return nil, fmt.Errorf("field %s is not a multi-value field; use %s=value instead", arg.Key, arg.Key)
+			}
+			item, err := convertFieldItem(field, arg.Value, resolveUser)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", arg.Key, err)
+			}
+			verb := "add"
+			if arg.Op == FieldRemove {
+				verb = "remove"
+			}
+			ops, _ := changes.Update[fieldID].([]map[string]interface{})
+			changes.Update[fieldID] = append(ops, map[string]interface{}{verb: item})
+		}
+	}
+
+	return changes, nil
+}
+
+// FieldArgsFromValues converts structured field values, such as those read
+// from YAML or JSON, to field arguments in key order. Strings and scalars
+// become key=value, lists of scalars become comma-separated values and
+// anything else is passed as raw JSON (key:=json).
+func FieldArgsFromValues(values map[string]interface{}) ([]string, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		switch v := values[key].(type) {
+		case nil:
+			args = append(args, key+"=")
+		case string, bool, int, int64, float64:
+			args = append(args, fmt.Sprintf("%s=%v", key, v))
+		default:
+			if items, ok := scalarList(v); ok {
+				args = append(args, key+"="+strings.Join(items, ","))
+				continue
+			}
+			data, err := json.Marshal(jsonCompatible(v))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", key, err)
+			}
+			args = append(args, key+":="+string(data))
+		}
+	}
+
+	return args, nil
+}
+
+// scalarList returns the items of a list containing only scalars
+func scalarList(v interface{}) ([]string, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		switch s := item.(type) {
+		case string, bool, int, int64, float64:
+			items = append(items, fmt.Sprint(s))
+		default:
+			return nil, false
+		}
+	}
+	return items, true
+}
+
+// jsonCompatible converts YAML-decoded maps with interface{} keys so they can be marshaled to JSON
+func jsonCompatible(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = jsonCompatible(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = jsonCompatible(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = jsonCompatible(item)
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+// ResolveFieldArgs fetches field metadata and builds the changes described by
+// args. User fields accept email addresses, which are resolved to account IDs.
+func (c *Client) ResolveFieldArgs(args []string) (*FieldChanges, error) {
+	if len(args) == 0 {
+		return &FieldChanges{Fields: map[string]interface{}{}, Update: map[string]interface{}{}}, nil
+	}
+
+	fields, err := c.GetFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field metadata: %w", err)
+	}
+
+	return BuildFieldChanges(fields, args, c.ResolveUserByEmail)
+}
+
+// ResolveUserByEmail returns the account ID of the user with the given email address
+func (c *Client) ResolveUserByEmail(email string) (string, error) {
+	users, err := c.SearchUsers(email, 10)
+	if err != nil {
+		return "", err
+	}
+
+	for _, u := range users {
+		if strings.EqualFold(u.EmailAddress, email) {
+			return u.AccountID, nil
+		}
+	}
+	// Email addresses are often hidden by privacy settings; trust a unique match
+	if len(users) == 1 {
+		return users[0].AccountID, nil
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("no user found for %s", email)
+	}
+	return "", fmt.Errorf("multiple users match %s; use an account ID instead", email)
+}
+
+// convertFieldValue converts a key=value argument to the field's API shape
+func convertFieldValue(field *Field, value string, resolveUser UserResolver) (interface{}, error) {
+	if field == nil {
+		return value, nil
+	}
+
+	if field.Schema.Type == "array" {
+		if value == "" {
+			return []interface{}{}, nil
+		}
+		var items []interface{}
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			item, err := convertFieldItem(field, part, resolveUser)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	if isRichTextField(field) {
+		return NewADFDocument(value), nil
+	}
+
+	return convertScalar(field.Schema.Type, value, resolveUser)
+}
+
+// isRichTextField reports whether a field takes an ADF document: multi-line
+// text custom fields, the description and environment, and any doc field
+func isRichTextField(field *Field) bool {
+	return field.Schema.Custom == textareaCustomType || field.Schema.Type == "doc" || richTextSystemFields[field.Schema.System]
+}
+
+// convertFieldItem converts a single element of a multi-value field
+func convertFieldItem(field *Field, value string, resolveUser UserResolver) (interface{}, error) {
+	if field == nil {
+		return value, nil
+	}
+	return convertScalar(field.Schema.Items, value, resolveUser)
+}
+
+// convertScalar converts a single value based on its schema type
+func convertScalar(schemaType, value string, resolveUser UserResolver) (interface{}, error) {
+	switch schemaType {
+	case "option":
+		return map[string]string{"value": value}, nil
+	case "option-with-child":
+		parent, child, ok := strings.Cut(value, cascadeSeparator)
+		if !ok {
+			return map[string]interface{}{"value": strings.TrimSpace(value)}, nil
+		}
+		return map[string]interface{}{
+			"value": strings.TrimSpace(parent),
+			"child": map[string]string{"value": strings.TrimSpace(child)},
+		}, nil
+	case "user":
+		accountID, err := resolveAccountID(value, resolveUser)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"accountId": accountID}, nil
+	case "version", "component", "priority", "resolution", "issuetype", "securitylevel":
+		return map[string]string{"name": value}, nil
+	case "project":
+		return map[string]string{"key": value}, nil
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return n, nil
+	case "date":
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+		}
+		return t.Format("2006-01-02"), nil
+	case "datetime":
+		t, err := parseDateTimeArg(value)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(jiraTimeFormat), nil
+	default:
+		return value, nil
+	}
+}
+
+// resolveAccountID returns value as an account ID, looking up email addresses
+func resolveAccountID(value string, resolveUser UserResolver) (string, error) {
+	if !strings.Contains(value, "@") || resolveUser == nil {
+		return value, nil
+	}
+	return resolveUser(value)
+}
+
+// jiraTimeFormat is the timestamp layout used by the Jira REST API
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// parseDateTimeArg accepts the common ways of writing a timestamp on the command line
+func parseDateTimeArg(value string) (time.Time, error) {
+	layouts := []string{
+		jiraTimeFormat,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date-time %q (expected e.g. 2024-01-31T14:00)", value)
+}
+
+// ConvertFieldValue converts a single key=value style value to the field's API
+// shape, as BuildFieldChanges does for set operations
+func ConvertFieldValue(field *Field, value string, resolveUser UserResolver) (interface{}, error) {
+	return convertFieldValue(field, value, resolveUser)
+}
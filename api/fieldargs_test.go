@@ -0,0 +1,209 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldArg(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    FieldArg
+		wantErr bool
+	}{
+		{arg: "priority=High", want: FieldArg{Key: "priority", Op: FieldSet, Value: "High"}},
+		{arg: "Story Points=5", want: FieldArg{Key: "Story Points", Op: FieldSet, Value: "5"}},
+		{arg: "labels+=urgent", want: FieldArg{Key: "labels", Op: FieldAdd, Value: "urgent"}},
+		{arg: "labels-=stale", want: FieldArg{Key: "labels", Op: FieldRemove, Value: "stale"}},
+		{arg: `customfield_1:={"id":"1"}`, want: FieldArg{Key: "customfield_1", Op: FieldJSON, Value: `{"id":"1"}`}},
+		{arg: "summary=a=b", want: FieldArg{Key: "summary", Op: FieldSet, Value: "a=b"}},
+		{arg: "duedate=", want: FieldArg{Key: "duedate", Op: FieldSet, Value: ""}},
+		{arg: "novalue", wantErr: true},
+		{arg: "=value", wantErr: true},
+		{arg: "+=value", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseFieldArg(tt.arg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+var testFieldDefs = []Field{
+	{ID: "summary", Name: "Summary", Schema: FieldSchema{Type: "string"}},
+	{ID: "labels", Name: "Labels", Schema: FieldSchema{Type: "array", Items: "string"}},
+	{ID: "components", Name: "Components", Schema: FieldSchema{Type: "array", Items: "component"}},
+	{ID: "fixVersions", Name: "Fix versions", Schema: FieldSchema{Type: "array", Items: "version"}},
+	{ID: "assignee", Name: "Assignee", Schema: FieldSchema{Type: "user"}},
+	{ID: "priority", Name: "Priority", Schema: FieldSchema{Type: "priority"}},
+	{ID: "duedate", Name: "Due date", Schema: FieldSchema{Type: "date"}},
+	{ID: "customfield_10016", Name: "Story Points", Schema: FieldSchema{Type: "number"}},
+	{ID: "customfield_10020", Name: "Region", Schema: FieldSchema{Type: "option-with-child"}},
+	{ID: "customfield_10030", Name: "Teams", Schema: FieldSchema{Type: "array", Items: "option"}},
+	{ID: "customfield_10040", Name: "Reviewers", Schema: FieldSchema{Type: "array", Items: "user"}},
+	{ID: "customfield_10050", Name: "Notes", Schema: FieldSchema{Type: "string", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:textarea"}},
+	{ID: "description", Name: "Description", Schema: FieldSchema{Type: "string", System: "description"}},
+	{ID: "environment", Name: "Environment", Schema: FieldSchema{Type: "string", System: "environment"}},
+}
+
+func TestBuildFieldChanges(t *testing.T) {
+	users := map[string]string{"jane@example.com": "acc-jane", "bob@example.com": "acc-bob"}
+	resolve := func(email string) (string, error) {
+		if id, ok := users[email]; ok {
+			return id, nil
+		}
+		return "", fmt.Errorf("no user found for %s", email)
+	}
+
+	changes, err := BuildFieldChanges(testFieldDefs, []string{
+		"labels=infra, q3",
+		"components+=API",
+		"components-=Legacy",
+		"Fix versions=2.3.0",
+		"assignee=jane@example.com",
+		"priority=High",
+		"Due date=2024-09-30",
+		"Story Points=5",
+		"Region=EMEA -> Germany",
+		"Teams+=Platform",
+		"Reviewers=jane@example.com,acc-123",
+		`customfield_99999:={"id":"7"}`,
+		"unknownfield=raw",
+	}, resolve)
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"infra", "q3"}, changes.Fields["labels"])
+	assert.Equal(t, []interface{}{map[string]string{"name": "2.3.0"}}, changes.Fields["fixVersions"])
+	assert.Equal(t, map[string]string{"accountId": "acc-jane"}, changes.Fields["assignee"])
+	assert.Equal(t, map[string]string{"name": "High"}, changes.Fields["priority"])
+	assert.Equal(t, "2024-09-30", changes.Fields["duedate"])
+	assert.Equal(t, 5.0, changes.Fields["customfield_10016"])
+	assert.Equal(t, map[string]interface{}{
+		"value": "EMEA",
+		"child": map[string]string{"value": "Germany"},
+	}, changes.Fields["customfield_10020"])
+	assert.Equal(t, []interface{}{
+		map[string]string{"accountId": "acc-jane"},
+		map[string]string{"accountId": "acc-123"},
+	}, changes.Fields["customfield_10040"])
+	assert.Equal(t, map[string]interface{}{"id": "7"}, changes.Fields["customfield_99999"])
+	assert.Equal(t, "raw", changes.Fields["unknownfield"])
+
+	assert.Equal(t, []map[string]interface{}{
+		{"add": map[string]string{"name": "API"}},
+		{"remove": map[string]string{"name": "Legacy"}},
+	}, changes.Update["components"])
+	assert.Equal(t, []map[string]interface{}{
+		{"add": map[string]string{"value": "Platform"}},
+	}, changes.Update["customfield_10030"])
+}
+
+func TestBuildFieldChanges_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{"bad number", "Story Points=five", "invalid number"},
+		{"bad date", "duedate=30/09/2024", "invalid date"},
+		{"append to scalar", "priority+=High", "not a multi-value field"},
+		{"bad json", "customfield_1:={oops", "invalid JSON"},
+		{"unknown user", "assignee=ghost@example.com", "no user found"},
+		{"bad format", "labels", "invalid field format"},
+	}
+
+	resolve := func(email string) (string, error) {
+		return "", fmt.Errorf("no user found for %s", email)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildFieldChanges(testFieldDefs, []string{tt.arg}, resolve)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestBuildFieldChanges_TextareaAndClear(t *testing.T) {
+	changes, err := BuildFieldChanges(testFieldDefs, []string{"Notes=**bold**", "description=Steps", "environment=Linux", "duedate=", "labels="}, nil)
+	require.NoError(t, err)
+
+	for _, id := range []string{"customfield_10050", "description", "environment"} {
+		doc, ok := changes.Fields[id].(*ADFDocument)
+		require.True(t, ok, "%s is %T", id, changes.Fields[id])
+		assert.Equal(t, "doc", doc.Type)
+	}
+	assert.Equal(t, NewADFDocument("Linux"), changes.Fields["environment"])
+	assert.Nil(t, changes.Fields["duedate"])
+	assert.Contains(t, changes.Fields, "duedate")
+	assert.Equal(t, []interface{}{}, changes.Fields["labels"])
+	assert.True(t, (&FieldChanges{}).IsEmpty())
+	assert.False(t, changes.IsEmpty())
+}
+
+func TestFieldChanges_AddComment(t *testing.T) {
+	changes, err := BuildFieldChanges(testFieldDefs, []string{"comment+=First"}, nil)
+	require.NoError(t, err)
+
+	changes.AddComment("Second")
+	ops, ok := changes.Update["comment"].([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, ops, 2)
+	assert.Equal(t, map[string]interface{}{"body": NewADFDocument("First")}, ops[0]["add"])
+	assert.Equal(t, map[string]interface{}{"body": NewADFDocument("Second")}, ops[1]["add"])
+
+	// Jira's comment field is not an array, but comment+= still adds a comment
+	withComment := append([]Field{{ID: "comment", Name: "Comment", Schema: FieldSchema{Type: "comments-page", System: "comment"}}}, testFieldDefs...)
+	changes, err = BuildFieldChanges(withComment, []string{"Comment+=Third"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"add": map[string]interface{}{"body": NewADFDocument("Third")}}}, changes.Update["comment"])
+
+	_, err = BuildFieldChanges(testFieldDefs, []string{"comment-=First"}, nil)
+	assert.ErrorContains(t, err, "comments cannot be removed")
+}
+
+func TestClient_ResolveUserByEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/user/search", r.URL.Path)
+		switch r.URL.Query().Get("query") {
+		case "jane@example.com":
+			w.Write([]byte(`[{"accountId":"acc-jane","emailAddress":"jane@example.com"},{"accountId":"acc-janet","emailAddress":"janet@example.com"}]`))
+		case "hidden@example.com":
+			w.Write([]byte(`[{"accountId":"acc-hidden"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	id, err := client.ResolveUserByEmail("jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "acc-jane", id)
+
+	id, err = client.ResolveUserByEmail("hidden@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "acc-hidden", id)
+
+	_, err = client.ResolveUserByEmail("nobody@example.com")
+	assert.Error(t, err)
+}
@@ -10,7 +10,7 @@ import (
 // GetFields returns all field definitions
 func (c *Client) GetFields() ([]Field, error) {
 	urlStr := fmt.Sprintf("%s/field", c.BaseURL)
-	body, err := c.get(urlStr)
+	body, err := c.getCached(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +61,40 @@ func FindFieldByID(fields []Field, id string) *Field {
 	return nil
 }
 
+// storyPointsFieldNames are the names Jira gives the story points field:
+// company-managed projects use "Story Points", team-managed projects
+// "Story point estimate"
+var storyPointsFieldNames = []string{"Story Points", "Story point estimate"}
+
+// FindStoryPointsField finds the story points field. Its ID differs between
+// Jira sites, so it is looked up by name among the number fields.
+func FindStoryPointsField(fields []Field) *Field {
+	for _, name := range storyPointsFieldNames {
+		if f := FindFieldByName(fields, name); f != nil && f.Schema.Type == "number" {
+			return f
+		}
+	}
+	return nil
+}
+
+// StoryPointsField returns the field named by nameOrID or, when it is empty,
+// the story points field found by FindStoryPointsField. It returns nil when
+// no story points field is found.
+func (c *Client) StoryPointsField(nameOrID string) (*Field, error) {
+	fields, err := c.GetFields()
+	if err != nil {
+		return nil, err
+	}
+	if nameOrID == "" {
+		return FindStoryPointsField(fields), nil
+	}
+	id, err := ResolveFieldID(fields, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return FindFieldByID(fields, id), nil
+}
+
 // ResolveFieldID resolves a field name or ID to its ID
 func ResolveFieldID(fields []Field, nameOrID string) (string, error) {
 	// First try exact ID match
@@ -77,45 +111,65 @@ func ResolveFieldID(fields []Field, nameOrID string) (string, error) {
 }
 
 // FormatFieldValue formats a field value based on its type for the Jira API.
-// It handles special cases like:
-//   - option fields: wraps value as {"value": "..."}
-//   - array fields: wraps value as [{"value": "..."}] or []string{...}
-//   - user fields: wraps value as {"accountId": "..."}
-//   - number fields: converts string to float64
-//   - textarea custom fields: converts to ADF document
+// A value for an array field becomes a list of that one value.
+//
+// Deprecated: use ConvertFieldValue, which also splits comma-separated lists,
+// handles dates and reports invalid values.
 func FormatFieldValue(field *Field, value string) interface{} {
-	if field == nil {
-		return value
+	if field != nil && field.Schema.Type == "array" {
+		if field.Schema.Items == "option" {
+			return []map[string]string{{"value": value}}
+		}
+		return []string{value}
 	}
 
-	// Check for textarea custom fields that require ADF format
-	if field.Schema.Custom == "com.atlassian.jira.plugin.system.customfieldtypes:textarea" {
-		return NewADFDocument(value)
+	v, err := ConvertFieldValue(field, value, nil)
+	if err != nil {
+		return value
 	}
+	return v
+}
 
-	// Handle different field types
-	switch field.Schema.Type {
-	case "option":
-		// Single select fields need {"value": "..."} format
-		return map[string]string{"value": value}
-	case "array":
-		// Multi-select options need [{"value": "..."}] format
-		if field.Schema.Items == "option" {
-			return []map[string]string{{"value": value}}
+// FieldValueText renders a field value as returned by the API for display:
+// options by value, users by display name, lists comma-separated and rich
+// text as plain text
+func FieldValueText(raw interface{}) string {
+	switch v := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if text := FieldValueText(item); text != "" {
+				items = append(items, text)
+			}
 		}
-		// Other arrays (like labels) are just string arrays
-		return []string{value}
-	case "user":
-		// User fields need {"accountId": "..."} format
-		return map[string]string{"accountId": value}
-	case "number":
-		// Number fields need to be sent as JSON numbers, not strings
-		if n, err := strconv.ParseFloat(value, 64); err == nil {
-			return n
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			data, _ := json.Marshal(v)
+			var doc ADFDocument
+			if err := json.Unmarshal(data, &doc); err == nil {
+				return strings.TrimSpace(doc.ToPlainText())
+			}
 		}
-		return value
+		for _, key := range []string{"value", "displayName", "name", "key", "id"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					return s + " -> " + FieldValueText(child)
+				}
+				return s
+			}
+		}
+		return ""
 	default:
-		return value
+		return fmt.Sprint(v)
 	}
 }
 
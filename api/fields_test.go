@@ -368,3 +368,46 @@ func TestClient_GetFieldOptionsFromEditMeta(t *testing.T) {
 		assert.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestFieldValueText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want string
+	}{
+		{"nil", nil, ""},
+		{"string", "text", "text"},
+		{"number", float64(3.5), "3.5"},
+		{"integer", float64(8), "8"},
+		{"option", map[string]interface{}{"id": "1", "value": "High"}, "High"},
+		{"user", map[string]interface{}{"accountId": "abc", "displayName": "Jane Doe"}, "Jane Doe"},
+		{"cascading", map[string]interface{}{"value": "EMEA", "child": map[string]interface{}{"value": "Germany"}}, "EMEA -> Germany"},
+		{"list", []interface{}{"a", map[string]interface{}{"name": "API"}}, "a, API"},
+		{"document", map[string]interface{}{"type": "doc", "version": float64(1), "content": []interface{}{
+			map[string]interface{}{"type": "paragraph", "content": []interface{}{
+				map[string]interface{}{"type": "text", "text": "Hello"},
+			}},
+		}}, "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FieldValueText(tt.raw))
+		})
+	}
+}
+
+func TestFindStoryPointsField(t *testing.T) {
+	fields := []Field{
+		{ID: "customfield_10020", Name: "Sprint", Schema: FieldSchema{Type: "array"}},
+		{ID: "customfield_10016", Name: "Story point estimate", Schema: FieldSchema{Type: "number"}},
+	}
+	require.NotNil(t, FindStoryPointsField(fields))
+	assert.Equal(t, "customfield_10016", FindStoryPointsField(fields).ID)
+
+	// Company-managed projects' field is preferred
+	fields = append(fields, Field{ID: "customfield_10028", Name: "Story Points", Schema: FieldSchema{Type: "number"}})
+	assert.Equal(t, "customfield_10028", FindStoryPointsField(fields).ID)
+
+	assert.Nil(t, FindStoryPointsField([]Field{{ID: "x", Name: "Story Points", Schema: FieldSchema{Type: "string"}}}))
+}
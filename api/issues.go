@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 )
 
@@ -127,3 +128,85 @@ func BuildCreateRequest(projectKey, issueType, summary, description string, extr
 func BuildUpdateRequest(fields map[string]interface{}) *UpdateIssueRequest {
 	return &UpdateIssueRequest{Fields: fields}
 }
+
+// BulkCreateMaxIssues is the largest number of issues the bulk create endpoint accepts per request
+const BulkCreateMaxIssues = 50
+
+// BulkCreateResult is the outcome of creating one issue in a bulk request
+type BulkCreateResult struct {
+	Issue *Issue // set when the issue was created
+	Err   error  // set when it failed
+}
+
+// bulkCreateResponse is the response of the bulk create endpoint
+type bulkCreateResponse struct {
+	Issues []Issue `json:"issues"`
+	Errors []struct {
+		Status              int `json:"status"`
+		FailedElementNumber int `json:"failedElementNumber"`
+		ElementErrors       struct {
+			ErrorMessages []string          `json:"errorMessages"`
+			Errors        map[string]string `json:"errors"`
+		} `json:"elementErrors"`
+	} `json:"errors"`
+}
+
+// BulkCreateIssues creates up to BulkCreateMaxIssues issues in one request.
+// The results are in the same order as reqs; issues that Jira rejected carry
+// their error while the rest of the batch is still created.
+func (c *Client) BulkCreateIssues(reqs []*CreateIssueRequest) ([]BulkCreateResult, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) > BulkCreateMaxIssues {
+		return nil, fmt.Errorf("at most %d issues can be created per request, got %d", BulkCreateMaxIssues, len(reqs))
+	}
+
+	urlStr := fmt.Sprintf("%s/issue/bulk", c.BaseURL)
+	httpResp, body, err := c.doRawRequest(http.MethodPost, urlStr, map[string]interface{}{"issueUpdates": reqs})
+	if err != nil {
+		return nil, err
+	}
+
+	// When every issue fails Jira responds 400 with the per-issue errors
+	var resp bulkCreateResponse
+	if httpResp.StatusCode >= 400 {
+		if httpResp.StatusCode != http.StatusBadRequest || json.Unmarshal(body, &resp) != nil || len(resp.Errors) == 0 {
+			return nil, ParseAPIError(httpResp, body)
+		}
+	} else if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse bulk create response: %w", err)
+	}
+
+	results := make([]BulkCreateResult, len(reqs))
+	failed := make(map[int]bool, len(resp.Errors))
+	for _, e := range resp.Errors {
+		if e.FailedElementNumber < 0 || e.FailedElementNumber >= len(reqs) {
+			continue
+		}
+		apiErr := &APIError{
+			StatusCode:    e.Status,
+			ErrorMessages: e.ElementErrors.ErrorMessages,
+			Errors:        e.ElementErrors.Errors,
+		}
+		results[e.FailedElementNumber].Err = apiErr
+		failed[e.FailedElementNumber] = true
+	}
+
+	// Created issues are listed in request order, skipping the failed ones
+	next := 0
+	for i := range results {
+		if failed[i] {
+			continue
+		}
+		if next < len(resp.Issues) {
+			issue := resp.Issues[next]
+			results[i].Issue = &issue
+			next++
+		} else {
+			results[i].Err = fmt.Errorf("issue missing from bulk create response")
+		}
+	}
+
+	return results, nil
+}
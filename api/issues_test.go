@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCreateIssues(t *testing.T) {
+	t.Run("partial failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/issue/bulk", r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			var body struct {
+				IssueUpdates []CreateIssueRequest `json:"issueUpdates"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Len(t, body.IssueUpdates, 3)
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"issues": [{"id": "1", "key": "PROJ-1"}, {"id": "3", "key": "PROJ-3"}],
+				"errors": [{"status": 400, "failedElementNumber": 1,
+					"elementErrors": {"errors": {"summary": "Summary is required"}}}]
+			}`))
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		reqs := []*CreateIssueRequest{
+			BuildCreateRequest("PROJ", "Task", "One", "", nil),
+			BuildCreateRequest("PROJ", "Task", "", "", nil),
+			BuildCreateRequest("PROJ", "Task", "Three", "", nil),
+		}
+
+		results, err := client.BulkCreateIssues(reqs)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, "PROJ-1", results[0].Issue.Key)
+		assert.Nil(t, results[1].Issue)
+		require.Error(t, results[1].Err)
+		assert.Contains(t, results[1].Err.Error(), "Summary is required")
+		assert.Equal(t, "PROJ-3", results[2].Issue.Key)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"issues": [], "errors": [
+				{"status": 400, "failedElementNumber": 0, "elementErrors": {"errorMessages": ["Project is archived"]}}
+			]}`))
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		results, err := client.BulkCreateIssues([]*CreateIssueRequest{BuildCreateRequest("OLD", "Task", "One", "", nil)})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Error(t, results[0].Err)
+		assert.Contains(t, results[0].Err.Error(), "Project is archived")
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errorMessages": ["Forbidden"]}`))
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		_, err := client.BulkCreateIssues([]*CreateIssueRequest{BuildCreateRequest("PROJ", "Task", "One", "", nil)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Forbidden")
+	})
+
+	t.Run("too many issues", func(t *testing.T) {
+		client := &Client{BaseURL: "http://unused"}
+		_, err := client.BulkCreateIssues(make([]*CreateIssueRequest, BulkCreateMaxIssues+1))
+		require.Error(t, err)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/yuin/goldmark/extension"
 	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
 )
 
 // MarkdownToADF converts markdown text to an Atlassian Document Format document.
@@ -231,7 +232,8 @@ func convertInlineContent(parent ast.Node, source []byte) []ADFNode {
 func convertInlineNode(node ast.Node, source []byte) []ADFNode {
 	switch n := node.(type) {
 	case *ast.Text:
-		text := string(n.Segment.Value(source))
+		// Resolve backslash escapes such as \* so they produce the literal character
+		text := string(util.UnescapePunctuations(n.Segment.Value(source)))
 		if text == "" {
 			return nil
 		}
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// IssueLinkType represents a type of link between issues (e.g., "Blocks")
+type IssueLinkType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// CreateMetaField describes a field on the create screen of a project and issue type
+type CreateMetaField struct {
+	FieldID         string        `json:"fieldId"`
+	Key             string        `json:"key"`
+	Name            string        `json:"name"`
+	Required        bool          `json:"required"`
+	HasDefaultValue bool          `json:"hasDefaultValue"`
+	Schema          FieldSchema   `json:"schema"`
+	AllowedValues   []FieldOption `json:"allowedValues,omitempty"`
+	Operations      []string      `json:"operations,omitempty"`
+}
+
+// ID returns the field's ID, falling back to its key
+func (f CreateMetaField) ID() string {
+	if f.FieldID != "" {
+		return f.FieldID
+	}
+	return f.Key
+}
+
+// createMetaPageSize is the page size used for createmeta requests
+const createMetaPageSize = 50
+
+// GetIssueTypes returns all issue types visible to the user
+func (c *Client) GetIssueTypes() ([]IssueType, error) {
+	urlStr := fmt.Sprintf("%s/issuetype", c.BaseURL)
+	body, err := c.getCached(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []IssueType
+	if err := json.Unmarshal(body, &types); err != nil {
+		return nil, fmt.Errorf("failed to parse issue types: %w", err)
+	}
+
+	return types, nil
+}
+
+// GetStatuses returns all statuses
+func (c *Client) GetStatuses() ([]Status, error) {
+	urlStr := fmt.Sprintf("%s/status", c.BaseURL)
+	body, err := c.getCached(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	if err := json.Unmarshal(body, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to parse statuses: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// GetPriorities returns all issue priorities
+func (c *Client) GetPriorities() ([]Priority, error) {
+	urlStr := fmt.Sprintf("%s/priority", c.BaseURL)
+	body, err := c.getCached(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var priorities []Priority
+	if err := json.Unmarshal(body, &priorities); err != nil {
+		return nil, fmt.Errorf("failed to parse priorities: %w", err)
+	}
+
+	return priorities, nil
+}
+
+// GetIssueLinkTypes returns all issue link types
+func (c *Client) GetIssueLinkTypes() ([]IssueLinkType, error) {
+	urlStr := fmt.Sprintf("%s/issueLinkType", c.BaseURL)
+	body, err := c.getCached(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issue link types: %w", err)
+	}
+
+	return result.IssueLinkTypes, nil
+}
+
+// GetCreateMetaIssueTypes returns the issue types that can be created in a project
+func (c *Client) GetCreateMetaIssueTypes(projectKey string) ([]IssueType, error) {
+	if projectKey == "" {
+		return nil, ErrProjectKeyRequired
+	}
+
+	base := fmt.Sprintf("%s/issue/createmeta/%s/issuetypes", c.BaseURL, url.PathEscape(projectKey))
+
+	var all []IssueType
+	for startAt := 0; ; {
+		urlStr := buildURL(base, map[string]string{
+			"startAt":    strconv.Itoa(startAt),
+			"maxResults": strconv.Itoa(createMetaPageSize),
+		})
+		body, err := c.getCached(urlStr)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Total      int         `json:"total"`
+			IssueTypes []IssueType `json:"issueTypes"`
+			Values     []IssueType `json:"values"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse create metadata: %w", err)
+		}
+
+		items := page.IssueTypes
+		if len(items) == 0 {
+			items = page.Values
+		}
+		all = append(all, items...)
+
+		startAt += len(items)
+		if len(items) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// GetCreateMetaFields returns the fields on the create screen for a project and issue type
+func (c *Client) GetCreateMetaFields(projectKey, issueTypeID string) ([]CreateMetaField, error) {
+	if projectKey == "" {
+		return nil, ErrProjectKeyRequired
+	}
+	if issueTypeID == "" {
+		return nil, fmt.Errorf("issue type ID is required")
+	}
+
+	base := fmt.Sprintf("%s/issue/createmeta/%s/issuetypes/%s", c.BaseURL, url.PathEscape(projectKey), url.PathEscape(issueTypeID))
+
+	var all []CreateMetaField
+	for startAt := 0; ; {
+		urlStr := buildURL(base, map[string]string{
+			"startAt":    strconv.Itoa(startAt),
+			"maxResults": strconv.Itoa(createMetaPageSize),
+		})
+		body, err := c.getCached(urlStr)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Total  int               `json:"total"`
+			Fields []CreateMetaField `json:"fields"`
+			Values []CreateMetaField `json:"values"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse create metadata: %w", err)
+		}
+
+		items := page.Fields
+		if len(items) == 0 {
+			items = page.Values
+		}
+		all = append(all, items...)
+
+		startAt += len(items)
+		if len(items) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// GetCreateMeta returns the create screen fields for a project and an issue
+// type given by name or ID
+func (c *Client) GetCreateMeta(projectKey, issueType string) ([]CreateMetaField, error) {
+	types, err := c.GetCreateMetaIssueTypes(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, t := range types {
+		if t.ID == issueType || strings.EqualFold(t.Name, issueType) {
+			return c.GetCreateMetaFields(projectKey, t.ID)
+		}
+		names = append(names, t.Name)
+	}
+
+	return nil, fmt.Errorf("%w: issue type %q is not available in project %s (available: %s)",
+		ErrInvalidIssueType, issueType, projectKey, strings.Join(names, ", "))
+}
+
+// ErrInvalidIssueType is returned when an issue type cannot be created in a project
+var ErrInvalidIssueType = errors.New("invalid issue type")
+
+// CreateValidationError lists problems found when checking fields against create metadata
+type CreateValidationError struct {
+	Missing []CreateMetaField
+	Invalid []InvalidFieldValue
+}
+
+// InvalidFieldValue describes a value that is not among a field's allowed values
+type InvalidFieldValue struct {
+	Field   CreateMetaField
+	Value   string
+	Allowed []string
+}
+
+func (e *CreateValidationError) Error() string {
+	var parts []string
+
+	if len(e.Missing) > 0 {
+		names := make([]string, len(e.Missing))
+		for i, f := range e.Missing {
+			names[i] = fmt.Sprintf("%s (%s)", f.Name, f.ID())
+		}
+		parts = append(parts, "missing required fields: "+strings.Join(names, ", "))
+	}
+
+	for _, inv := range e.Invalid {
+		parts = append(parts, fmt.Sprintf("invalid value %q for %s (allowed: %s)",
+			inv.Value, inv.Field.Name, strings.Join(inv.Allowed, ", ")))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// ValidateCreateRequest checks a create request against create metadata.
+// It reports required fields that have no value and no server-side default,
+// and values of fields with a fixed set of allowed values that match none of them.
+// Values added through the update section count as set.
+func ValidateCreateRequest(meta []CreateMetaField, req *CreateIssueRequest) error {
+	verr := &CreateValidationError{}
+
+	for _, f := range meta {
+		value, present := req.Fields[f.ID()]
+		if !present {
+			value, present = addedValues(req.Update[f.ID()])
+		}
+
+		if !present || isEmptyValue(value) {
+			if f.Required && !f.HasDefaultValue {
+				verr.Missing = append(verr.Missing, f)
+			}
+			continue
+		}
+
+		if len(f.AllowedValues) == 0 {
+			continue
+		}
+		for _, ref := range valueRefs(value) {
+			if !MatchesAllowedValue(ref, f.AllowedValues) {
+				verr.Invalid = append(verr.Invalid, InvalidFieldValue{
+					Field:   f,
+					Value:   ref,
+					Allowed: allowedLabels(f.AllowedValues),
+				})
+			}
+		}
+	}
+
+	if len(verr.Missing) == 0 && len(verr.Invalid) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// addedValues collects the values of "add" operations in an update entry
+func addedValues(ops interface{}) (interface{}, bool) {
+	list, ok := ops.([]map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	var added []interface{}
+	for _, op := range list {
+		if v, ok := op["add"]; ok {
+			added = append(added, v)
+		}
+	}
+	return added, len(added) > 0
+}
+
+// MatchesAllowedValue reports whether ref names one of the allowed options by ID, key, name or value
+func MatchesAllowedValue(ref string, allowed []FieldOption) bool {
+	for _, opt := range allowed {
+		if opt.ID == ref || strings.EqualFold(opt.Key, ref) || strings.EqualFold(opt.Name, ref) || strings.EqualFold(opt.Value, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether a field value would leave the field unset
+func isEmptyValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(val) == ""
+	case []interface{}:
+		return len(val) == 0
+	case []string:
+		return len(val) == 0
+	case *ADFDocument:
+		return val == nil
+	}
+	return false
+}
+
+// valueRefs extracts the identifying strings (id, name, value or key) from a
+// field value so they can be compared with allowed values. Values that do not
+// reference an option (free text, numbers, ADF) yield no references.
+func valueRefs(v interface{}) []string {
+	switch val := v.(type) {
+	case map[string]string:
+		for _, k := range []string{"id", "value", "name", "key"} {
+			if s, ok := val[k]; ok {
+				return []string{s}
+			}
+		}
+	case map[string]interface{}:
+		for _, k := range []string{"id", "value", "name", "key"} {
+			if s, ok := val[k].(string); ok {
+				return []string{s}
+			}
+		}
+	case []interface{}:
+		var refs []string
+		for _, item := range val {
+			refs = append(refs, valueRefs(item)...)
+		}
+		return refs
+	case []map[string]string:
+		var refs []string
+		for _, item := range val {
+			refs = append(refs, valueRefs(item)...)
+		}
+		return refs
+	}
+	return nil
+}
+
+// allowedLabels returns display names for allowed values
+func allowedLabels(allowed []FieldOption) []string {
+	labels := make([]string, 0, len(allowed))
+	for _, opt := range allowed {
+		labels = append(labels, opt.Label())
+	}
+	return labels
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetadataTestClient(server *httptest.Server) *Client {
+	return &Client{
+		BaseURL:    server.URL + "/rest/api/3",
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+}
+
+func TestClient_MetadataLists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issuetype":
+			w.Write([]byte(`[{"id":"1","name":"Bug"}]`))
+		case "/rest/api/3/status":
+			w.Write([]byte(`[{"id":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}}]`))
+		case "/rest/api/3/priority":
+			w.Write([]byte(`[{"id":"2","name":"High"}]`))
+		case "/rest/api/3/issueLinkType":
+			w.Write([]byte(`{"issueLinkTypes":[{"id":"10000","name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newMetadataTestClient(server)
+
+	types, err := client.GetIssueTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "Bug", types[0].Name)
+
+	statuses, err := client.GetStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, "indeterminate", statuses[0].StatusCategory.Key)
+
+	priorities, err := client.GetPriorities()
+	require.NoError(t, err)
+	assert.Equal(t, "High", priorities[0].Name)
+
+	linkTypes, err := client.GetIssueLinkTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "blocks", linkTypes[0].Outward)
+}
+
+func TestClient_GetCreateMetaFields_Paginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/createmeta/PROJ/issuetypes/10001", r.URL.Path)
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			w.Write([]byte(`{"total":2,"fields":[{"fieldId":"summary","name":"Summary","required":true}]}`))
+		case "1":
+			w.Write([]byte(`{"total":2,"fields":[{"fieldId":"priority","name":"Priority","required":false,"allowedValues":[{"id":"1","name":"High"}]}]}`))
+		default:
+			t.Errorf("unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	}))
+	defer server.Close()
+	client := newMetadataTestClient(server)
+
+	fields, err := client.GetCreateMetaFields("PROJ", "10001")
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "summary", fields[0].ID())
+	assert.True(t, fields[0].Required)
+	assert.Equal(t, "High", fields[1].AllowedValues[0].Name)
+}
+
+func TestClient_GetCreateMetaIssueTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/createmeta/PROJ/issuetypes", r.URL.Path)
+		w.Write([]byte(`{"total":2,"issueTypes":[{"id":"1","name":"Bug"},{"id":"2","name":"Task"}]}`))
+	}))
+	defer server.Close()
+	client := newMetadataTestClient(server)
+
+	types, err := client.GetCreateMetaIssueTypes("PROJ")
+	require.NoError(t, err)
+	assert.Len(t, types, 2)
+
+	_, err = client.GetCreateMetaIssueTypes("")
+	assert.ErrorIs(t, err, ErrProjectKeyRequired)
+}
+
+func TestClient_GetCreateMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/PROJ/issuetypes":
+			w.Write([]byte(`{"total":2,"issueTypes":[{"id":"1","name":"Bug"},{"id":"2","name":"Task"}]}`))
+		case "/rest/api/3/issue/createmeta/PROJ/issuetypes/2":
+			w.Write([]byte(`{"total":1,"fields":[{"fieldId":"summary","name":"Summary","required":true}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newMetadataTestClient(server)
+
+	fields, err := client.GetCreateMeta("PROJ", "task")
+	require.NoError(t, err)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "summary", fields[0].ID())
+
+	_, err = client.GetCreateMeta("PROJ", "Epic")
+	require.ErrorIs(t, err, ErrInvalidIssueType)
+	assert.Contains(t, err.Error(), "Bug, Task")
+}
+
+func TestValidateCreateRequest(t *testing.T) {
+	meta := []CreateMetaField{
+		{FieldID: "project", Name: "Project", Required: true, AllowedValues: []FieldOption{{ID: "100", Key: "PROJ", Name: "Project"}}},
+		{FieldID: "summary", Name: "Summary", Required: true},
+		{FieldID: "reporter", Name: "Reporter", Required: true, HasDefaultValue: true},
+		{FieldID: "components", Name: "Components", Required: true, Schema: FieldSchema{Type: "array", Items: "component"},
+			AllowedValues: []FieldOption{{ID: "1", Name: "API"}, {ID: "2", Name: "UI"}}},
+		{FieldID: "customfield_10020", Name: "Team", Required: true},
+		{FieldID: "priority", Name: "Priority", AllowedValues: []FieldOption{{ID: "2", Name: "High"}, {ID: "3", Name: "Low"}}},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		req := BuildCreateRequest("PROJ", "Task", "Title", "", map[string]interface{}{
+			"components":        []interface{}{map[string]string{"name": "api"}},
+			"customfield_10020": "Platform",
+			"priority":          map[string]string{"id": "2"},
+		})
+		assert.NoError(t, ValidateCreateRequest(meta, req))
+	})
+
+	t.Run("values added through update count as set", func(t *testing.T) {
+		req := BuildCreateRequest("PROJ", "Task", "Title", "", map[string]interface{}{"customfield_10020": "Platform"})
+		req.Update = map[string]interface{}{
+			"components": []map[string]interface{}{{"add": map[string]string{"name": "UI"}}},
+		}
+		assert.NoError(t, ValidateCreateRequest(meta, req))
+	})
+
+	t.Run("missing and invalid", func(t *testing.T) {
+		req := BuildCreateRequest("PROJ", "Task", "Title", "", map[string]interface{}{
+			"components": []interface{}{},
+			"priority":   map[string]string{"name": "Urgent"},
+		})
+		err := ValidateCreateRequest(meta, req)
+
+		var verr *CreateValidationError
+		require.ErrorAs(t, err, &verr)
+		require.Len(t, verr.Missing, 2)
+		assert.Equal(t, "Components", verr.Missing[0].Name)
+		assert.Equal(t, "Team", verr.Missing[1].Name)
+		require.Len(t, verr.Invalid, 1)
+		assert.Equal(t, "Urgent", verr.Invalid[0].Value)
+		assert.Equal(t, `missing required fields: Components (components), Team (customfield_10020); invalid value "Urgent" for Priority (allowed: High, Low)`, err.Error())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // ProjectDetail represents detailed project information
@@ -53,3 +54,43 @@ func (c *Client) GetProject(projectKeyOrID string) (*ProjectDetail, error) {
 
 	return &project, nil
 }
+
+// Version is a project version, such as a release
+type Version struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// GetProjectVersions returns the versions of a project
+func (c *Client) GetProjectVersions(projectKeyOrID string) ([]Version, error) {
+	if projectKeyOrID == "" {
+		return nil, ErrProjectKeyRequired
+	}
+
+	urlStr := fmt.Sprintf("%s/project/%s/versions", c.BaseURL, url.PathEscape(projectKeyOrID))
+	body, err := c.get(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("failed to parse versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// FindVersion finds a version by name (case-insensitive) or ID
+func FindVersion(versions []Version, nameOrID string) *Version {
+	for i := range versions {
+		if strings.EqualFold(versions[i].Name, nameOrID) || versions[i].ID == nameOrID {
+			return &versions[i]
+		}
+	}
+	return nil
+}
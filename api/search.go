@@ -3,14 +3,22 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
 )
 
+// orderByPattern matches the ORDER BY clause of a JQL query
+var orderByPattern = regexp.MustCompile(`(?i)\s+order\s+by\s+.*$`)
+
 // SearchOptions contains options for JQL search
 type SearchOptions struct {
 	JQL        string
 	StartAt    int
 	MaxResults int
 	Fields     []string
+	Expand     string
 }
 
 // SearchRequest is the request body for the new JQL search API
@@ -19,6 +27,7 @@ type SearchRequest struct {
 	StartAt    int      `json:"startAt,omitempty"`
 	MaxResults int      `json:"maxResults,omitempty"`
 	Fields     []string `json:"fields,omitempty"`
+	Expand     string   `json:"expand,omitempty"`
 }
 
 // DefaultSearchFields are the fields returned by default in search results
@@ -31,6 +40,7 @@ var DefaultSearchFields = []string{
 	"project",
 	"created",
 	"updated",
+	"duedate",
 	"description",
 	"labels",
 	"components",
@@ -41,7 +51,8 @@ var DefaultSearchFields = []string{
 // Search searches for issues using JQL (uses new /search/jql endpoint)
 func (c *Client) Search(opts SearchOptions) (*SearchResult, error) {
 	req := SearchRequest{
-		JQL: opts.JQL,
+		JQL:    opts.JQL,
+		Expand: opts.Expand,
 	}
 
 	if opts.StartAt > 0 {
@@ -77,6 +88,23 @@ func (c *Client) Search(opts SearchOptions) (*SearchResult, error) {
 
 // SearchAll searches for all issues matching JQL (handles pagination)
 func (c *Client) SearchAll(jql string, maxResults int) ([]Issue, error) {
+	return c.SearchAllFields(jql, maxResults, nil)
+}
+
+// SearchAllFields is SearchAll returning the given fields instead of DefaultSearchFields
+func (c *Client) SearchAllFields(jql string, maxResults int, fields []string) ([]Issue, error) {
+	return c.searchAll(SearchOptions{JQL: jql, Fields: fields}, maxResults)
+}
+
+// SearchAllWithChangelog is SearchAllFields with each issue's changelog
+// expanded. Jira embeds at most 100 changelog entries per issue; use
+// IssueChangelog to get them all.
+func (c *Client) SearchAllWithChangelog(jql string, maxResults int, fields []string) ([]Issue, error) {
+	return c.searchAll(SearchOptions{JQL: jql, Fields: fields, Expand: "changelog"}, maxResults)
+}
+
+// searchAll pages through search results until maxResults issues are read
+func (c *Client) searchAll(opts SearchOptions, maxResults int) ([]Issue, error) {
 	if maxResults <= 0 {
 		maxResults = 1000
 	}
@@ -86,11 +114,8 @@ func (c *Client) SearchAll(jql string, maxResults int) ([]Issue, error) {
 	pageSize := 100
 
 	for {
-		result, err := c.Search(SearchOptions{
-			JQL:        jql,
-			StartAt:    startAt,
-			MaxResults: pageSize,
-		})
+		opts.StartAt, opts.MaxResults = startAt, pageSize
+		result, err := c.Search(opts)
 		if err != nil {
 			return nil, err
 		}
@@ -113,3 +138,32 @@ func (c *Client) SearchAll(jql string, maxResults int) ([]Issue, error) {
 
 	return allIssues, nil
 }
+
+// UpdatedWithin narrows a JQL query to issues updated in the last d. JQL
+// dates have minute precision, so d is rounded up to whole minutes plus one.
+// Relative dates avoid depending on the time zone of the user's Jira profile.
+// An ORDER BY clause stays at the end.
+func UpdatedWithin(jql string, d time.Duration) string {
+	minutes := int(math.Ceil(d.Minutes())) + 1
+	return AndJQL(jql, fmt.Sprintf("updated >= -%dm", minutes))
+}
+
+// AndJQL narrows a JQL query with another condition. An ORDER BY clause stays
+// at the end; an empty query yields the condition alone.
+func AndJQL(jql, condition string) string {
+	orderBy := orderByPattern.FindString(jql)
+	query := strings.TrimSpace(strings.TrimSuffix(jql, orderBy))
+	if query == "" {
+		return condition + orderBy
+	}
+	return fmt.Sprintf("(%s) AND %s%s", query, condition, orderBy)
+}
+
+// OrderJQL replaces the ORDER BY clause of a JQL query with orderBy
+func OrderJQL(jql, orderBy string) string {
+	query := strings.TrimSpace(strings.TrimSuffix(jql, orderByPattern.FindString(jql)))
+	if query == "" {
+		return "ORDER BY " + orderBy
+	}
+	return query + " ORDER BY " + orderBy
+}
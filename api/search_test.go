@@ -0,0 +1,27 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatedWithin(t *testing.T) {
+	assert.Equal(t, "(status = Open) AND updated >= -2m", UpdatedWithin("status = Open", 30*time.Second))
+	assert.Equal(t, "(a = b) AND updated >= -11m order by rank", UpdatedWithin("a = b order by rank", 10*time.Minute))
+	assert.Equal(t, "(project = PROJ) AND updated >= -1m ORDER BY updated DESC", UpdatedWithin("project = PROJ ORDER BY updated DESC", 0))
+	assert.Equal(t, "updated >= -61m ORDER BY key", UpdatedWithin(" ORDER BY key", time.Hour))
+}
+
+func TestAndJQL(t *testing.T) {
+	assert.Equal(t, "(project = PROJ) AND resolution IS NOT EMPTY", AndJQL("project = PROJ", "resolution IS NOT EMPTY"))
+	assert.Equal(t, "(a = b OR c = d) AND e = f ORDER BY key", AndJQL("a = b OR c = d ORDER BY key", "e = f"))
+	assert.Equal(t, "e = f", AndJQL("  ", "e = f"))
+}
+
+func TestOrderJQL(t *testing.T) {
+	assert.Equal(t, "project = PROJ ORDER BY updated ASC", OrderJQL("project = PROJ", "updated ASC"))
+	assert.Equal(t, "(a = b) AND c = d ORDER BY updated ASC", OrderJQL("(a = b) AND c = d order by rank, key", "updated ASC"))
+	assert.Equal(t, "ORDER BY key", OrderJQL(" ORDER BY rank", "key"))
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sprintField is the changelog name of the sprint field
+const sprintField = "Sprint"
+
+// StatusCategories maps statuses, by ID and by lower-case name, to the key of
+// their category: "new", "indeterminate" or "done"
+type StatusCategories map[string]string
+
+// NewStatusCategories indexes the categories of statuses
+func NewStatusCategories(statuses []Status) StatusCategories {
+	categories := make(StatusCategories, 2*len(statuses))
+	for _, s := range statuses {
+		categories[s.ID] = s.StatusCategory.Key
+		categories[strings.ToLower(s.Name)] = s.StatusCategory.Key
+	}
+	return categories
+}
+
+// Of returns the category of a status, found by ID and failing that by name
+func (c StatusCategories) Of(id, name string) string {
+	if key, ok := c[id]; ok {
+		return key
+	}
+	return c[strings.ToLower(name)]
+}
+
+// SprintHistory is an issue that was in a sprint at some point, with its
+// changelog, so its membership, status and estimate can be read at any moment
+type SprintHistory struct {
+	Issue     Issue
+	Changelog []ChangelogEntry
+
+	sprintID    string
+	pointsField string
+	inSprint    bool // currently in the sprint
+}
+
+// formerIssueMargin is how many days after a sprint ended an issue removed
+// from it may last have been updated and still be found without sprint WAS
+const formerIssueMargin = 7
+
+// SprintHistories finds the issues that were in a sprint at any point and
+// reads their changelogs, fetching up to concurrency at a time. The current
+// issues come from the sprint's issue list. Issues removed from the sprint are
+// no longer on it, so the same projects are searched with sprint WAS; sites
+// that reject WAS on the sprint field are searched for issues updated while
+// the sprint ran, up to formerIssueMargin days after it ended, instead, and
+// their changelogs checked for the sprint. pointsField is the ID of the story
+// points field; without one every issue counts as one point. Each list is read
+// up to max issues; truncated reports whether either reached it, so issues may
+// be missing.
+func (c *Client) SprintHistories(sprint *Sprint, pointsField string, max, concurrency int) (histories []*SprintHistory, truncated bool, err error) {
+	if sprint.StartDate == nil {
+		return nil, false, fmt.Errorf("sprint %d has not started", sprint.ID)
+	}
+
+	fields := []string{"summary", "status", "project", "created"}
+	if pointsField != "" {
+		fields = append(fields, pointsField)
+	}
+
+	current, err := c.SprintIssuesWithChangelog(sprint.ID, max, fields)
+	if err != nil {
+		return nil, false, err
+	}
+	truncated = max > 0 && len(current) >= max
+
+	var projects []string
+	seen := map[string]bool{}
+	for _, issue := range current {
+		if p := issue.Fields.Project; p != nil && !seen[p.Key] {
+			seen[p.Key] = true
+			projects = append(projects, fmt.Sprintf("%q", p.Key))
+		}
+	}
+
+	var former []Issue
+	if len(projects) > 0 {
+		former, err = c.SearchAllWithChangelog(formerIssuesJQL(projects, sprint, true), max, fields)
+		if errors.Is(err, ErrBadRequest) {
+			former, err = c.SearchAllWithChangelog(formerIssuesJQL(projects, sprint, false), max, fields)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		truncated = truncated || (max > 0 && len(former) >= max)
+	}
+
+	issues := append(current, former...)
+	changelogs, err := c.IssueChangelogs(issues, concurrency, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i, issue := range issues {
+		h := &SprintHistory{
+			Issue:       issue,
+			Changelog:   changelogs[i],
+			sprintID:    strconv.Itoa(sprint.ID),
+			pointsField: pointsField,
+			inSprint:    i < len(current),
+		}
+		if h.inSprint || h.everInSprint() {
+			histories = append(histories, h)
+		}
+	}
+	return histories, truncated, nil
+}
+
+// formerIssuesJQL selects the issues of projects that are no longer in a
+// sprint but may have been: those that were, with history, or otherwise those
+// updated between the sprint's start and formerIssueMargin days after its end
+func formerIssuesJQL(projects []string, sprint *Sprint, history bool) string {
+	notInSprint := fmt.Sprintf("(sprint is EMPTY OR sprint != %d)", sprint.ID)
+	if history {
+		return fmt.Sprintf("project in (%s) AND sprint WAS %d AND %s", strings.Join(projects, ", "), sprint.ID, notInSprint)
+	}
+
+	// JQL dates are in the user's time zone, so allow a day's margin
+	jql := fmt.Sprintf(`project in (%s) AND updated >= "%s"`,
+		strings.Join(projects, ", "), sprint.StartDate.AddDate(0, 0, -1).Format("2006-01-02"))
+	end := sprint.CompleteDate
+	if end == nil {
+		end = sprint.EndDate
+	}
+	if end != nil {
+		jql += fmt.Sprintf(` AND updated <= "%s"`, end.AddDate(0, 0, formerIssueMargin).Format("2006-01-02"))
+	}
+	return jql + " AND " + notInSprint
+}
+
+// MemberAt reports whether the issue was in the sprint at t
+func (h *SprintHistory) MemberAt(t time.Time) bool {
+	if created := h.Issue.Fields.Created; created != nil && created.After(t) {
+		return false
+	}
+	current := FieldValue{}
+	if h.inSprint {
+		current.Raw = h.sprintID
+	}
+	return containsSprint(FieldAt(h.Changelog, sprintField, t, current).Raw, h.sprintID)
+}
+
+// everInSprint reports whether any change moved the issue into or out of the sprint
+func (h *SprintHistory) everInSprint() bool {
+	for _, entry := range h.Changelog {
+		for _, item := range entry.Items {
+			if item.Matches(sprintField) && (containsSprint(item.To, h.sprintID) || containsSprint(item.From, h.sprintID)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddedBetween reports whether the issue was moved into the sprint after start and before end
+func (h *SprintHistory) AddedBetween(start, end time.Time) bool {
+	for _, entry := range h.Changelog {
+		if !entry.Created.After(start) || entry.Created.After(end) {
+			continue
+		}
+		for _, item := range entry.Items {
+			if item.Matches(sprintField) && containsSprint(item.To, h.sprintID) && !containsSprint(item.From, h.sprintID) {
+				return true
+			}
+		}
+	}
+	// Issues created straight into the sprint have no sprint change
+	created := h.Issue.Fields.Created
+	return created != nil && created.After(start) && !created.After(end) && h.MemberAt(end)
+}
+
+// DoneAt reports whether the issue was in a done status at t
+func (h *SprintHistory) DoneAt(t time.Time, categories StatusCategories) bool {
+	current := FieldValue{}
+	if s := h.Issue.Fields.Status; s != nil {
+		current = FieldValue{Raw: s.ID, Text: s.Name}
+	}
+	status := FieldAt(h.Changelog, "status", t, current)
+	return categories.Of(status.Raw, status.Text) == "done"
+}
+
+// PointsAt returns the issue's story points at t. Without a points field
+// every issue counts as one.
+func (h *SprintHistory) PointsAt(t time.Time) float64 {
+	if h.pointsField == "" {
+		return 1
+	}
+	current := FieldValue{Text: FieldValueText(h.Issue.Fields.Value(h.pointsField))}
+	n, _ := strconv.ParseFloat(FieldAt(h.Changelog, h.pointsField, t, current).Text, 64)
+	return n
+}
+
+// containsSprint reports whether a changelog sprint value, a comma-separated
+// list of sprint IDs, includes id
+func containsSprint(value, id string) bool {
+	for _, s := range strings.Split(value, ",") {
+		if strings.TrimSpace(s) == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCategories_Of(t *testing.T) {
+	categories := NewStatusCategories([]Status{
+		{ID: "1", Name: "To Do", StatusCategory: StatusCategory{Key: "new"}},
+		{ID: "10", Name: "Done", StatusCategory: StatusCategory{Key: "done"}},
+	})
+
+	assert.Equal(t, "done", categories.Of("10", ""))
+	assert.Equal(t, "new", categories.Of("", "to do"))
+	assert.Equal(t, "done", categories.Of("99", "DONE"))
+	assert.Equal(t, "", categories.Of("99", "Unknown"))
+}
+
+func TestSprintHistory_MemberAt(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	created := &Time{at("2024-03-01T00:00:00Z")}
+
+	// Moved from sprint 6 into 6 and 7, then out of 7
+	h := &SprintHistory{
+		Issue: Issue{Key: "PROJ-1", Fields: IssueFields{Created: created}},
+		Changelog: []ChangelogEntry{
+			{Created: Time{at("2024-03-05T00:00:00Z")}, Items: []ChangelogItem{{Field: "Sprint", From: "6", To: "6, 7"}}},
+			{Created: Time{at("2024-03-07T00:00:00Z")}, Items: []ChangelogItem{{Field: "Sprint", From: "6, 7", To: "6"}}},
+		},
+		sprintID: "7",
+	}
+
+	assert.False(t, h.MemberAt(at("2024-03-04T00:00:00Z")))
+	assert.True(t, h.MemberAt(at("2024-03-06T00:00:00Z")))
+	assert.False(t, h.MemberAt(at("2024-03-08T00:00:00Z")))
+	assert.True(t, h.everInSprint())
+	assert.True(t, h.AddedBetween(at("2024-03-04T00:00:00Z"), at("2024-03-08T00:00:00Z")))
+	assert.False(t, h.AddedBetween(at("2024-03-06T00:00:00Z"), at("2024-03-08T00:00:00Z")))
+	assert.Equal(t, 1.0, h.PointsAt(at("2024-03-06T00:00:00Z")))
+}
+
+func TestFormerIssuesJQL(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC)
+	projects := []string{`"PROJ"`, `"OPS"`}
+
+	closed := &Sprint{ID: 7, StartDate: &start, EndDate: &end}
+	assert.Equal(t, `project in ("PROJ", "OPS") AND sprint WAS 7 AND (sprint is EMPTY OR sprint != 7)`,
+		formerIssuesJQL(projects, closed, true))
+	assert.Equal(t, `project in ("PROJ", "OPS") AND updated >= "2024-03-03" AND updated <= "2024-03-15" AND (sprint is EMPTY OR sprint != 7)`,
+		formerIssuesJQL(projects, closed, false))
+
+	active := &Sprint{ID: 8, StartDate: &start}
+	assert.Equal(t, `project in ("PROJ", "OPS") AND updated >= "2024-03-03" AND (sprint is EMPTY OR sprint != 8)`,
+		formerIssuesJQL(projects, active, false))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // ListSprints returns sprints for a board
@@ -75,6 +76,49 @@ func (c *Client) GetSprintIssues(sprintID int, startAt, maxResults int) (*Search
 	return &result, nil
 }
 
+// sprintIssuePageSize is the largest page the Agile API returns
+const sprintIssuePageSize = 50
+
+// SprintIssuesWithChangelog returns up to maxResults issues in a sprint from
+// the Agile API, with the given fields and each issue's changelog expanded
+func (c *Client) SprintIssuesWithChangelog(sprintID, maxResults int, fields []string) ([]Issue, error) {
+	if maxResults <= 0 {
+		maxResults = 1000
+	}
+
+	var issues []Issue
+	for startAt := 0; ; {
+		params := map[string]string{
+			"startAt":    strconv.Itoa(startAt),
+			"maxResults": strconv.Itoa(sprintIssuePageSize),
+			"expand":     "changelog",
+		}
+		if len(fields) > 0 {
+			params["fields"] = strings.Join(fields, ",")
+		}
+
+		body, err := c.get(buildURL(fmt.Sprintf("%s/sprint/%d/issue", c.AgileURL, sprintID), params))
+		if err != nil {
+			return nil, err
+		}
+		var page SearchResult
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse sprint issues: %w", err)
+		}
+		issues = append(issues, page.Issues...)
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total || len(issues) >= maxResults {
+			break
+		}
+	}
+
+	if len(issues) > maxResults {
+		issues = issues[:maxResults]
+	}
+	return issues, nil
+}
+
 // GetCurrentSprint returns the active sprint for a board
 func (c *Client) GetCurrentSprint(boardID int) (*Sprint, error) {
 	result, err := c.ListSprints(boardID, "active", 0, 1)
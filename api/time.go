@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the timestamp formats the Jira APIs return. The REST API
+// uses a numeric zone without a colon, which RFC 3339 parsing rejects.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339Nano,
+}
+
+// dateLayout is the format of date-only fields such as the due date
+const dateLayout = "2006-01-02"
+
+// ParseTime parses a Jira timestamp
+func ParseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp: %s", s)
+}
+
+// Time is a Jira timestamp. It is written back out as RFC 3339, so JSON
+// output carries standard ISO timestamps.
+type Time struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a Jira timestamp; null and empty strings give the zero time
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil || s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON writes the time as RFC 3339
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// String returns the time as RFC 3339, or "" for the zero time
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Date is a calendar date without a time of day, such as an issue's due date
+type Date struct {
+	time.Time
+}
+
+// ParseDate parses a date in YYYY-MM-DD form
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD", s)
+	}
+	return Date{t}, nil
+}
+
+// UnmarshalJSON parses a YYYY-MM-DD date; null and empty strings give the zero date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil || s == "" {
+		d.Time = time.Time{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON writes the date as YYYY-MM-DD
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// String returns the date as YYYY-MM-DD, or "" for the zero date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateLayout)
+}
+
+// ParseSince parses the start of a time range: a date (2024-03-01), a Jira
+// timestamp, or a span back from now in minutes, hours, days or weeks
+// (30m, 12h, 7d, 2w)
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := ParseDate(s); err == nil {
+		return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	if t, err := ParseTime(s); err == nil {
+		return t, nil
+	}
+
+	if len(s) >= 2 {
+		n, err := strconv.Atoi(strings.TrimPrefix(s[:len(s)-1], "-"))
+		if err == nil && n >= 0 {
+			switch s[len(s)-1] {
+			case 'm':
+				return now.Add(-time.Duration(n) * time.Minute), nil
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a date (2024-03-01), a timestamp or a span such as 7d", s)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime_JSON(t *testing.T) {
+	var fields IssueFields
+	err := json.Unmarshal([]byte(`{
+		"summary": "Dated",
+		"created": "2024-03-05T10:15:30.000+0100",
+		"updated": "2024-03-06T08:00:00Z",
+		"duedate": "2024-03-31"
+	}`), &fields)
+	require.NoError(t, err)
+
+	require.NotNil(t, fields.Created)
+	assert.True(t, fields.Created.Equal(time.Date(2024, 3, 5, 9, 15, 30, 0, time.UTC)))
+	require.NotNil(t, fields.Updated)
+	assert.True(t, fields.Updated.Equal(time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC)))
+	require.NotNil(t, fields.DueDate)
+	assert.Equal(t, "2024-03-31", fields.DueDate.String())
+	assert.NotContains(t, fields.CustomFields, "duedate")
+
+	data, err := json.Marshal(fields)
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "2024-03-05T10:15:30+01:00", out["created"])
+	assert.Equal(t, "2024-03-06T08:00:00Z", out["updated"])
+	assert.Equal(t, "2024-03-31", out["duedate"])
+}
+
+func TestTime_JSON_Empty(t *testing.T) {
+	var fields IssueFields
+	require.NoError(t, json.Unmarshal([]byte(`{"summary":"x","created":null,"duedate":""}`), &fields))
+
+	data, err := json.Marshal(fields)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"summary":"x"}`, string(data))
+}
+
+func TestParseTime(t *testing.T) {
+	for _, s := range []string{
+		"2024-03-05T10:15:30.000+0000",
+		"2024-03-05T10:15:30+0000",
+		"2024-03-05T10:15:30Z",
+		"2024-03-05T10:15:30.123456Z",
+	} {
+		_, err := ParseTime(s)
+		assert.NoError(t, err, s)
+	}
+
+	_, err := ParseTime("last tuesday")
+	assert.Error(t, err)
+
+	_, err = ParseDate("31/03/2024")
+	assert.EqualError(t, err, `invalid date "31/03/2024": use YYYY-MM-DD`)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"30m", now.Add(-30 * time.Minute)},
+		{"12h", now.Add(-12 * time.Hour)},
+		{"7d", time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"-2w", time.Date(2024, 2, 25, 12, 0, 0, 0, time.UTC)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-03-01T08:00:00.000+0000", time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.in, now)
+		require.NoError(t, err, tt.in)
+		assert.True(t, tt.want.Equal(got), "%s: got %s", tt.in, got)
+	}
+
+	_, err := ParseSince("a while", now)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -39,6 +40,12 @@ func (c *Client) GetTransitionsWithFields(issueKey string, includeFields bool) (
 
 // DoTransition performs a transition on an issue with optional fields
 func (c *Client) DoTransition(issueKey, transitionID string, fields map[string]interface{}) error {
+	return c.DoTransitionWithUpdate(issueKey, transitionID, fields, nil)
+}
+
+// DoTransitionWithUpdate performs a transition on an issue with optional fields
+// and update operations (such as adding a comment) applied in the same request
+func (c *Client) DoTransitionWithUpdate(issueKey, transitionID string, fields, update map[string]interface{}) error {
 	if issueKey == "" {
 		return ErrIssueKeyRequired
 	}
@@ -47,6 +54,7 @@ func (c *Client) DoTransition(issueKey, transitionID string, fields map[string]i
 	req := TransitionRequest{
 		Transition: TransitionID{ID: transitionID},
 		Fields:     fields,
+		Update:     update,
 	}
 
 	_, err := c.post(urlStr, req)
@@ -63,3 +71,87 @@ func FindTransitionByName(transitions []Transition, name string) *Transition {
 	}
 	return nil
 }
+
+// ErrNoTransitionPath is returned when no sequence of transitions reaches the target status
+var ErrNoTransitionPath = errors.New("no transition path found")
+
+// TransitionLookup returns the transitions available to an issue in the given status
+type TransitionLookup func(status Status) ([]Transition, error)
+
+// StatusMatches reports whether a status has the given name (case-insensitive) or ID
+func StatusMatches(status Status, nameOrID string) bool {
+	return strings.EqualFold(status.Name, nameOrID) || (status.ID != "" && status.ID == nameOrID)
+}
+
+// FindTransitionPath finds the shortest sequence of transitions from the
+// current status to the target status using a breadth-first search.
+// initial holds the transitions available from the current status; lookup is
+// consulted for intermediate statuses and may return nil for statuses whose
+// outgoing transitions are unknown. At most maxHops transitions are used.
+func FindTransitionPath(current Status, initial []Transition, target string, maxHops int, lookup TransitionLookup) ([]Transition, error) {
+	if StatusMatches(current, target) {
+		return nil, nil
+	}
+	if maxHops < 1 {
+		maxHops = 1
+	}
+
+	type node struct {
+		status Status
+		path   []Transition
+	}
+
+	visited := map[string]bool{statusKey(current): true}
+	queue := []node{{status: current}}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if len(n.path) >= maxHops {
+			continue
+		}
+
+		transitions := initial
+		if len(n.path) > 0 {
+			var err error
+			transitions, err = lookup(n.status)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Prefer a direct hit before queueing deeper statuses
+		for _, t := range transitions {
+			if StatusMatches(t.To, target) {
+				return appendPath(n.path, t), nil
+			}
+		}
+
+		for _, t := range transitions {
+			key := statusKey(t.To)
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			queue = append(queue, node{status: t.To, path: appendPath(n.path, t)})
+		}
+	}
+
+	return nil, fmt.Errorf("%w from %s to %s within %d hop(s)", ErrNoTransitionPath, current.Name, target, maxHops)
+}
+
+// appendPath copies path so queued branches never share a backing array
+func appendPath(path []Transition, t Transition) []Transition {
+	next := make([]Transition, len(path), len(path)+1)
+	copy(next, path)
+	return append(next, t)
+}
+
+// statusKey identifies a status by ID, falling back to its lower-cased name
+func statusKey(s Status) string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return strings.ToLower(s.Name)
+}
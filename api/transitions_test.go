@@ -254,3 +254,91 @@ func TestClient_DoTransition(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_DoTransitionWithUpdate(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		Email:      "user@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+
+	fields := map[string]interface{}{"resolution": map[string]string{"name": "Fixed"}}
+	changes := &FieldChanges{Fields: fields, Update: map[string]interface{}{}}
+	changes.AddComment("Closing this out")
+	err := client.DoTransitionWithUpdate("PROJ-1", "31", fields, changes.Update)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"id": "31"}, received["transition"])
+	assert.Equal(t, map[string]interface{}{"resolution": map[string]interface{}{"name": "Fixed"}}, received["fields"])
+
+	update, ok := received["update"].(map[string]interface{})
+	require.True(t, ok)
+	comments, ok := update["comment"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, comments, 1)
+	add := comments[0].(map[string]interface{})["add"].(map[string]interface{})
+	assert.Equal(t, "doc", add["body"].(map[string]interface{})["type"])
+}
+
+func TestFindTransitionPath(t *testing.T) {
+	todo := Status{ID: "1", Name: "To Do"}
+	inProgress := Status{ID: "3", Name: "In Progress"}
+	review := Status{ID: "4", Name: "In Review"}
+	done := Status{ID: "5", Name: "Done"}
+
+	workflow := map[string][]Transition{
+		"1": {{ID: "11", Name: "Start", To: inProgress}},
+		"3": {{ID: "21", Name: "Review", To: review}, {ID: "41", Name: "Stop", To: todo}},
+		"4": {{ID: "31", Name: "Approve", To: done}, {ID: "51", Name: "Reject", To: inProgress}},
+	}
+	lookup := func(s Status) ([]Transition, error) {
+		return workflow[s.ID], nil
+	}
+
+	t.Run("already in target", func(t *testing.T) {
+		path, err := FindTransitionPath(done, nil, "done", 5, lookup)
+		require.NoError(t, err)
+		assert.Empty(t, path)
+	})
+
+	t.Run("direct transition", func(t *testing.T) {
+		path, err := FindTransitionPath(todo, workflow["1"], "In Progress", 5, lookup)
+		require.NoError(t, err)
+		require.Len(t, path, 1)
+		assert.Equal(t, "11", path[0].ID)
+	})
+
+	t.Run("multi hop", func(t *testing.T) {
+		path, err := FindTransitionPath(todo, workflow["1"], "done", 5, lookup)
+		require.NoError(t, err)
+		var ids []string
+		for _, tr := range path {
+			ids = append(ids, tr.ID)
+		}
+		assert.Equal(t, []string{"11", "21", "31"}, ids)
+	})
+
+	t.Run("hop limit", func(t *testing.T) {
+		_, err := FindTransitionPath(todo, workflow["1"], "Done", 2, lookup)
+		assert.ErrorIs(t, err, ErrNoTransitionPath)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := FindTransitionPath(todo, workflow["1"], "Cancelled", 5, lookup)
+		assert.ErrorIs(t, err, ErrNoTransitionPath)
+	})
+
+	t.Run("target by ID", func(t *testing.T) {
+		path, err := FindTransitionPath(inProgress, workflow["3"], "5", 5, lookup)
+		require.NoError(t, err)
+		assert.Len(t, path, 2)
+	})
+}
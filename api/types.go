@@ -11,6 +11,18 @@ type Issue struct {
 	Key    string      `json:"key"`
 	Self   string      `json:"self"`
 	Fields IssueFields `json:"fields"`
+
+	// Changelog is only present when requested with expand=changelog
+	Changelog *IssueChangelog `json:"changelog,omitempty"`
+}
+
+// IssueChangelog is the changelog embedded in an issue. It may hold only the
+// most recent entries; Total tells whether any are missing.
+type IssueChangelog struct {
+	StartAt    int              `json:"startAt"`
+	MaxResults int              `json:"maxResults"`
+	Total      int              `json:"total"`
+	Histories  []ChangelogEntry `json:"histories"`
 }
 
 // IssueFields contains the fields of a Jira issue
@@ -23,8 +35,9 @@ type IssueFields struct {
 	Assignee    *User        `json:"assignee,omitempty"`
 	Reporter    *User        `json:"reporter,omitempty"`
 	Project     *Project     `json:"project,omitempty"`
-	Created     string       `json:"created,omitempty"`
-	Updated     string       `json:"updated,omitempty"`
+	Created     *Time        `json:"created,omitempty"`
+	Updated     *Time        `json:"updated,omitempty"`
+	DueDate     *Date        `json:"duedate,omitempty"`
 	Labels      []string     `json:"labels,omitempty"`
 	Components  []Component  `json:"components,omitempty"`
 	Sprint      *Sprint      `json:"sprint,omitempty"`
@@ -40,7 +53,7 @@ var knownFieldKeys = map[string]bool{
 	"issuetype": true, "priority": true, "assignee": true,
 	"reporter": true, "project": true, "created": true,
 	"updated": true, "labels": true, "components": true,
-	"sprint": true, "parent": true,
+	"sprint": true, "parent": true, "duedate": true,
 }
 
 // UnmarshalJSON custom unmarshaler to capture custom fields
@@ -103,12 +116,15 @@ func (f IssueFields) MarshalJSON() ([]byte, error) {
 	if f.Project != nil {
 		result["project"] = f.Project
 	}
-	if f.Created != "" {
+	if f.Created != nil && !f.Created.IsZero() {
 		result["created"] = f.Created
 	}
-	if f.Updated != "" {
+	if f.Updated != nil && !f.Updated.IsZero() {
 		result["updated"] = f.Updated
 	}
+	if f.DueDate != nil && !f.DueDate.IsZero() {
+		result["duedate"] = f.DueDate
+	}
 	if len(f.Labels) > 0 {
 		result["labels"] = f.Labels
 	}
@@ -130,6 +146,27 @@ func (f IssueFields) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// Value returns the value of a field by ID in its API form, for typed and
+// custom fields alike. It returns nil when the field is not set.
+func (f *IssueFields) Value(id string) interface{} {
+	if v, ok := f.CustomFields[id]; ok {
+		return v
+	}
+	if !knownFieldKeys[id] {
+		return nil
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil
+	}
+	return all[id]
+}
+
 // Description can be either a string (Agile API) or ADF document (REST API v3)
 type Description struct {
 	Text string       // Plain text (from string or extracted from ADF)
@@ -183,6 +220,22 @@ type ADFDocument struct {
 	Content []ADFNode `json:"content,omitempty"`
 }
 
+// UnmarshalJSON reads an ADF document, or plain text as webhooks send comment
+// bodies, which becomes a document of one paragraph
+func (d *ADFDocument) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*d = ADFDocument{Type: "doc", Version: 1}
+		if str != "" {
+			d.Content = []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: str}}}}
+		}
+		return nil
+	}
+
+	type alias ADFDocument
+	return json.Unmarshal(data, (*alias)(d))
+}
+
 // ADFNode represents a node in an ADF document
 type ADFNode struct {
 	Type    string                 `json:"type"`
@@ -249,6 +302,8 @@ type IssueType struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	Subtask     bool   `json:"subtask"`
+	// HierarchyLevel is 1 for epics, 0 for standard types and -1 for subtasks
+	HierarchyLevel int `json:"hierarchyLevel,omitempty"`
 }
 
 // Priority represents an issue priority
@@ -326,17 +381,30 @@ type TransitionField struct {
 // FieldOption represents an allowed value for a field
 type FieldOption struct {
 	ID    string `json:"id,omitempty"`
+	Key   string `json:"key,omitempty"`
 	Name  string `json:"name,omitempty"`
 	Value string `json:"value,omitempty"`
 }
 
+// Label returns the text shown to users for an option: its name, value or ID
+func (o FieldOption) Label() string {
+	switch {
+	case o.Name != "":
+		return o.Name
+	case o.Value != "":
+		return o.Value
+	default:
+		return o.ID
+	}
+}
+
 // Comment represents an issue comment
 type Comment struct {
 	ID      string       `json:"id"`
 	Author  User         `json:"author"`
 	Body    *ADFDocument `json:"body"`
-	Created string       `json:"created"`
-	Updated string       `json:"updated"`
+	Created Time         `json:"created"`
+	Updated Time         `json:"updated"`
 }
 
 // Field represents a Jira field definition
@@ -402,6 +470,7 @@ type CommentsResponse struct {
 // CreateIssueRequest represents a request to create an issue
 type CreateIssueRequest struct {
 	Fields map[string]interface{} `json:"fields"`
+	Update map[string]interface{} `json:"update,omitempty"`
 }
 
 // UpdateIssueRequest represents a request to update an issue
@@ -414,6 +483,7 @@ type UpdateIssueRequest struct {
 type TransitionRequest struct {
 	Transition TransitionID           `json:"transition"`
 	Fields     map[string]interface{} `json:"fields,omitempty"`
+	Update     map[string]interface{} `json:"update,omitempty"`
 }
 
 // TransitionID wraps a transition ID
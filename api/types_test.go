@@ -374,6 +374,17 @@ func TestComment_UnmarshalJSON(t *testing.T) {
 	assert.Equal(t, "This is a comment\n", comment.Body.ToPlainText())
 }
 
+func TestComment_UnmarshalJSON_TextBody(t *testing.T) {
+	input := `{"id": "10002", "author": {"displayName": "Jane Doe"}, "body": "Looks *good*", "created": "2024-01-15T10:00:00.000+0000"}`
+
+	var comment Comment
+	require.NoError(t, json.Unmarshal([]byte(input), &comment))
+
+	require.NotNil(t, comment.Body)
+	assert.Equal(t, "doc", comment.Body.Type)
+	assert.Equal(t, "Looks *good*\n", comment.Body.ToPlainText())
+}
+
 func TestCreateIssueRequest_MarshalJSON(t *testing.T) {
 	req := CreateIssueRequest{
 		Fields: map[string]interface{}{
@@ -509,3 +520,21 @@ func TestIssue_RoundTrip_WithCustomFields(t *testing.T) {
 	assert.Equal(t, float64(8), fields["customfield_10001"])
 	assert.Equal(t, "Bug Fix", fields["customfield_10002"].(map[string]interface{})["value"])
 }
+
+func TestIssueFields_Value(t *testing.T) {
+	var fields IssueFields
+	err := json.Unmarshal([]byte(`{
+		"summary": "Test",
+		"status": {"name": "Open"},
+		"labels": ["a", "b"],
+		"customfield_10016": 5
+	}`), &fields)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Test", fields.Value("summary"))
+	assert.Equal(t, "Open", FieldValueText(fields.Value("status")))
+	assert.Equal(t, []interface{}{"a", "b"}, fields.Value("labels"))
+	assert.Equal(t, float64(5), fields.Value("customfield_10016"))
+	assert.Nil(t, fields.Value("priority"))
+	assert.Nil(t, fields.Value("customfield_99999"))
+}
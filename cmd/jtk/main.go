@@ -6,16 +6,24 @@ import (
 
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/attachments"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/boards"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cachecmd"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/comments"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/completion"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/configcmd"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/gitcmd"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/initcmd"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/issues"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/local"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/me"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/releasenotes"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/report"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/sprints"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/synccmd"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/templates"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/transitions"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/users"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/webhook"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/exitcode"
 )
 
@@ -39,7 +47,15 @@ func run() error {
 	boards.Register(rootCmd, opts)
 	sprints.Register(rootCmd, opts)
 	users.Register(rootCmd, opts)
+	webhook.Register(rootCmd, opts)
 	me.Register(rootCmd, opts)
+	report.Register(rootCmd, opts)
+	releasenotes.Register(rootCmd, opts)
+	gitcmd.Register(rootCmd, opts)
+	synccmd.Register(rootCmd, opts)
+	local.Register(rootCmd, opts)
+	cachecmd.Register(rootCmd, opts)
+	templates.Register(rootCmd, opts)
 	completion.Register(rootCmd, opts)
 
 	return rootCmd.Execute()
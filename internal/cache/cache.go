@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	cacheDirName  = "jira-ticket-cli"
+	cacheDirMode  = 0700
+	cacheFileMode = 0600
+	entrySuffix   = ".json"
+
+	// DefaultTTL is how long cached metadata stays fresh unless configured otherwise
+	DefaultTTL = time.Hour
+)
+
+// unsafeChars matches characters that are replaced when deriving a directory name from a URL
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileCache is an on-disk cache with one file per entry. Entries expire TTL
+// after they were written, based on the file's modification time.
+type FileCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// Status summarizes the contents of a cache directory
+type Status struct {
+	Dir     string        `json:"dir"`
+	TTL     time.Duration `json:"ttl"`
+	Entries int           `json:"entries"`
+	Expired int           `json:"expired"`
+	Bytes   int64         `json:"bytes"`
+	Oldest  time.Time     `json:"oldest,omitempty"`
+	Newest  time.Time     `json:"newest,omitempty"`
+}
+
+// Root returns the base cache directory shared by all instances
+func Root() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(dir, cacheDirName), nil
+}
+
+// New returns the cache for a Jira instance. Each instance URL gets its own
+// directory so switching between sites never serves another site's metadata.
+func New(instanceURL string, ttl time.Duration) (*FileCache, error) {
+	root, err := Root()
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &FileCache{Dir: filepath.Join(root, instanceDirName(instanceURL)), TTL: ttl}, nil
+}
+
+// InstanceName returns the filesystem-safe name an instance's cache is kept under
+func InstanceName(instanceURL string) string {
+	return instanceDirName(instanceURL)
+}
+
+// instanceDirName turns an instance URL into a readable, filesystem-safe name
+func instanceDirName(instanceURL string) string {
+	name := instanceURL
+	if u, err := url.Parse(instanceURL); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+	}
+	name = strings.Trim(unsafeChars.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		name = "default"
+	}
+	return name
+}
+
+// entryPath returns the file that stores key
+func (c *FileCache) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+entrySuffix)
+}
+
+// Get returns the cached data for key if present and not expired
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.entryPath(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if time.Since(info.ModTime()) > c.TTL {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores data for key. The entry is written to a temporary file and
+// renamed so concurrent readers never observe a partial write.
+func (c *FileCache) Set(key string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, cacheDirMode); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath) //nolint:errcheck
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Chmod(tmpPath, cacheFileMode); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, c.entryPath(key)); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every entry in the cache
+func (c *FileCache) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// Status reports the number, size and age of cached entries
+func (c *FileCache) Status() (*Status, error) {
+	status := &Status{Dir: c.Dir, TTL: c.TTL}
+
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return status, nil
+		}
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), entrySuffix) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		status.Entries++
+		status.Bytes += info.Size()
+		if time.Since(info.ModTime()) > c.TTL {
+			status.Expired++
+		}
+		if status.Oldest.IsZero() || info.ModTime().Before(status.Oldest) {
+			status.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(status.Newest) {
+			status.Newest = info.ModTime()
+		}
+	}
+
+	return status, nil
+}
+
+// ClearAll removes the cache of every instance
+func ClearAll() error {
+	root, err := Root()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCache_SetGet(t *testing.T) {
+	c := &FileCache{Dir: filepath.Join(t.TempDir(), "instance"), TTL: time.Hour}
+
+	_, ok := c.Get("https://example.atlassian.net/rest/api/3/field")
+	assert.False(t, ok)
+
+	require.NoError(t, c.Set("https://example.atlassian.net/rest/api/3/field", []byte(`[{"id":"summary"}]`)))
+
+	data, ok := c.Get("https://example.atlassian.net/rest/api/3/field")
+	require.True(t, ok)
+	assert.Equal(t, `[{"id":"summary"}]`, string(data))
+}
+
+func TestFileCache_Expiry(t *testing.T) {
+	c := &FileCache{Dir: t.TempDir(), TTL: time.Minute}
+	require.NoError(t, c.Set("key", []byte("value")))
+
+	// Age the entry past the TTL
+	old := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(c.entryPath("key"), old, old))
+
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+
+	status, err := c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 1, status.Entries)
+	assert.Equal(t, 1, status.Expired)
+}
+
+func TestFileCache_StatusAndClear(t *testing.T) {
+	c := &FileCache{Dir: filepath.Join(t.TempDir(), "instance"), TTL: time.Hour}
+
+	status, err := c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 0, status.Entries)
+
+	require.NoError(t, c.Set("a", []byte("12345")))
+	require.NoError(t, c.Set("b", []byte("123")))
+
+	status, err = c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 2, status.Entries)
+	assert.Equal(t, int64(8), status.Bytes)
+	assert.Equal(t, 0, status.Expired)
+
+	require.NoError(t, c.Clear())
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestNew_PerInstanceDirectories(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	a, err := New("https://one.atlassian.net", 0)
+	require.NoError(t, err)
+	b, err := New("https://two.atlassian.net", 0)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a.Dir, b.Dir)
+	assert.Equal(t, DefaultTTL, a.TTL)
+	assert.Equal(t, "one.atlassian.net", filepath.Base(a.Dir))
+}
+
+func TestInstanceDirName(t *testing.T) {
+	assert.Equal(t, "jira.corp.com_jira", instanceDirName("https://jira.corp.com/jira"))
+	assert.Equal(t, "example.atlassian.net", instanceDirName("https://example.atlassian.net"))
+	assert.Equal(t, "default", instanceDirName(""))
+}
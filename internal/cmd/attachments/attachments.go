@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/api"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
 )
 
 // Register registers the attachments commands
@@ -66,7 +68,7 @@ func runList(opts *root.Options, issueKey string) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(attachments)
 	}
 
@@ -74,7 +76,6 @@ func runList(opts *root.Options, issueKey string) error {
 	var rows [][]string
 
 	for _, att := range attachments {
-		created := formatDate(att.Created)
 		author := att.Author.DisplayName
 		if author == "" {
 			author = att.Author.AccountID
@@ -85,7 +86,7 @@ func runList(opts *root.Options, issueKey string) error {
 			att.Filename,
 			api.FormatFileSize(att.Size),
 			author,
-			created,
+			v.Time(att.Created.Time),
 		})
 	}
 
@@ -93,74 +94,143 @@ func runList(opts *root.Options, issueKey string) error {
 }
 
 func newAddCmd(opts *root.Options) *cobra.Command {
+	var upload uploadOptions
+
 	cmd := &cobra.Command{
 		Use:   "add <issue-key> --file <path>",
 		Short: "Add an attachment to an issue",
 		Long: `Upload a file as an attachment to a Jira issue.
 
-Multiple files can be attached by repeating the --file flag.`,
+Multiple files can be attached by repeating the --file flag. Use --file - to
+read the content from stdin; --name sets the attachment's filename.
+
+Directories are rejected unless --recursive is given, in which case every file
+below the directory is uploaded, named after its path within the directory
+(nested/b.log becomes nested_b.log). Add --zip to upload the directory as a
+single archive instead. Sizes are checked against the instance's upload limit
+before anything is sent. A file that fails to upload does not stop the others;
+the command reports every file and exits with an error naming the failed ones.`,
 		Example: `  # Add a single file
   jtk attachments add PROJ-123 --file document.pdf
 
   # Add multiple files
-  jtk attachments add PROJ-123 --file doc.pdf --file screenshot.png`,
+  jtk attachments add PROJ-123 --file doc.pdf --file screenshot.png
+
+  # Upload a build log from a pipe
+  make test 2>&1 | jtk attachments add PROJ-123 --file - --name test.log
+
+  # Upload every file in a directory
+  jtk attachments add PROJ-123 --file ./artifacts --recursive
+
+  # Upload a directory as one zip archive
+  jtk attachments add PROJ-123 --file ./artifacts --recursive --zip`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			files, _ := cmd.Flags().GetStringArray("file")
-			return runAdd(opts, args[0], files)
+			return runAdd(opts, args[0], upload)
 		},
 	}
 
-	cmd.Flags().StringArrayP("file", "f", nil, "File(s) to attach (can be repeated)")
+	cmd.Flags().StringArrayVarP(&upload.Files, "file", "f", nil, "File(s) to attach, or - for stdin (can be repeated)")
+	cmd.Flags().StringVar(&upload.Name, "name", "", "Attachment filename when reading from stdin")
+	cmd.Flags().BoolVarP(&upload.Recursive, "recursive", "r", false, "Upload the contents of directories")
+	cmd.Flags().BoolVar(&upload.Zip, "zip", false, "Zip each directory into a single archive before uploading (requires --recursive)")
 	cmd.MarkFlagRequired("file") //nolint:errcheck
 
 	return cmd
 }
 
-func runAdd(opts *root.Options, issueKey string, files []string) error {
+func runAdd(opts *root.Options, issueKey string, upload uploadOptions) error {
 	v := opts.View()
 
-	if len(files) == 0 {
+	if len(upload.Files) == 0 {
 		return fmt.Errorf("at least one file is required")
 	}
 
+	sources, cleanup, err := collectUploads(upload, opts.Stdin)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
 	client, err := opts.APIClient()
 	if err != nil {
 		return err
 	}
 
-	var allAttachments []api.Attachment
-	for _, file := range files {
-		v.Info("Uploading %s...", filepath.Base(file))
+	settings, err := client.GetAttachmentSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get attachment settings: %w", err)
+	}
+	if err := checkUploadLimits(sources, settings); err != nil {
+		return err
+	}
 
-		attachments, err := client.AddAttachment(issueKey, file)
+	var results []uploadResult
+	var uploaded, failed int
+	var totalSize int64
+	var failedNames []string
+	for _, src := range sources {
+		if !opts.DataOutput() {
+			v.Info("Uploading %s...", src.Name)
+		}
+
+		attachments, err := uploadItem(client, opts, issueKey, src, settings.UploadLimit)
 		if err != nil {
-			return fmt.Errorf("failed to upload %s: %w", file, err)
+			results = append(results, uploadResult{File: src.Name, Status: "failed", Error: err.Error()})
+			failed++
+			failedNames = append(failedNames, src.Name)
+			continue
 		}
 
-		allAttachments = append(allAttachments, attachments...)
+		for _, att := range attachments {
+			results = append(results, uploadResult{
+				File: src.Name, Status: "uploaded", ID: att.ID.String(), Filename: att.Filename, Size: att.Size,
+			})
+			totalSize += att.Size
+		}
+		uploaded++
 	}
 
-	if opts.Output == "json" {
-		return v.JSON(allAttachments)
+	if opts.DataOutput() {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Status == "failed" {
+				v.Error("Failed %s: %s", r.File, r.Error)
+				continue
+			}
+			v.Success("Added %s (ID: %s, %s)", r.Filename, r.ID, api.FormatFileSize(r.Size))
+		}
+		if len(sources) > 1 {
+			v.Info("Uploaded %d of %d files (%s) to %s", uploaded, len(sources), api.FormatFileSize(totalSize), issueKey)
+		}
 	}
 
-	for _, att := range allAttachments {
-		v.Success("Added %s (ID: %s, %s)", att.Filename, att.ID.String(), api.FormatFileSize(att.Size))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed to upload: %s", failed, len(sources), strings.Join(failedNames, ", "))
 	}
-
 	return nil
 }
 
 func newGetCmd(opts *root.Options) *cobra.Command {
 	var outputPath string
+	var all bool
+	var pattern string
+	var concurrency int
+	var skipExisting bool
 
 	cmd := &cobra.Command{
-		Use:   "get <attachment-id>",
-		Short: "Download an attachment",
-		Long: `Download an attachment by its ID.
+		Use:   "get <attachment-id> | <issue-key> --all",
+		Short: "Download attachments",
+		Long: `Download an attachment by its ID, or several attachments from an issue.
 
-The attachment ID can be found using 'jtk attachments list'.`,
+The attachment ID can be found using 'jtk attachments list'.
+
+With --all or --pattern, the argument is an issue key and every matching
+attachment on that issue is downloaded concurrently into the output directory.
+Use --output - to stream a single attachment to stdout.`,
 		Example: `  # Download to current directory
   jtk attachments get 12345
 
@@ -168,14 +238,35 @@ The attachment ID can be found using 'jtk attachments list'.`,
   jtk attachments get 12345 --output ./downloads/
 
   # Download with specific filename
-  jtk attachments get 12345 --output ./myfile.pdf`,
+  jtk attachments get 12345 --output ./myfile.pdf
+
+  # Stream to stdout
+  jtk attachments get 12345 -O - | less
+
+  # Download every attachment on an issue
+  jtk attachments get PROJ-123 --all --output ./downloads/
+
+  # Download only log files, skipping ones already on disk
+  jtk attachments get PROJ-123 --pattern '*.log' --skip-existing`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all || pattern != "" {
+				return runGetAll(opts, args[0], bulkDownloadOptions{
+					OutputDir:    outputPath,
+					Pattern:      pattern,
+					Concurrency:  concurrency,
+					SkipExisting: skipExisting,
+				})
+			}
 			return runGet(opts, args[0], outputPath)
 		},
 	}
 
-	cmd.Flags().StringVarP(&outputPath, "output", "O", ".", "Output path (directory or file)")
+	cmd.Flags().StringVarP(&outputPath, "output", "O", ".", "Output path (directory or file, - for stdout)")
+	cmd.Flags().BoolVar(&all, "all", false, "Download all attachments on the given issue")
+	cmd.Flags().StringVar(&pattern, "pattern", "", "Only download attachments whose filename matches this glob (implies --all)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of parallel downloads")
+	cmd.Flags().BoolVar(&skipExisting, "skip-existing", false, "Skip files that already exist locally with the same size")
 
 	return cmd
 }
@@ -194,18 +285,24 @@ func runGet(opts *root.Options, attachmentID, outputPath string) error {
 		return err
 	}
 
-	v.Info("Downloading %s (%s)...", attachment.Filename, api.FormatFileSize(attachment.Size))
-
-	if err := client.DownloadAttachment(attachment, outputPath); err != nil {
-		return err
+	if outputPath == stdioPath {
+		return client.DownloadAttachmentTo(attachment, opts.Stdout)
 	}
 
-	// Determine final output path for success message
+	v.Info("Downloading %s (%s)...", attachment.Filename, api.FormatFileSize(attachment.Size))
+
+	// Determine final output path
 	finalPath := outputPath
 	if isDirectory(outputPath) {
 		finalPath = filepath.Join(outputPath, attachment.Filename)
 	}
 
+	bar := progress.New(opts.Stderr, attachment.Filename, attachment.Size)
+	if err := downloadToFile(client, attachment, finalPath, bar); err != nil {
+		return err
+	}
+	bar.Finish()
+
 	v.Success("Downloaded to %s", finalPath)
 	return nil
 }
@@ -244,14 +341,6 @@ func runDelete(opts *root.Options, attachmentID string) error {
 	return nil
 }
 
-// formatDate extracts just the date portion from an ISO timestamp
-func formatDate(timestamp string) string {
-	if len(timestamp) >= 10 {
-		return timestamp[:10]
-	}
-	return timestamp
-}
-
 // isDirectory checks if a path is a directory
 func isDirectory(path string) bool {
 	info, err := os.Stat(path)
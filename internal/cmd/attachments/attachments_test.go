@@ -0,0 +1,65 @@
+package attachments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func TestRunAdd_ContinuesPastFailures(t *testing.T) {
+	dir := t.TempDir()
+	var files []string
+	for _, name := range []string{"a.log", "b.log", "c.log"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o644))
+		files = append(files, path)
+	}
+
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/attachment/meta":
+			w.Write([]byte(`{"enabled":true,"uploadLimit":1048576}`))
+		case "/rest/api/3/issue/PROJ-1/attachments":
+			_, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			if header.Filename == "b.log" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"errorMessages":["storage full"]}`))
+				return
+			}
+			uploads++
+			fmt.Fprintf(w, `[{"id":"%d","filename":%q,"size":5}]`, uploads, header.Filename)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	err := runAdd(opts, "PROJ-1", uploadOptions{Files: files})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 file(s) failed to upload: b.log")
+
+	var results []uploadResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, uploadResult{File: "a.log", Status: "uploaded", ID: "1", Filename: "a.log", Size: 5}, results[0])
+	assert.Equal(t, "failed", results[1].Status)
+	assert.Contains(t, results[1].Error, "storage full")
+	assert.Equal(t, "c.log", results[2].Filename)
+}
@@ -0,0 +1,237 @@
+package attachments
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+)
+
+// stdioPath is the path value that means stdout for --output and stdin for --file
+const stdioPath = "-"
+
+// bulkDownloadOptions controls downloading several attachments from one issue
+type bulkDownloadOptions struct {
+	OutputDir    string
+	Pattern      string
+	Concurrency  int
+	SkipExisting bool
+}
+
+// downloadResult records the outcome of downloading one attachment
+type downloadResult struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Status   string `json:"status"` // downloaded, skipped, failed
+	Error    string `json:"error,omitempty"`
+}
+
+func runGetAll(opts *root.Options, issueKey string, bulk bulkDownloadOptions) error {
+	v := opts.View()
+
+	if bulk.OutputDir == stdioPath {
+		return fmt.Errorf("cannot stream multiple attachments to stdout; use --output with a directory")
+	}
+	if bulk.Concurrency < 1 {
+		bulk.Concurrency = 1
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	attachments, err := client.GetIssueAttachments(issueKey)
+	if err != nil {
+		return err
+	}
+
+	attachments, err = filterAttachments(attachments, bulk.Pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(attachments) == 0 {
+		v.Info("No matching attachments found on %s", issueKey)
+		return nil
+	}
+
+	if err := os.MkdirAll(bulk.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	paths := localPaths(attachments, bulk.OutputDir)
+	results := make([]downloadResult, len(attachments))
+
+	var total int64
+	var pending []int
+	for i, att := range attachments {
+		results[i] = downloadResult{
+			ID:       att.ID.String(),
+			Filename: att.Filename,
+			Path:     paths[i],
+			Size:     att.Size,
+		}
+		if bulk.SkipExisting && existsWithSize(paths[i], att.Size) {
+			results[i].Status = "skipped"
+			continue
+		}
+		total += att.Size
+		pending = append(pending, i)
+	}
+
+	bar := progress.New(opts.Stderr, fmt.Sprintf("Downloading %d file(s)", len(pending)), total)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < bulk.Concurrency && w < len(pending); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := downloadToFile(client, &attachments[i], paths[i], bar); err != nil {
+					results[i].Status = "failed"
+					results[i].Error = err.Error()
+					continue
+				}
+				results[i].Status = "downloaded"
+			}
+		}()
+	}
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(pending) > 0 {
+		bar.Finish()
+	}
+
+	var downloaded, skipped, failed int
+	for _, r := range results {
+		switch r.Status {
+		case "downloaded":
+			downloaded++
+		case "skipped":
+			skipped++
+		case "failed":
+			failed++
+		}
+	}
+
+	if opts.DataOutput() {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch r.Status {
+			case "downloaded":
+				v.Success("Downloaded %s (%s)", r.Path, api.FormatFileSize(r.Size))
+			case "skipped":
+				v.Info("Skipped %s (already exists)", r.Path)
+			case "failed":
+				v.Error("Failed %s: %s", r.Filename, r.Error)
+			}
+		}
+		v.Info("%d downloaded, %d skipped, %d failed", downloaded, skipped, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d attachment(s) failed to download", failed, len(attachments))
+	}
+	return nil
+}
+
+// filterAttachments keeps attachments whose filename matches the glob pattern
+// and orders them by ID so that local names are stable between runs
+func filterAttachments(attachments []api.Attachment, pattern string) ([]api.Attachment, error) {
+	var filtered []api.Attachment
+	for _, att := range attachments {
+		if pattern != "" {
+			matched, err := filepath.Match(pattern, att.Filename)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		filtered = append(filtered, att)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return lessID(filtered[i].ID.String(), filtered[j].ID.String())
+	})
+
+	return filtered, nil
+}
+
+// lessID compares numeric IDs without parsing them
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// localPaths maps attachments to output file paths. Jira allows several
+// attachments with the same filename on one issue, so later duplicates are
+// prefixed with their attachment ID.
+func localPaths(attachments []api.Attachment, dir string) []string {
+	paths := make([]string, len(attachments))
+	seen := make(map[string]bool)
+	for i, att := range attachments {
+		name := filepath.Base(att.Filename)
+		if seen[strings.ToLower(name)] {
+			name = att.ID.String() + "-" + name
+		}
+		seen[strings.ToLower(name)] = true
+		paths[i] = filepath.Join(dir, name)
+	}
+	return paths
+}
+
+// existsWithSize reports whether path is a regular file of exactly size bytes
+func existsWithSize(path string, size int64) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Mode().IsRegular() && info.Size() == size
+}
+
+// downloadToFile downloads into a temporary file next to path and renames it
+// into place once the size has been verified, so an interrupted transfer never
+// leaves a truncated file that looks complete
+func downloadToFile(client *api.Client, attachment *api.Attachment, path string, bar *progress.Bar) error {
+	tmpPath := path + ".part"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	err = client.DownloadAttachmentTo(attachment, bar.Writer(file))
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(tmpPath) //nolint:errcheck
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath) //nolint:errcheck
+		return fmt.Errorf("failed to move file into place: %w", err)
+	}
+	return nil
+}
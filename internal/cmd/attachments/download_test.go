@@ -0,0 +1,59 @@
+package attachments
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+func TestFilterAttachments(t *testing.T) {
+	attachments := []api.Attachment{
+		{ID: "10010", Filename: "server.log"},
+		{ID: "9999", Filename: "client.log"},
+		{ID: "10001", Filename: "screenshot.png"},
+	}
+
+	filtered, err := filterAttachments(attachments, "*.log")
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "9999", filtered[0].ID.String())
+	assert.Equal(t, "10010", filtered[1].ID.String())
+
+	all, err := filterAttachments(attachments, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	_, err = filterAttachments(attachments, "[")
+	assert.Error(t, err)
+}
+
+func TestLocalPaths_DuplicateNames(t *testing.T) {
+	attachments := []api.Attachment{
+		{ID: "1", Filename: "image.png"},
+		{ID: "2", Filename: "image.png"},
+		{ID: "3", Filename: "notes.txt"},
+	}
+
+	paths := localPaths(attachments, "out")
+	assert.Equal(t, []string{
+		filepath.Join("out", "image.png"),
+		filepath.Join("out", "2-image.png"),
+		filepath.Join("out", "notes.txt"),
+	}, paths)
+}
+
+func TestExistsWithSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("12345"), 0o644))
+
+	assert.True(t, existsWithSize(path, 5))
+	assert.False(t, existsWithSize(path, 6))
+	assert.False(t, existsWithSize(filepath.Join(dir, "missing"), 5))
+	assert.False(t, existsWithSize(dir, 0))
+}
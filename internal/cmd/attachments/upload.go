@@ -0,0 +1,275 @@
+package attachments
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+)
+
+// uploadOptions holds the flags for attachments add
+type uploadOptions struct {
+	Files     []string
+	Name      string
+	Recursive bool
+	Zip       bool
+}
+
+// uploadSource is a single attachment to upload
+type uploadSource struct {
+	Name   string    // Attachment filename
+	Path   string    // Local path; empty when reading from Reader
+	Size   int64     // Size in bytes, or -1 when unknown (stdin)
+	Reader io.Reader // Content source when Path is empty
+}
+
+// collectUploads expands the --file arguments into individual uploads.
+// The returned cleanup function removes any temporary archives and must
+// always be called, even when an error is returned.
+func collectUploads(upload uploadOptions, stdin io.Reader) ([]uploadSource, func(), error) {
+	var sources []uploadSource
+	var tempFiles []string
+	cleanup := func() {
+		for _, f := range tempFiles {
+			os.Remove(f) //nolint:errcheck
+		}
+	}
+
+	if upload.Zip && !upload.Recursive {
+		return nil, cleanup, fmt.Errorf("--zip requires --recursive")
+	}
+
+	usedStdin := false
+	for _, file := range upload.Files {
+		if file == stdioPath {
+			if usedStdin {
+				return nil, cleanup, fmt.Errorf("stdin can only be used once")
+			}
+			if upload.Name == "" {
+				return nil, cleanup, fmt.Errorf("--name is required when reading from stdin")
+			}
+			usedStdin = true
+			sources = append(sources, uploadSource{Name: upload.Name, Size: -1, Reader: stdin})
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		if !info.IsDir() {
+			sources = append(sources, uploadSource{Name: filepath.Base(file), Path: file, Size: info.Size()})
+			continue
+		}
+
+		if !upload.Recursive {
+			return nil, cleanup, fmt.Errorf("%s is a directory (use --recursive to upload its contents)", file)
+		}
+
+		if upload.Zip {
+			archive, err := zipDirectory(file)
+			if archive != "" {
+				tempFiles = append(tempFiles, archive)
+			}
+			if err != nil {
+				return nil, cleanup, err
+			}
+			archiveInfo, err := os.Stat(archive)
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("failed to read archive: %w", err)
+			}
+			sources = append(sources, uploadSource{
+				Name: archiveName(file),
+				Path: archive,
+				Size: archiveInfo.Size(),
+			})
+			continue
+		}
+
+		files, err := walkFiles(file)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		sources = append(sources, files...)
+	}
+
+	if upload.Name != "" && !usedStdin {
+		return nil, cleanup, fmt.Errorf("--name can only be used with --file -")
+	}
+
+	return sources, cleanup, nil
+}
+
+// uploadResult records the outcome of uploading one file
+type uploadResult struct {
+	File     string `json:"file"`
+	Status   string `json:"status"` // uploaded, failed
+	ID       string `json:"id,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// walkFiles lists every regular file below dir. Each is named after its path
+// relative to dir with separators replaced by underscores, so logs/a.log
+// becomes logs_a.log; names that still collide are an error.
+func walkFiles(dir string) ([]uploadSource, error) {
+	var sources []uploadSource
+	paths := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := strings.ReplaceAll(filepath.ToSlash(rel), "/", "_")
+		if other, ok := paths[name]; ok {
+			return fmt.Errorf("%s and %s would both be attached as %s", other, path, name)
+		}
+		paths[name] = path
+		sources = append(sources, uploadSource{Name: name, Path: path, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("directory %s contains no files", dir)
+	}
+	return sources, nil
+}
+
+// archiveName returns the attachment name for a zipped directory
+func archiveName(dir string) string {
+	name := filepath.Base(filepath.Clean(dir))
+	if name == "." || name == string(filepath.Separator) {
+		name = "archive"
+	}
+	return name + ".zip"
+}
+
+// zipDirectory writes dir into a temporary zip archive and returns its path.
+// The archive is built on disk so its final size is known before uploading.
+func zipDirectory(dir string) (string, error) {
+	tmp, err := os.CreateTemp("", "jtk-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return tmp.Name(), fmt.Errorf("failed to archive %s: %w", dir, err)
+	}
+	if err := zw.Close(); err != nil {
+		return tmp.Name(), fmt.Errorf("failed to archive %s: %w", dir, err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// checkUploadLimits rejects the whole batch up front if attachments are
+// disabled or any known file size exceeds the instance's upload limit
+func checkUploadLimits(sources []uploadSource, settings *api.AttachmentSettings) error {
+	if !settings.Enabled {
+		return fmt.Errorf("attachments are disabled on this Jira instance")
+	}
+	if settings.UploadLimit <= 0 {
+		return nil
+	}
+
+	var tooLarge []string
+	for _, src := range sources {
+		if src.Size > settings.UploadLimit {
+			tooLarge = append(tooLarge, fmt.Sprintf("%s (%s)", src.Name, api.FormatFileSize(src.Size)))
+		}
+	}
+	if len(tooLarge) > 0 {
+		return fmt.Errorf("files exceed the upload limit of %s: %s",
+			api.FormatFileSize(settings.UploadLimit), strings.Join(tooLarge, ", "))
+	}
+	return nil
+}
+
+// uploadItem uploads a single source, drawing a progress bar on stderr
+func uploadItem(client *api.Client, opts *root.Options, issueKey string, src uploadSource, limit int64) ([]api.Attachment, error) {
+	reader := src.Reader
+	if src.Path != "" {
+		f, err := os.Open(src.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	// Stream sizes are unknown up front, so enforce the limit while reading
+	if src.Size < 0 && limit > 0 {
+		reader = &limitedReader{r: reader, remaining: limit, limit: limit}
+	}
+
+	bar := progress.New(opts.Stderr, src.Name, src.Size)
+	attachments, err := client.AddAttachmentReader(issueKey, src.Name, bar.Reader(reader))
+	bar.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// limitedReader fails once more than limit bytes have been read
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, fmt.Errorf("input exceeds the upload limit of %s", api.FormatFileSize(l.limit))
+	}
+	return n, err
+}
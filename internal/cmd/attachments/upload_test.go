@@ -0,0 +1,110 @@
+package attachments
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+func writeTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "logs", "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "a.log"), []byte("aaa"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "nested", "b.log"), []byte("bbbbb"), 0o644))
+	return filepath.Join(dir, "logs")
+}
+
+func TestCollectUploads_Stdin(t *testing.T) {
+	sources, cleanup, err := collectUploads(uploadOptions{Files: []string{"-"}, Name: "ci.log"}, strings.NewReader("data"))
+	defer cleanup()
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "ci.log", sources[0].Name)
+	assert.Equal(t, int64(-1), sources[0].Size)
+	assert.NotNil(t, sources[0].Reader)
+}
+
+func TestCollectUploads_StdinRequiresName(t *testing.T) {
+	_, cleanup, err := collectUploads(uploadOptions{Files: []string{"-"}}, strings.NewReader(""))
+	defer cleanup()
+	assert.ErrorContains(t, err, "--name")
+}
+
+func TestCollectUploads_DirectoryRequiresRecursive(t *testing.T) {
+	dir := writeTree(t)
+	_, cleanup, err := collectUploads(uploadOptions{Files: []string{dir}}, nil)
+	defer cleanup()
+	assert.ErrorContains(t, err, "is a directory")
+}
+
+func TestCollectUploads_Recursive(t *testing.T) {
+	dir := writeTree(t)
+	sources, cleanup, err := collectUploads(uploadOptions{Files: []string{dir}, Recursive: true}, nil)
+	defer cleanup()
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "a.log", sources[0].Name)
+	assert.Equal(t, int64(3), sources[0].Size)
+	assert.Equal(t, "nested_b.log", sources[1].Name)
+}
+
+func TestCollectUploads_RecursiveNameCollision(t *testing.T) {
+	dir := writeTree(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested_b.log"), []byte("b"), 0o644))
+
+	_, cleanup, err := collectUploads(uploadOptions{Files: []string{dir}, Recursive: true}, nil)
+	defer cleanup()
+	assert.ErrorContains(t, err, "would both be attached as nested_b.log")
+}
+
+func TestCollectUploads_Zip(t *testing.T) {
+	dir := writeTree(t)
+	sources, cleanup, err := collectUploads(uploadOptions{Files: []string{dir}, Recursive: true, Zip: true}, nil)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "logs.zip", sources[0].Name)
+
+	zr, err := zip.OpenReader(sources[0].Path)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	zr.Close()
+	assert.ElementsMatch(t, []string{"a.log", "nested/b.log"}, names)
+
+	cleanup()
+	_, err = os.Stat(sources[0].Path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCheckUploadLimits(t *testing.T) {
+	sources := []uploadSource{
+		{Name: "small.txt", Size: 10},
+		{Name: "huge.bin", Size: 2048},
+		{Name: "stdin", Size: -1},
+	}
+
+	err := checkUploadLimits(sources, &api.AttachmentSettings{Enabled: true, UploadLimit: 1024})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "huge.bin")
+	assert.NotContains(t, err.Error(), "small.txt")
+
+	assert.NoError(t, checkUploadLimits(sources[:1], &api.AttachmentSettings{Enabled: true, UploadLimit: 1024}))
+	assert.ErrorContains(t, checkUploadLimits(sources[:1], &api.AttachmentSettings{Enabled: false}), "disabled")
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("0123456789"), remaining: 5, limit: 5}
+	buf := make([]byte, 10)
+	_, err := r.Read(buf)
+	assert.ErrorContains(t, err, "upload limit")
+}
@@ -19,6 +19,7 @@ func Register(parent *cobra.Command, opts *root.Options) {
 
 	cmd.AddCommand(newListCmd(opts))
 	cmd.AddCommand(newGetCmd(opts))
+	cmd.AddCommand(newVelocityCmd(opts))
 
 	parent.AddCommand(cmd)
 }
@@ -65,7 +66,7 @@ func runList(opts *root.Options, project string, maxResults int) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(result.Values)
 	}
 
@@ -114,7 +115,7 @@ func runGet(opts *root.Options, boardID int) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(board)
 	}
 
@@ -0,0 +1,321 @@
+package boards
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// velocityChartWidth is the width of the bars in the velocity chart
+const velocityChartWidth = 30
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// velocityOptions holds the flags of boards velocity
+type velocityOptions struct {
+	Sprints     int
+	PointsField string
+	Max         int
+	Concurrency int
+}
+
+// velocityReport is the JSON form of a velocity report
+type velocityReport struct {
+	BoardID     int              `json:"boardId"`
+	PointsField string           `json:"pointsField,omitempty"`
+	Sprints     []velocitySprint `json:"sprints"`
+	Average     float64          `json:"average"`
+	StdDev      float64          `json:"stdDev"`
+}
+
+// velocitySprint is the work committed to and completed in one sprint
+type velocitySprint struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	StartDate    *time.Time `json:"startDate,omitempty"`
+	CompleteDate *time.Time `json:"completeDate,omitempty"`
+	Committed    float64    `json:"committed"`
+	Completed    float64    `json:"completed"`
+	// Truncated is set when --max was reached, so issues may be missing
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func newVelocityCmd(opts *root.Options) *cobra.Command {
+	var velocity velocityOptions
+
+	cmd := &cobra.Command{
+		Use:   "velocity <board-id>",
+		Short: "Show committed and completed story points of recent sprints",
+		Long: `Show the story points committed at the start of each of a board's last
+closed sprints and the points completed by its end, with the average and
+standard deviation of the completed points.
+
+Committed work includes issues later removed from the sprint; completed work
+includes issues added after it started. The story points field is found by
+name; use --points-field when your site names it differently. Without story
+points, issues are counted instead.`,
+		Example: `  # The last six sprints
+  jtk boards velocity 123
+
+  # The last ten, as JSON
+  jtk boards velocity 123 --sprints 10 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var boardID int
+			if _, err := fmt.Sscanf(args[0], "%d", &boardID); err != nil {
+				return fmt.Errorf("invalid board ID: %s", args[0])
+			}
+			return runVelocity(opts, boardID, velocity)
+		},
+	}
+
+	cmd.Flags().IntVar(&velocity.Sprints, "sprints", 6, "Number of closed sprints to include")
+	cmd.Flags().StringVar(&velocity.PointsField, "points-field", "", "Story points field name or ID (default: found automatically)")
+	cmd.Flags().IntVarP(&velocity.Max, "max", "m", 500, "Maximum number of issues to analyze per sprint")
+	cmd.Flags().IntVar(&velocity.Concurrency, "concurrency", 4, "Number of changelogs to fetch in parallel")
+
+	return cmd
+}
+
+func runVelocity(opts *root.Options, boardID int, vo velocityOptions) error {
+	v := opts.View()
+
+	if vo.Sprints < 1 {
+		return fmt.Errorf("--sprints must be at least 1")
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	sprints, err := closedSprints(client, boardID, vo.Sprints)
+	if err != nil {
+		return err
+	}
+	if len(sprints) == 0 {
+		v.Info("No closed sprints found")
+		return nil
+	}
+
+	points, err := client.StoryPointsField(vo.PointsField)
+	if err != nil {
+		return err
+	}
+	if points == nil && !opts.DataOutput() {
+		v.Warning("No story points field found; counting issues instead (use --points-field to name it)")
+	}
+	pointsID := ""
+	if points != nil {
+		pointsID = points.ID
+	}
+
+	statuses, err := client.GetStatuses()
+	if err != nil {
+		return err
+	}
+	categories := api.NewStatusCategories(statuses)
+
+	report := velocityReport{BoardID: boardID, Sprints: []velocitySprint{}}
+	if points != nil {
+		report.PointsField = points.Name
+	}
+
+	bar := progress.NewCount(opts.Stderr, fmt.Sprintf("Analyzing %d sprint(s)", len(sprints)), len(sprints))
+	for i := range sprints {
+		histories, truncated, err := client.SprintHistories(&sprints[i], pointsID, vo.Max, vo.Concurrency)
+		if err != nil {
+			bar.Finish()
+			return fmt.Errorf("failed to analyze sprint %s: %w", sprints[i].Name, err)
+		}
+		sprint := measureSprint(sprints[i], histories, categories)
+		sprint.Truncated = truncated
+		report.Sprints = append(report.Sprints, sprint)
+		bar.Add(1)
+	}
+	bar.Finish()
+
+	var truncated []string
+	for _, s := range report.Sprints {
+		if s.Truncated {
+			truncated = append(truncated, s.Name)
+		}
+	}
+	if len(truncated) > 0 {
+		v.Warning("Stopped at --max %d issues in %s; the figures may be incomplete", vo.Max, strings.Join(truncated, ", "))
+	}
+
+	completed := make([]float64, len(report.Sprints))
+	for i, s := range report.Sprints {
+		completed[i] = s.Completed
+	}
+	report.Average, report.StdDev = meanStdDev(completed)
+
+	if opts.DataOutput() {
+		return v.JSON(report)
+	}
+	return renderVelocity(v, report, points != nil)
+}
+
+// closedSprints returns the last n closed sprints of a board that started,
+// oldest first
+func closedSprints(client *api.Client, boardID, n int) ([]api.Sprint, error) {
+	var sprints []api.Sprint
+	for startAt := 0; ; {
+		result, err := client.ListSprints(boardID, "closed", startAt, 50)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range result.Values {
+			if s.StartDate != nil {
+				sprints = append(sprints, s)
+			}
+		}
+
+		startAt += len(result.Values)
+		if len(result.Values) == 0 || result.IsLast {
+			break
+		}
+	}
+
+	if len(sprints) > n {
+		sprints = sprints[len(sprints)-n:]
+	}
+	return sprints, nil
+}
+
+// measureSprint sums the points in the sprint when it started and the points
+// done when it was completed
+func measureSprint(sprint api.Sprint, histories []*api.SprintHistory, categories api.StatusCategories) velocitySprint {
+	start := *sprint.StartDate
+	end := time.Now()
+	switch {
+	case sprint.CompleteDate != nil:
+		end = *sprint.CompleteDate
+	case sprint.EndDate != nil:
+		end = *sprint.EndDate
+	}
+
+	result := velocitySprint{
+		ID:           sprint.ID,
+		Name:         sprint.Name,
+		StartDate:    sprint.StartDate,
+		CompleteDate: sprint.CompleteDate,
+	}
+	for _, h := range histories {
+		if h.MemberAt(start) {
+			result.Committed += h.PointsAt(start)
+		}
+		if h.MemberAt(end) && h.DoneAt(end, categories) {
+			result.Completed += h.PointsAt(end)
+		}
+	}
+	return result
+}
+
+// meanStdDev returns the mean and population standard deviation of values
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range values {
+		sum += x
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, x := range values {
+		squares += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+func renderVelocity(v *view.View, report velocityReport, hasPoints bool) error {
+	headers := []string{"SPRINT", "COMPLETED ON", "COMMITTED", "COMPLETED"}
+	chart := v.Format == view.FormatTable
+	if chart {
+		headers = append(headers, "")
+	}
+
+	top := 0.0
+	for _, s := range report.Sprints {
+		top = math.Max(top, math.Max(s.Committed, s.Completed))
+	}
+
+	rows := make([][]string, len(report.Sprints))
+	for i, s := range report.Sprints {
+		completedOn := "-"
+		if s.CompleteDate != nil {
+			completedOn = v.Time(*s.CompleteDate)
+		}
+		rows[i] = []string{s.Name, completedOn, view.Number(s.Committed), view.Number(s.Completed)}
+		if chart {
+			rows[i] = append(rows[i], velocityBar(s.Committed, s.Completed, top))
+		}
+	}
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	if !chart {
+		return nil
+	}
+
+	unit := "issues"
+	if hasPoints {
+		unit = "points"
+	}
+	completed := make([]float64, len(report.Sprints))
+	for i, s := range report.Sprints {
+		completed[i] = s.Completed
+	}
+	v.Println("\nCompleted: %s", sparkline(completed))
+	v.Println("Average:   %s %s", view.Number(round1(report.Average)), unit)
+	v.Println("Std dev:   %s %s", view.Number(round1(report.StdDev)), unit)
+	return nil
+}
+
+// velocityBar draws the completed work as '#', followed by '-' for the rest
+// of the committed work
+func velocityBar(committed, completed, top float64) string {
+	if top == 0 {
+		return ""
+	}
+	scale := func(x float64) int {
+		return int(math.Round(x / top * velocityChartWidth))
+	}
+	done := scale(completed)
+	return strings.Repeat("#", done) + strings.Repeat("-", max(0, scale(committed)-done))
+}
+
+// sparkline draws values as a row of blocks scaled from zero to the largest
+func sparkline(values []float64) string {
+	top := 0.0
+	for _, x := range values {
+		top = math.Max(top, x)
+	}
+
+	var sb strings.Builder
+	for _, x := range values {
+		level := 0
+		if top > 0 {
+			level = int(math.Round(x / top * float64(len(sparkBlocks)-1)))
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+func round1(x float64) float64 {
+	return math.Round(x*10) / 10
+}
@@ -0,0 +1,135 @@
+package boards
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+func velocityIssue(key, statusID string, points float64) string {
+	return `{"key":"` + key + `","fields":{"summary":"` + key + `","project":{"key":"PROJ"},` +
+		`"created":"2024-01-01T00:00:00.000+0000","status":{"id":"` + statusID + `"},"customfield_10016":` +
+		view.Number(points) + `},"changelog":{"total":0,"histories":[]}}`
+}
+
+func newVelocityTestServer(t *testing.T) *httptest.Server {
+	sprint := func(id, name, start, complete string) string {
+		s := `{"id":` + id + `,"name":"` + name + `","state":"closed"`
+		if start != "" {
+			s += `,"startDate":"` + start + `T09:00:00.000Z","endDate":"` + complete + `T17:00:00.000Z","completeDate":"` + complete + `T17:00:00.000Z"`
+		}
+		return s + `}`
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board/12/sprint":
+			assert.Equal(t, "closed", r.URL.Query().Get("state"))
+			if r.URL.Query().Get("startAt") == "" {
+				w.Write([]byte(`{"isLast":false,"values":[` + sprint("1", "Sprint 1", "2024-01-01", "2024-01-12") + `,` +
+					sprint("9", "Cancelled", "", "") + `]}`))
+				return
+			}
+			w.Write([]byte(`{"isLast":true,"values":[` + sprint("2", "Sprint 2", "2024-01-15", "2024-01-26") + `,` +
+				sprint("3", "Sprint 3", "2024-01-29", "2024-02-09") + `]}`))
+		case "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case "/rest/api/3/status":
+			w.Write([]byte(`[{"id":"1","name":"To Do","statusCategory":{"key":"new"}},{"id":"10","name":"Done","statusCategory":{"key":"done"}}]`))
+		case "/rest/agile/1.0/sprint/1/issue":
+			t.Error("sprint 1 is older than the last two")
+		case "/rest/agile/1.0/sprint/2/issue":
+			w.Write([]byte(`{"total":2,"issues":[` + velocityIssue("PROJ-1", "10", 5) + `,` + velocityIssue("PROJ-2", "1", 3) + `]}`))
+		case "/rest/agile/1.0/sprint/3/issue":
+			w.Write([]byte(`{"total":2,"issues":[` + velocityIssue("PROJ-3", "10", 8) + `,` + velocityIssue("PROJ-4", "10", 2) + `]}`))
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"total":0,"issues":[]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunVelocity_JSON(t *testing.T) {
+	server := newVelocityTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runVelocity(opts, 12, velocityOptions{Sprints: 2, Max: 100}))
+
+	var report velocityReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+
+	assert.Equal(t, "Story Points", report.PointsField)
+	require.Len(t, report.Sprints, 2)
+	assert.Equal(t, "Sprint 2", report.Sprints[0].Name)
+	assert.Equal(t, 8.0, report.Sprints[0].Committed)
+	assert.Equal(t, 5.0, report.Sprints[0].Completed)
+	assert.Equal(t, "Sprint 3", report.Sprints[1].Name)
+	assert.Equal(t, 10.0, report.Sprints[1].Committed)
+	assert.Equal(t, 10.0, report.Sprints[1].Completed)
+	assert.Equal(t, 7.5, report.Average)
+	assert.Equal(t, 2.5, report.StdDev)
+}
+
+func TestRunVelocity_Table(t *testing.T) {
+	server := newVelocityTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	require.NoError(t, runVelocity(cmdtest.Options(server, &stdout), 12, velocityOptions{Sprints: 2, Max: 100}))
+
+	out := stdout.String()
+	assert.Contains(t, out, strings.Repeat("#", 15)+strings.Repeat("-", 9)+"\n")
+	assert.Contains(t, out, strings.Repeat("#", 30)+"\n")
+	assert.Contains(t, out, "Completed: ▅█\n")
+	assert.Contains(t, out, "Average:   7.5 points\n")
+	assert.Contains(t, out, "Std dev:   2.5 points\n")
+}
+
+func TestRunVelocity_Truncated(t *testing.T) {
+	server := newVelocityTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runVelocity(opts, 12, velocityOptions{Sprints: 2, Max: 2}))
+
+	var report velocityReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	require.Len(t, report.Sprints, 2)
+	assert.True(t, report.Sprints[0].Truncated)
+	assert.True(t, report.Sprints[1].Truncated)
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Stopped at --max 2 issues in Sprint 2, Sprint 3")
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▂█", sparkline([]float64{0, 2, 10}))
+	assert.Equal(t, "▁▁", sparkline([]float64{0, 0}))
+	assert.Equal(t, "", sparkline(nil))
+}
+
+func TestMeanStdDev(t *testing.T) {
+	mean, sd := meanStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.Equal(t, 5.0, mean)
+	assert.Equal(t, 2.0, sd)
+
+	mean, sd = meanStdDev(nil)
+	assert.Zero(t, mean)
+	assert.Zero(t, sd)
+}
@@ -0,0 +1,129 @@
+package cachecmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cache"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/config"
+)
+
+// Register registers the cache commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local metadata cache",
+		Long: `Commands for inspecting and clearing the local metadata cache.
+
+jtk caches rarely-changing metadata (fields, issue types, statuses, priorities,
+link types and create metadata) per Jira instance. Entries expire after the
+TTL set by cache_ttl in the config file or JIRA_CACHE_TTL (default 1h).
+Use --no-cache on any command to bypass the cache.`,
+	}
+
+	cmd.AddCommand(newStatusCmd(opts))
+	cmd.AddCommand(newClearCmd(opts))
+
+	parent.AddCommand(cmd)
+}
+
+// instanceCache returns the cache for the configured Jira instance
+func instanceCache() (*cache.FileCache, error) {
+	url := config.GetURL()
+	if url == "" {
+		return nil, fmt.Errorf("no Jira URL configured; run 'jtk init' first")
+	}
+	return cache.New(url, config.GetCacheTTL())
+}
+
+func newStatusCmd(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show cache status",
+		Long:  "Show the location, size and freshness of the cache for the configured Jira instance.",
+		Example: `  jtk cache status
+  jtk cache status -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := opts.View()
+
+			c, err := instanceCache()
+			if err != nil {
+				return err
+			}
+
+			status, err := c.Status()
+			if err != nil {
+				return err
+			}
+
+			if opts.DataOutput() {
+				return v.JSON(status)
+			}
+
+			headers := []string{"KEY", "VALUE"}
+			rows := [][]string{
+				{"dir", status.Dir},
+				{"ttl", status.TTL.String()},
+				{"entries", fmt.Sprintf("%d", status.Entries)},
+				{"expired", fmt.Sprintf("%d", status.Expired)},
+				{"size", api.FormatFileSize(status.Bytes)},
+				{"oldest", formatTime(status.Oldest)},
+				{"newest", formatTime(status.Newest)},
+			}
+
+			return v.Table(headers, rows)
+		},
+	}
+}
+
+func newClearCmd(opts *root.Options) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clear cached metadata",
+		Long:  "Remove cached metadata for the configured Jira instance, or for every instance with --all.",
+		Example: `  # Clear the cache for the current instance
+  jtk cache clear
+
+  # Clear the cache for all instances
+  jtk cache clear --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := opts.View()
+
+			if all {
+				if err := cache.ClearAll(); err != nil {
+					return err
+				}
+				v.Success("Cache cleared for all instances")
+				return nil
+			}
+
+			c, err := instanceCache()
+			if err != nil {
+				return err
+			}
+			if err := c.Clear(); err != nil {
+				return err
+			}
+
+			v.Success("Cache cleared (%s)", c.Dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Clear the cache for every Jira instance")
+
+	return cmd
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,32 @@
+// Package cmdtest provides helpers for testing commands against a fake Jira
+// server
+package cmdtest
+
+import (
+	"bytes"
+	"net/http/httptest"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+)
+
+// Options returns options that send API requests to server, print tables in
+// UTC to stdout and collect stderr in a buffer. Issue links point at
+// https://example.atlassian.net.
+func Options(server *httptest.Server, stdout *bytes.Buffer) *root.Options {
+	opts := &root.Options{
+		Output: "table",
+		TZ:     "UTC",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetAPIClient(&api.Client{
+		URL:        "https://example.atlassian.net",
+		BaseURL:    server.URL + "/rest/api/3",
+		AgileURL:   server.URL + "/rest/agile/1.0",
+		Email:      "test@example.com",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	})
+	return opts
+}
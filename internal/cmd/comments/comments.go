@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
 )
 
 // Register registers the comments commands
@@ -59,7 +60,7 @@ func runList(opts *root.Options, issueKey string, maxResults int) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(result.Comments)
 	}
 
@@ -69,16 +70,13 @@ func runList(opts *root.Options, issueKey string, maxResults int) error {
 	for _, c := range result.Comments {
 		body := ""
 		if c.Body != nil {
-			body = c.Body.ToPlainText()
-			if len(body) > 50 {
-				body = body[:50] + "..."
-			}
+			body = view.Truncate(c.Body.ToPlainText(), 50)
 		}
 
 		rows = append(rows, []string{
 			c.ID,
 			c.Author.DisplayName,
-			formatTime(c.Created),
+			v.Time(c.Created.Time),
 			body,
 		})
 	}
@@ -119,7 +117,7 @@ func runAdd(opts *root.Options, issueKey, body string) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(comment)
 	}
 
@@ -154,18 +152,10 @@ func runDelete(opts *root.Options, issueKey, commentID string) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(map[string]string{"status": "deleted", "commentId": commentID})
 	}
 
 	v.Success("Deleted comment %s from %s", commentID, issueKey)
 	return nil
 }
-
-func formatTime(t string) string {
-	// Jira returns ISO 8601 format, just show date
-	if len(t) >= 10 {
-		return t[:10]
-	}
-	return t
-}
@@ -2,6 +2,7 @@ package configcmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -26,18 +27,21 @@ func Register(parent *cobra.Command, opts *root.Options) {
 }
 
 func newSetCmd(opts *root.Options) *cobra.Command {
-	var url, email, token string
+	var url, email, token, columns string
 
 	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "Set configuration values",
-		Long:  "Set Jira credentials. All values are required.",
+		Long:  "Set Jira credentials and preferences. Only the given values are changed.",
 		Example: `  # Set all credentials (Jira Cloud)
   jtk config set --url https://mycompany.atlassian.net --email user@example.com --token YOUR_API_TOKEN
 
   # Self-hosted Jira
   jtk config set --url https://jira.internal.corp.com --email user@example.com --token YOUR_API_TOKEN
 
+  # Default columns for issue listings
+  jtk config set --columns key,priority,status,assignee,updated,summary
+
   # Using environment variables instead
   export JIRA_URL=https://mycompany.atlassian.net
   export JIRA_EMAIL=user@example.com
@@ -60,6 +64,9 @@ func newSetCmd(opts *root.Options) *cobra.Command {
 			if token != "" {
 				cfg.APIToken = token
 			}
+			if cmd.Flags().Changed("columns") {
+				cfg.Columns = strings.Join(config.SplitList(columns), ",")
+			}
 
 			if err := config.Save(cfg); err != nil {
 				return err
@@ -73,6 +80,7 @@ func newSetCmd(opts *root.Options) *cobra.Command {
 	cmd.Flags().StringVar(&url, "url", "", "Jira URL (e.g., 'https://mycompany.atlassian.net' or 'https://jira.internal.corp.com')")
 	cmd.Flags().StringVar(&email, "email", "", "Email address for authentication")
 	cmd.Flags().StringVar(&token, "token", "", "API token (create at https://id.atlassian.com/manage-profile/security/api-tokens)")
+	cmd.Flags().StringVar(&columns, "columns", "", "Default columns for issue lists (comma-separated; empty to reset)")
 
 	return cmd
 }
@@ -104,12 +112,14 @@ func newShowCmd(opts *root.Options) *cobra.Command {
 				{"url", url, getURLSource()},
 				{"email", email, getEmailSource()},
 				{"api_token", maskedToken, getAPITokenSource()},
+				{"columns", strings.Join(config.GetColumns(), ","), getColumnsSource()},
 			}
 
 			data := map[string]string{
 				"url":       url,
 				"email":     email,
 				"api_token": maskedToken,
+				"columns":   strings.Join(config.GetColumns(), ","),
 				"path":      config.Path(),
 			}
 
@@ -199,6 +209,20 @@ func getAPITokenSource() string {
 	return "-"
 }
 
+func getColumnsSource() string {
+	if os.Getenv("JIRA_COLUMNS") != "" {
+		return "env (JIRA_COLUMNS)"
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return "-"
+	}
+	if cfg.Columns != "" {
+		return "config"
+	}
+	return "default"
+}
+
 func newTestCmd(opts *root.Options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "test",
@@ -0,0 +1,337 @@
+package gitcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/git"
+)
+
+// maxSlugLength bounds the summary part of branch names
+const maxSlugLength = 50
+
+// branchOptions holds the flags of git branch
+type branchOptions struct {
+	Prefix     string
+	Start      bool
+	Status     string
+	NoCheckout bool
+}
+
+// branchResult describes the outcome of git branch
+type branchResult struct {
+	Key        string `json:"key"`
+	Branch     string `json:"branch"`
+	Created    bool   `json:"created"`
+	CheckedOut bool   `json:"checkedOut"`
+	Status     string `json:"status,omitempty"`
+}
+
+// currentOptions holds the flags of git current
+type currentOptions struct {
+	Commits int
+	KeyOnly bool
+	Offline bool
+}
+
+// Register registers the git commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: "Connect git branches and commits to issues",
+		Long: `Commands for working on issues in a git repository.
+
+Issue keys are read from branch names and commit messages, so branches created
+with 'jtk git branch' and commits mentioning a key are linked to their issue.
+All commands run the git binary in the current repository.`,
+	}
+
+	cmd.AddCommand(newBranchCmd(opts))
+	cmd.AddCommand(newCurrentCmd(opts))
+	cmd.AddCommand(newHookCmd(opts))
+
+	parent.AddCommand(cmd)
+}
+
+func newBranchCmd(opts *root.Options) *cobra.Command {
+	var branch branchOptions
+
+	cmd := &cobra.Command{
+		Use:   "branch <issue-key>",
+		Short: "Create a branch for an issue",
+		Long: `Create and check out a branch named after an issue, such as
+feature/PROJ-123-fix-login-redirect. When the branch already exists it is
+checked out instead.
+
+With --start, the issue is also moved to In Progress (or the status given
+with --status) when a transition to it is available.`,
+		Example: `  # Create feature/PROJ-123-<summary>
+  jtk git branch PROJ-123
+
+  # Create bugfix/PROJ-123-<summary> and start work on the issue
+  jtk git branch PROJ-123 --prefix bugfix --start`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := git.Open("")
+			if err != nil {
+				return err
+			}
+			return runBranch(opts, repo, args[0], branch)
+		},
+	}
+
+	cmd.Flags().StringVar(&branch.Prefix, "prefix", "feature", "Branch name prefix; empty for none")
+	cmd.Flags().BoolVar(&branch.Start, "start", false, "Transition the issue to --status")
+	cmd.Flags().StringVar(&branch.Status, "status", "In Progress", "Status to move the issue to with --start")
+	cmd.Flags().BoolVar(&branch.NoCheckout, "no-checkout", false, "Create the branch without checking it out")
+
+	return cmd
+}
+
+func runBranch(opts *root.Options, repo *git.Repo, issueKey string, branch branchOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.GetIssue(issueKey)
+	if err != nil {
+		return err
+	}
+
+	result := branchResult{
+		Key:        issue.Key,
+		Branch:     branchName(branch.Prefix, issue.Key, issue.Fields.Summary),
+		CheckedOut: !branch.NoCheckout,
+	}
+
+	if repo.BranchExists(result.Branch) {
+		if !branch.NoCheckout {
+			if err := repo.Checkout(result.Branch); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := repo.CreateBranch(result.Branch, !branch.NoCheckout); err != nil {
+			return err
+		}
+		result.Created = true
+	}
+
+	if !opts.DataOutput() {
+		switch {
+		case result.Created && result.CheckedOut:
+			v.Success("Created and switched to branch %s", result.Branch)
+		case result.Created:
+			v.Success("Created branch %s", result.Branch)
+		case result.CheckedOut:
+			v.Success("Switched to existing branch %s", result.Branch)
+		default:
+			v.Info("Branch %s already exists", result.Branch)
+		}
+	}
+
+	if branch.Start {
+		from, err := startIssue(client, issue, branch.Status)
+		if err != nil {
+			return err
+		}
+		result.Status = branch.Status
+		if !opts.DataOutput() {
+			if from == "" {
+				v.Info("%s is already in %s", issue.Key, issue.Fields.Status.Name)
+			} else {
+				v.Success("%s: %s -> %s", issue.Key, from, branch.Status)
+			}
+		}
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(result)
+	}
+	return nil
+}
+
+// branchName builds "<prefix>/<KEY>-<summary-slug>"
+func branchName(prefix, key, summary string) string {
+	name := key
+	if slug := git.Slug(summary, maxSlugLength); slug != "" {
+		name += "-" + slug
+	}
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		name = prefix + "/" + name
+	}
+	return name
+}
+
+// startIssue moves an issue to a status with a single transition, matched by
+// transition name or target status. It returns the status the issue was in,
+// or "" when it already was in the target status.
+func startIssue(client *api.Client, issue *api.Issue, status string) (string, error) {
+	if issue.Fields.Status != nil && api.StatusMatches(*issue.Fields.Status, status) {
+		return "", nil
+	}
+
+	transitions, err := client.GetTransitions(issue.Key)
+	if err != nil {
+		return "", err
+	}
+
+	t := api.FindTransitionByName(transitions, status)
+	if t == nil {
+		for i := range transitions {
+			if api.StatusMatches(transitions[i].To, status) {
+				t = &transitions[i]
+				break
+			}
+		}
+	}
+	if t == nil {
+		names := make([]string, len(transitions))
+		for i, tr := range transitions {
+			names[i] = tr.To.Name
+		}
+		return "", fmt.Errorf("no transition to %q is available on %s (available: %s); try 'jtk transitions to %s %q'",
+			status, issue.Key, strings.Join(names, ", "), issue.Key, status)
+	}
+
+	if err := client.DoTransition(issue.Key, t.ID, nil); err != nil {
+		return "", err
+	}
+
+	from := ""
+	if issue.Fields.Status != nil {
+		from = issue.Fields.Status.Name
+	}
+	return from, nil
+}
+
+func newCurrentCmd(opts *root.Options) *cobra.Command {
+	var current currentOptions
+
+	cmd := &cobra.Command{
+		Use:   "current",
+		Short: "Show the issue being worked on",
+		Long: `Find the issue key in the current branch name or, failing that, in the
+messages of recent commits, and show the issue like 'jtk issues get'. Keys of
+issues that do not exist, such as UTF-8 in a commit message, are skipped.`,
+		Example: `  jtk git current
+  jtk git current --key
+  jtk git current -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := git.Open("")
+			if err != nil {
+				return err
+			}
+			return runCurrent(opts, repo, current, issueExists(opts, current.Offline), issuesGet(cmd, current.Offline))
+		},
+	}
+
+	cmd.Flags().IntVar(&current.Commits, "commits", 10, "Number of recent commits to search when the branch has no key")
+	cmd.Flags().BoolVar(&current.KeyOnly, "key", false, "Only print the issue key")
+	cmd.Flags().BoolVar(&current.Offline, "offline", false, "Show the issue from the local store instead of Jira")
+
+	return cmd
+}
+
+func runCurrent(opts *root.Options, repo *git.Repo, current currentOptions, exists func(key string) (bool, error), show func(key string) error) error {
+	key, err := currentKey(repo, current.Commits, exists)
+	if err != nil {
+		return err
+	}
+
+	if current.KeyOnly {
+		opts.View().Println("%s", key)
+		return nil
+	}
+	return show(key)
+}
+
+// currentKey finds the issue key of the current branch, or of the most
+// recent commit that mentions one. Keys of issues that do not exist, such as
+// UTF-8, are skipped; keys that cannot be checked are used as they are.
+func currentKey(repo *git.Repo, commits int, exists func(key string) (bool, error)) (string, error) {
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	candidates := git.IssueKeys(branch)
+
+	messages, err := repo.RecentMessages(commits)
+	if err != nil {
+		return "", err
+	}
+	for _, m := range messages {
+		candidates = append(candidates, git.IssueKeys(m)...)
+	}
+
+	checked := map[string]bool{}
+	var missing []string
+	for _, key := range candidates {
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+		if ok, err := exists(key); ok || err != nil {
+			return key, nil
+		}
+		missing = append(missing, key)
+	}
+
+	if branch == "" {
+		branch = "HEAD"
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no issue key found in branch %s or the last %d commit(s): %s %s",
+			branch, commits, strings.Join(missing, ", "), doesNotExist(missing))
+	}
+	return "", fmt.Errorf("no issue key found in branch %s or the last %d commit(s)", branch, commits)
+}
+
+// issueExists returns a function reporting whether an issue exists in Jira
+// or, offline, in the local store
+func issueExists(opts *root.Options, offline bool) func(key string) (bool, error) {
+	return func(key string) (bool, error) {
+		if offline {
+			s, err := opts.Store()
+			if err != nil {
+				return false, err
+			}
+			defer s.Close()
+			return s.Has(key)
+		}
+
+		client, err := opts.APIClient()
+		if err != nil {
+			return false, err
+		}
+		_, err = client.GetIssue(key)
+		if api.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
+// issuesGet returns a function showing an issue with the 'issues get' command
+func issuesGet(cmd *cobra.Command, offline bool) func(key string) error {
+	return func(key string) error {
+		get, _, err := cmd.Root().Find([]string{"issues", "get"})
+		if err != nil || get.RunE == nil {
+			return fmt.Errorf("the issues get command is not available")
+		}
+		if offline {
+			if err := get.Flags().Set("offline", "true"); err != nil {
+				return err
+			}
+		}
+		return get.RunE(get, []string{key})
+	}
+}
@@ -0,0 +1,333 @@
+package gitcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/git"
+)
+
+// newTestRepo creates a repository with one commit
+func newTestRepo(t *testing.T) *git.Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "--quiet", "--initial-branch=main")
+	gitRun(t, dir, "config", "user.name", "Test")
+	gitRun(t, dir, "config", "user.email", "test@example.com")
+	gitRun(t, dir, "config", "commit.gpgsign", "false")
+	gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", "Initial commit")
+
+	repo, err := git.Open(dir)
+	require.NoError(t, err)
+	return repo
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(bytes.TrimSpace(out))
+}
+
+// newIssueServer serves PROJ-1 in To Do with a transition to In Progress;
+// other issues do not exist
+func newIssueServer(t *testing.T, transitioned *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1":
+			w.Write([]byte(`{"key":"PROJ-1","fields":{"summary":"Fix login redirect!","status":{"id":"1","name":"To Do"}}}`))
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"transitions":[
+				{"id":"11","name":"Start work","to":{"id":"3","name":"In Progress"}},
+				{"id":"21","name":"Finish","to":{"id":"5","name":"Done"}}
+			]}`))
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions" && r.Method == http.MethodPost:
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			*transitioned = append(*transitioned, body.Transition.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."]}`))
+		}
+	}))
+}
+
+func TestBranchName(t *testing.T) {
+	assert.Equal(t, "feature/PROJ-1-fix-login-redirect", branchName("feature", "PROJ-1", "Fix login redirect!"))
+	assert.Equal(t, "bugfix/PROJ-1-fix", branchName("bugfix/", "PROJ-1", "Fix"))
+	assert.Equal(t, "PROJ-1-fix", branchName("", "PROJ-1", "Fix"))
+	assert.Equal(t, "feature/PROJ-1", branchName("feature", "PROJ-1", "✓✓✓"))
+}
+
+func TestRunBranch(t *testing.T) {
+	var transitioned []string
+	server := newIssueServer(t, &transitioned)
+	defer server.Close()
+	repo := newTestRepo(t)
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runBranch(opts, repo, "PROJ-1", branchOptions{Prefix: "feature", Status: "In Progress", Start: true}))
+	assert.Contains(t, stdout.String(), "Created and switched to branch feature/PROJ-1-fix-login-redirect")
+	assert.Contains(t, stdout.String(), "PROJ-1: To Do -> In Progress")
+	assert.Equal(t, []string{"11"}, transitioned)
+
+	branch, err := repo.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "feature/PROJ-1-fix-login-redirect", branch)
+
+	// Running it again switches back to the existing branch
+	gitRun(t, repo.Dir, "checkout", "--quiet", "main")
+	stdout.Reset()
+	require.NoError(t, runBranch(opts, repo, "PROJ-1", branchOptions{Prefix: "feature"}))
+	assert.Contains(t, stdout.String(), "Switched to existing branch feature/PROJ-1-fix-login-redirect")
+	branch, err = repo.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "feature/PROJ-1-fix-login-redirect", branch)
+	assert.Len(t, transitioned, 1)
+}
+
+func TestRunBranch_JSONNoCheckout(t *testing.T) {
+	var transitioned []string
+	server := newIssueServer(t, &transitioned)
+	defer server.Close()
+	repo := newTestRepo(t)
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runBranch(opts, repo, "PROJ-1", branchOptions{Prefix: "bugfix", NoCheckout: true}))
+
+	var result branchResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, branchResult{Key: "PROJ-1", Branch: "bugfix/PROJ-1-fix-login-redirect", Created: true}, result)
+	assert.True(t, repo.BranchExists("bugfix/PROJ-1-fix-login-redirect"))
+
+	branch, err := repo.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+}
+
+func TestStartIssue_NoTransition(t *testing.T) {
+	var transitioned []string
+	server := newIssueServer(t, &transitioned)
+	defer server.Close()
+
+	client := &api.Client{BaseURL: server.URL + "/rest/api/3", HTTPClient: server.Client()}
+	issue := &api.Issue{Key: "PROJ-1", Fields: api.IssueFields{Status: &api.Status{Name: "To Do"}}}
+
+	from, err := startIssue(client, issue, "start work")
+	require.NoError(t, err)
+	assert.Equal(t, "To Do", from)
+
+	_, err = startIssue(client, issue, "In Review")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: In Progress, Done")
+
+	from, err = startIssue(client, issue, "to do")
+	require.NoError(t, err)
+	assert.Empty(t, from)
+	assert.Equal(t, []string{"11"}, transitioned)
+}
+
+func TestRunCurrent(t *testing.T) {
+	repo := newTestRepo(t)
+
+	var shown []string
+	show := func(key string) error {
+		shown = append(shown, key)
+		return nil
+	}
+	exists := func(key string) (bool, error) { return key != "UTF-8", nil }
+
+	// No key in the branch or commits
+	err := runCurrent(&root.Options{Stdout: &bytes.Buffer{}}, repo, currentOptions{Commits: 10}, exists, show)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no issue key found in branch main")
+
+	// From a recent commit, skipping keys of issues that do not exist
+	gitRun(t, repo.Dir, "commit", "--quiet", "--allow-empty", "-m", "OPS-9 Tune alerts")
+	gitRun(t, repo.Dir, "commit", "--quiet", "--allow-empty", "-m", "Read UTF-8 names")
+	require.NoError(t, runCurrent(&root.Options{Stdout: &bytes.Buffer{}}, repo, currentOptions{Commits: 10}, exists, show))
+	assert.Equal(t, []string{"OPS-9"}, shown)
+
+	// Only keys of issues that do not exist
+	err = runCurrent(&root.Options{Stdout: &bytes.Buffer{}}, repo, currentOptions{Commits: 1}, exists, show)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UTF-8 does not exist")
+
+	// The branch takes precedence over commits
+	gitRun(t, repo.Dir, "checkout", "--quiet", "-b", "feature/PROJ-1-fix-login")
+	var stdout bytes.Buffer
+	require.NoError(t, runCurrent(&root.Options{Stdout: &stdout}, repo, currentOptions{Commits: 10, KeyOnly: true}, exists, show))
+	assert.Equal(t, "PROJ-1\n", stdout.String())
+	assert.Len(t, shown, 1)
+}
+
+func TestIssueExists(t *testing.T) {
+	var transitioned []string
+	server := newIssueServer(t, &transitioned)
+	defer server.Close()
+
+	exists := issueExists(cmdtest.Options(server, &bytes.Buffer{}), false)
+	ok, err := exists("PROJ-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = exists("UTF-8")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestHookInstall(t *testing.T) {
+	repo := newTestRepo(t)
+	opts := &root.Options{Output: "table", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	path := filepath.Join(repo.Dir, ".git", "hooks", "commit-msg")
+
+	require.NoError(t, runHookInstall(opts, repo, "jtk", false))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, hookScript("jtk"), string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0o100, "hook is executable")
+
+	// Reinstalling replaces jtk's own hook
+	require.NoError(t, runHookInstall(opts, repo, "'/opt/jtk'", false))
+
+	require.NoError(t, runHookUninstall(opts, repo))
+	assert.NoFileExists(t, path)
+
+	// Other hooks are kept unless forced
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755))
+	err = runHookInstall(opts, repo, "jtk", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	err = runHookUninstall(opts, repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not installed by jtk")
+
+	require.NoError(t, runHookInstall(opts, repo, "jtk", true))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), hookMarker)
+}
+
+func TestRunHookCommitMsg(t *testing.T) {
+	var transitioned []string
+	server := newIssueServer(t, &transitioned)
+	defer server.Close()
+
+	writeMessage := func(t *testing.T, message string) string {
+		path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+		require.NoError(t, os.WriteFile(path, []byte(message), 0o644))
+		return path
+	}
+
+	t.Run("existing issue", func(t *testing.T) {
+		repo := newTestRepo(t)
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		require.NoError(t, runHookCommitMsg(opts, repo, writeMessage(t, "PROJ-1 Fix login\n")))
+	})
+
+	t.Run("some keys missing", func(t *testing.T) {
+		repo := newTestRepo(t)
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		require.NoError(t, runHookCommitMsg(opts, repo, writeMessage(t, "PROJ-1 Handle UTF-8 names\n")))
+		assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "UTF-8 does not exist")
+	})
+
+	t.Run("missing issue", func(t *testing.T) {
+		repo := newTestRepo(t)
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		err := runHookCommitMsg(opts, repo, writeMessage(t, "PROJ-404 Fix login\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PROJ-404, which does not exist")
+	})
+
+	t.Run("only keys of missing issues, key from branch", func(t *testing.T) {
+		repo := newTestRepo(t)
+		gitRun(t, repo.Dir, "checkout", "--quiet", "-b", "feature/PROJ-1-fix-login")
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		path := writeMessage(t, "Handle UTF-8 names\n")
+
+		require.NoError(t, runHookCommitMsg(opts, repo, path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Jira: PROJ-1")
+		assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "UTF-8 does not exist")
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		repo := newTestRepo(t)
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		err := runHookCommitMsg(opts, repo, writeMessage(t, "Fix login\n# PROJ-1 in a comment\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not mention an issue key")
+	})
+
+	t.Run("key from branch", func(t *testing.T) {
+		repo := newTestRepo(t)
+		gitRun(t, repo.Dir, "checkout", "--quiet", "-b", "feature/PROJ-1-fix-login")
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		path := writeMessage(t, "Fix login\n\n# Please enter the commit message for your changes.\n")
+
+		require.NoError(t, runHookCommitMsg(opts, repo, path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Jira: PROJ-1")
+		assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Added trailer Jira: PROJ-1")
+	})
+
+	t.Run("generated and empty messages", func(t *testing.T) {
+		repo := newTestRepo(t)
+		opts := cmdtest.Options(server, &bytes.Buffer{})
+		require.NoError(t, runHookCommitMsg(opts, repo, writeMessage(t, "Merge branch 'main' into feature\n")))
+		require.NoError(t, runHookCommitMsg(opts, repo, writeMessage(t, "fixup! Fix login\n")))
+		require.NoError(t, runHookCommitMsg(opts, repo, writeMessage(t, "# only comments\n")))
+	})
+}
+
+func TestRunHookCommitMsg_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	repo := newTestRepo(t)
+
+	opts := cmdtest.Options(server, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(path, []byte("PROJ-1 Fix login\n"), 0o644))
+
+	require.NoError(t, runHookCommitMsg(opts, repo, path))
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Could not check PROJ-1")
+}
+
+func TestStripComments(t *testing.T) {
+	message := "Fix login\n\nDetails\n# a comment\n" + scissorsLine + "\ndiff --git a/x b/x\n"
+	assert.Equal(t, "Fix login\n\nDetails", stripComments(message))
+}
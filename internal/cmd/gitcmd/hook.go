@@ -0,0 +1,300 @@
+package gitcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/git"
+)
+
+// hookMarker identifies hooks written by jtk, so they can be replaced and removed
+const hookMarker = "# Installed by jtk git hook install"
+
+// trailerToken is the trailer added to messages that lack an issue key
+const trailerToken = "Jira"
+
+// scissorsLine marks the start of the diff shown by git commit --verbose;
+// git drops it and everything below it from the message
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// skippedPrefixes start messages git generates, which are not checked
+var skippedPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+func newHookCmd(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Manage the commit-msg hook",
+		Long: `Commands for the commit-msg hook that checks commit messages mention an issue.
+
+The hook passes a commit when its message mentions at least one issue that
+exists, and warns about mentioned keys that do not. When the message mentions
+no existing issue (words such as UTF-8 look like keys) but the branch name
+does, a "Jira: KEY" trailer is added to it. When Jira cannot be reached, the
+commit is allowed with a warning. Use
+'git commit --no-verify' to skip the hook.`,
+	}
+
+	cmd.AddCommand(newHookInstallCmd(opts))
+	cmd.AddCommand(newHookUninstallCmd(opts))
+	cmd.AddCommand(newHookCommitMsgCmd(opts))
+
+	return cmd
+}
+
+func newHookInstallCmd(opts *root.Options) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the commit-msg hook in the current repository",
+		Example: `  jtk git hook install
+  jtk git hook install --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := git.Open("")
+			if err != nil {
+				return err
+			}
+			return runHookInstall(opts, repo, hookCommand(), force)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing commit-msg hook not installed by jtk")
+
+	return cmd
+}
+
+func newHookUninstallCmd(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the commit-msg hook from the current repository",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := git.Open("")
+			if err != nil {
+				return err
+			}
+			return runHookUninstall(opts, repo)
+		},
+	}
+}
+
+func newHookCommitMsgCmd(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:    "commit-msg <message-file>",
+		Short:  "Check a commit message (run by the commit-msg hook)",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, err := git.Open("")
+			if err != nil {
+				return err
+			}
+			return runHookCommitMsg(opts, repo, args[0])
+		},
+	}
+}
+
+// hookCommand returns how the hook invokes jtk: by name when jtk is on the
+// PATH, otherwise by the path of the running executable
+func hookCommand() string {
+	if _, err := exec.LookPath("jtk"); err == nil {
+		return "jtk"
+	}
+	if exe, err := os.Executable(); err == nil {
+		return shellQuote(exe)
+	}
+	return "jtk"
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookScript returns the commit-msg hook running jtk with the given command
+func hookScript(command string) string {
+	return "#!/bin/sh\n" +
+		hookMarker + "\n" +
+		"exec " + command + " git hook commit-msg \"$1\"\n"
+}
+
+// hookPath returns the location of the commit-msg hook, whether a hook
+// exists there and whether jtk installed it
+func hookPath(repo *git.Repo) (path string, ours, exists bool, err error) {
+	dir, err := repo.HooksDir()
+	if err != nil {
+		return "", false, false, err
+	}
+	path = filepath.Join(dir, "commit-msg")
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, false, false, nil
+	}
+	if err != nil {
+		return "", false, false, err
+	}
+	return path, strings.Contains(string(data), hookMarker), true, nil
+}
+
+func runHookInstall(opts *root.Options, repo *git.Repo, command string, force bool) error {
+	v := opts.View()
+
+	path, ours, exists, err := hookPath(repo)
+	if err != nil {
+		return err
+	}
+	if exists && !ours && !force {
+		return fmt.Errorf("%s already exists and was not installed by jtk; use --force to replace it", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hookScript(command)), 0o755); err != nil { //nolint:gosec // hooks must be executable
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0o755); err != nil { //nolint:gosec // hooks must be executable
+		return fmt.Errorf("failed to make hook executable: %w", err)
+	}
+
+	v.Success("Installed commit-msg hook in %s", path)
+	return nil
+}
+
+func runHookUninstall(opts *root.Options, repo *git.Repo) error {
+	v := opts.View()
+
+	path, ours, exists, err := hookPath(repo)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		v.Info("No commit-msg hook is installed")
+		return nil
+	}
+	if !ours {
+		return fmt.Errorf("%s was not installed by jtk; remove it yourself", path)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove hook: %w", err)
+	}
+	v.Success("Removed commit-msg hook from %s", path)
+	return nil
+}
+
+// runHookCommitMsg checks the commit message in path. An error fails the commit.
+func runHookCommitMsg(opts *root.Options, repo *git.Repo, path string) error {
+	v := opts.View()
+	// Hook output goes to the terminal; keep stdout for git
+	v.Out = opts.Stderr
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	message := stripComments(string(data))
+	if message == "" || skippedMessage(message) {
+		return nil
+	}
+
+	exists := issueExists(opts, false)
+
+	// Keys of issues that do not exist, such as UTF-8, count as no key
+	found, missing, err := existingKeys(git.IssueKeys(message), exists)
+	if err != nil {
+		v.Warning("Could not check %s: %v", strings.Join(git.IssueKeys(message), ", "), err)
+		return nil
+	}
+	if len(found) > 0 {
+		if len(missing) > 0 {
+			v.Warning("%s %s", strings.Join(missing, ", "), doesNotExist(missing))
+		}
+		return nil
+	}
+
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	branchFound, branchMissing, err := existingKeys(git.IssueKeys(branch), exists)
+	if err != nil {
+		v.Warning("Could not check %s: %v", strings.Join(git.IssueKeys(branch), ", "), err)
+		branchFound = git.IssueKeys(branch)
+	}
+	if len(branchFound) == 0 {
+		missing = append(missing, branchMissing...)
+		if len(missing) > 0 {
+			return fmt.Errorf("commit message mentions %s, which %s (use --no-verify to skip this check)", strings.Join(missing, ", "), doesNotExist(missing))
+		}
+		return fmt.Errorf("commit message does not mention an issue key such as PROJ-123 (use --no-verify to skip this check)")
+	}
+
+	if err := repo.AddTrailer(path, trailerToken, branchFound[0]); err != nil {
+		return err
+	}
+	v.Info("Added trailer %s: %s", trailerToken, branchFound[0])
+	if len(missing) > 0 {
+		v.Warning("%s %s", strings.Join(missing, ", "), doesNotExist(missing))
+	}
+	return nil
+}
+
+// existingKeys splits keys into those of issues that exist and those of
+// issues that do not. An error means the keys could not be checked.
+func existingKeys(keys []string, exists func(key string) (bool, error)) (found, missing []string, err error) {
+	for _, key := range keys {
+		ok, err := exists(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			found = append(found, key)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	return found, missing, nil
+}
+
+func doesNotExist(keys []string) string {
+	if len(keys) == 1 {
+		return "does not exist"
+	}
+	return "do not exist"
+}
+
+// stripComments removes the lines git drops from a commit message: comment
+// lines and the diff below the scissors line
+func stripComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// skippedMessage reports whether git generated the message
+func skippedMessage(message string) bool {
+	for _, prefix := range skippedPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
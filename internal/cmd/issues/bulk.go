@@ -0,0 +1,345 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+)
+
+// Bulk result statuses
+const (
+	bulkUpdated      = "updated"
+	bulkTransitioned = "transitioned"
+	bulkSkipped      = "skipped"
+	bulkWould        = "dry-run"
+	bulkFailed       = "failed"
+)
+
+// bulkOptions holds the flags shared by the bulk commands
+type bulkOptions struct {
+	JQL         string
+	Max         int
+	Concurrency int
+	DryRun      bool
+}
+
+// bulkResult is the outcome of a bulk operation on one issue
+type bulkResult struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Detail  string `json:"detail,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newBulkCmd(opts *root.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Update or transition many issues at once",
+		Long: `Apply the same change to every issue matching a JQL query.
+
+Issues are processed concurrently and a per-issue report is printed at the end.
+The command exits with an error when any issue fails. Use --dry-run to see which
+issues would be changed first.`,
+	}
+
+	cmd.AddCommand(newBulkUpdateCmd(opts))
+	cmd.AddCommand(newBulkTransitionCmd(opts))
+
+	return cmd
+}
+
+func addBulkFlags(cmd *cobra.Command, bulk *bulkOptions) {
+	cmd.Flags().StringVar(&bulk.JQL, "jql", "", "JQL query selecting the issues (required)")
+	cmd.Flags().IntVarP(&bulk.Max, "max", "m", 1000, "Maximum number of issues to change; more matches are an error")
+	cmd.Flags().IntVar(&bulk.Concurrency, "concurrency", 4, "Number of issues to change in parallel")
+	cmd.Flags().BoolVar(&bulk.DryRun, "dry-run", false, "Show what would change without changing anything")
+	_ = cmd.MarkFlagRequired("jql")
+}
+
+func newBulkUpdateCmd(opts *root.Options) *cobra.Command {
+	var bulk bulkOptions
+	var fields []string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update fields on every issue matching a JQL query",
+		Long: `Update fields on every issue matching a JQL query.
+
+Fields use the same syntax as "jtk issues update --field", including += and -=
+to add and remove values of multi-value fields.`,
+		Example: `  # Label everything reported this week
+  jtk issues bulk update --jql "project = PROJ AND created >= -7d" -f labels+=triaged
+
+  # Preview a priority change
+  jtk issues bulk update --jql "project = PROJ AND labels = outage" -f priority=Highest --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBulkUpdate(opts, bulk, fields)
+		},
+	}
+
+	addBulkFlags(cmd, &bulk)
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "Fields to update (key=value, key+=value, key-=value, key:=json)")
+
+	return cmd
+}
+
+func runBulkUpdate(opts *root.Options, bulk bulkOptions, fieldArgs []string) error {
+	if len(fieldArgs) == 0 {
+		return fmt.Errorf("no fields specified to update")
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	// Resolve fields once so that a bad argument fails before any issue is touched
+	changes, err := client.ResolveFieldArgs(fieldArgs)
+	if err != nil {
+		return err
+	}
+	req := api.BuildUpdateRequest(changes.Fields)
+	if len(changes.Update) > 0 {
+		req.Update = changes.Update
+	}
+
+	issues, err := searchBulkIssues(client, bulk)
+	if err != nil {
+		return err
+	}
+
+	detail := strings.Join(fieldArgs, ", ")
+	return runBulk(opts, bulk, issues, "Updating", func(issue api.Issue) bulkResult {
+		result := newBulkResult(issue)
+		if bulk.DryRun {
+			result.Status, result.Detail = bulkWould, detail
+			return result
+		}
+		if err := client.UpdateIssue(issue.Key, req); err != nil {
+			result.Status, result.Error = bulkFailed, err.Error()
+			return result
+		}
+		result.Status, result.Detail = bulkUpdated, detail
+		return result
+	})
+}
+
+// bulkTransitionOptions holds the flags of bulk transition
+type bulkTransitionOptions struct {
+	To         string
+	Fields     []string
+	Resolution string
+	Comment    string
+}
+
+func newBulkTransitionCmd(opts *root.Options) *cobra.Command {
+	var bulk bulkOptions
+	var tr bulkTransitionOptions
+
+	cmd := &cobra.Command{
+		Use:   "transition",
+		Short: "Transition every issue matching a JQL query",
+		Long: `Transition every issue matching a JQL query.
+
+--to names a transition or its target status, or gives a transition ID.
+Workflows can differ between issue types, so the transition is looked up for
+each issue. Issues already in the target status are skipped.`,
+		Example: `  # Close out a finished sprint's stragglers
+  jtk issues bulk transition --jql "sprint = 42 AND status = 'In Review'" --to Done --resolution Done
+
+  # See which issues can move without changing them
+  jtk issues bulk transition --jql "project = PROJ AND labels = wontfix" --to Closed --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBulkTransition(opts, bulk, tr)
+		},
+	}
+
+	addBulkFlags(cmd, &bulk)
+	cmd.Flags().StringVar(&tr.To, "to", "", "Transition name, transition ID or target status (required)")
+	cmd.Flags().StringArrayVarP(&tr.Fields, "field", "f", nil, "Fields to set during transition (key=value, key+=value, key:=json)")
+	cmd.Flags().StringVar(&tr.Resolution, "resolution", "", "Resolution to set (e.g., Fixed, Done)")
+	cmd.Flags().StringVar(&tr.Comment, "comment", "", "Comment to add with the transition")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runBulkTransition(opts *root.Options, bulk bulkOptions, tr bulkTransitionOptions) error {
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	changes, err := client.ResolveFieldArgs(tr.Fields)
+	if err != nil {
+		return err
+	}
+	if tr.Resolution != "" {
+		changes.Fields["resolution"] = map[string]string{"name": tr.Resolution}
+	}
+	if tr.Comment != "" {
+		changes.AddComment(tr.Comment)
+	}
+
+	issues, err := searchBulkIssues(client, bulk)
+	if err != nil {
+		return err
+	}
+
+	return runBulk(opts, bulk, issues, "Transitioning", func(issue api.Issue) bulkResult {
+		result := newBulkResult(issue)
+
+		if issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, tr.To) {
+			result.Status, result.Detail = bulkSkipped, "already "+issue.Fields.Status.Name
+			return result
+		}
+
+		transitions, err := client.GetTransitions(issue.Key)
+		if err != nil {
+			result.Status, result.Error = bulkFailed, err.Error()
+			return result
+		}
+		t := matchBulkTransition(transitions, tr.To)
+		if t == nil {
+			result.Status, result.Error = bulkFailed, fmt.Sprintf("no transition to %q available", tr.To)
+			return result
+		}
+
+		detail := fmt.Sprintf("%s -> %s", t.Name, t.To.Name)
+		if bulk.DryRun {
+			result.Status, result.Detail = bulkWould, detail
+			return result
+		}
+		if err := client.DoTransitionWithUpdate(issue.Key, t.ID, changes.Fields, changes.Update); err != nil {
+			result.Status, result.Error = bulkFailed, err.Error()
+			return result
+		}
+		result.Status, result.Detail = bulkTransitioned, detail
+		return result
+	})
+}
+
+// matchBulkTransition finds a transition by ID, then by name, then by target status
+func matchBulkTransition(transitions []api.Transition, to string) *api.Transition {
+	for i := range transitions {
+		if transitions[i].ID == to {
+			return &transitions[i]
+		}
+	}
+	if t := api.FindTransitionByName(transitions, to); t != nil {
+		return t
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, to) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+// searchBulkIssues returns the issues matching --jql. It fails when more than
+// --max match, rather than changing only some of them.
+func searchBulkIssues(client *api.Client, bulk bulkOptions) ([]api.Issue, error) {
+	if bulk.Max < 1 {
+		return nil, fmt.Errorf("--max must be at least 1")
+	}
+	issues, err := client.SearchAll(bulk.JQL, bulk.Max+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) > bulk.Max {
+		return nil, fmt.Errorf("more than %d issues match the query; raise --max or narrow --jql", bulk.Max)
+	}
+	return issues, nil
+}
+
+func newBulkResult(issue api.Issue) bulkResult {
+	return bulkResult{Key: issue.Key, Summary: issue.Fields.Summary}
+}
+
+// runBulk applies fn to every issue with bounded concurrency and reports the results
+func runBulk(opts *root.Options, bulk bulkOptions, issues []api.Issue, verb string, fn func(api.Issue) bulkResult) error {
+	v := opts.View()
+
+	if len(issues) == 0 {
+		v.Info("No issues found")
+		return nil
+	}
+	if bulk.Concurrency < 1 {
+		bulk.Concurrency = 1
+	}
+
+	label := fmt.Sprintf("%s %d issue(s)", verb, len(issues))
+	if bulk.DryRun {
+		label = fmt.Sprintf("Checking %d issue(s)", len(issues))
+	}
+	bar := progress.NewCount(opts.Stderr, label, len(issues))
+
+	results := make([]bulkResult, len(issues))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < bulk.Concurrency && w < len(issues); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fn(issues[i])
+				bar.Add(1)
+			}
+		}()
+	}
+	for i := range issues {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.Finish()
+
+	failed, skipped := 0, 0
+	for _, r := range results {
+		switch r.Status {
+		case bulkFailed:
+			failed++
+		case bulkSkipped:
+			skipped++
+		}
+	}
+
+	if opts.DataOutput() {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch r.Status {
+			case bulkFailed:
+				v.Error("%s: %s", r.Key, r.Error)
+			case bulkSkipped:
+				v.Info("%s: skipped (%s)", r.Key, r.Detail)
+			case bulkWould:
+				v.Info("%s: would apply %s", r.Key, r.Detail)
+			default:
+				v.Success("%s: %s %s", r.Key, r.Status, r.Detail)
+			}
+		}
+
+		done := len(results) - failed - skipped
+		if bulk.DryRun {
+			v.Info("%d would change, %d skipped, %d failed (dry run, nothing changed)", done, skipped, failed)
+		} else {
+			v.Info("%d changed, %d skipped, %d failed", done, skipped, failed)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issue(s) failed", failed, len(results))
+	}
+	return nil
+}
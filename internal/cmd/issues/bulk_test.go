@@ -0,0 +1,158 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+// newBulkTestServer serves a search returning PROJ-1..PROJ-3 and records
+// update and transition requests by issue key
+func newBulkTestServer(t *testing.T, mu *sync.Mutex, changed map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/3")
+		switch {
+		case path == "/search/jql":
+			w.Write([]byte(`{"total":3,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"One","status":{"name":"To Do"}}},
+				{"key":"PROJ-2","fields":{"summary":"Two","status":{"name":"Done"}}},
+				{"key":"PROJ-3","fields":{"summary":"Three","status":{"name":"Blocked"}}}
+			]}`))
+		case path == "/field":
+			w.Write([]byte(`[{"id":"labels","name":"Labels","schema":{"type":"array","items":"string"}}]`))
+		case r.Method == http.MethodPut && path == "/issue/PROJ-3":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":{"labels":"Field cannot be set"}}`))
+		case r.Method == http.MethodPut:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			data, _ := json.Marshal(body)
+			mu.Lock()
+			changed[strings.TrimPrefix(path, "/issue/")] = string(data)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "/transitions"):
+			if strings.Contains(path, "PROJ-3") {
+				w.Write([]byte(`{"transitions":[{"id":"11","name":"Unblock","to":{"name":"To Do"}}]}`))
+				return
+			}
+			w.Write([]byte(`{"transitions":[{"id":"31","name":"Finish","to":{"name":"Done"}}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/transitions"):
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			data, _ := json.Marshal(body)
+			mu.Lock()
+			changed[strings.TrimSuffix(strings.TrimPrefix(path, "/issue/"), "/transitions")] = string(data)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunBulkUpdate(t *testing.T) {
+	var mu sync.Mutex
+	changed := make(map[string]string)
+	server := newBulkTestServer(t, &mu, changed)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	err := runBulkUpdate(opts, bulkOptions{JQL: "project = PROJ", Max: 100, Concurrency: 2}, []string{"labels+=triaged"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 issue(s) failed")
+
+	assert.Len(t, changed, 2)
+	assert.JSONEq(t, `{"update":{"labels":[{"add":"triaged"}]}}`, changed["PROJ-1"])
+
+	var results []bulkResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, bulkUpdated, results[0].Status)
+	assert.Equal(t, bulkUpdated, results[1].Status)
+	assert.Equal(t, bulkFailed, results[2].Status)
+	assert.Contains(t, results[2].Error, "Field cannot be set")
+}
+
+func TestRunBulkUpdate_DryRun(t *testing.T) {
+	var mu sync.Mutex
+	changed := make(map[string]string)
+	server := newBulkTestServer(t, &mu, changed)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	err := runBulkUpdate(cmdtest.Options(server, &stdout),
+		bulkOptions{JQL: "project = PROJ", Max: 100, Concurrency: 4, DryRun: true}, []string{"labels+=triaged"})
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Contains(t, stdout.String(), "3 would change")
+}
+
+func TestRunBulkTransition(t *testing.T) {
+	var mu sync.Mutex
+	changed := make(map[string]string)
+	server := newBulkTestServer(t, &mu, changed)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	err := runBulkTransition(opts, bulkOptions{JQL: "project = PROJ", Max: 100, Concurrency: 3},
+		bulkTransitionOptions{To: "Done", Resolution: "Fixed"})
+	require.Error(t, err)
+
+	assert.Len(t, changed, 1)
+	assert.JSONEq(t, `{"transition":{"id":"31"},"fields":{"resolution":{"name":"Fixed"}}}`, changed["PROJ-1"])
+
+	var results []bulkResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, bulkTransitioned, results[0].Status)
+	assert.Equal(t, bulkSkipped, results[1].Status)
+	assert.Equal(t, bulkFailed, results[2].Status)
+	assert.Contains(t, results[2].Error, `no transition to "Done"`)
+}
+
+func TestRunBulkUpdate_TooManyIssues(t *testing.T) {
+	var mu sync.Mutex
+	changed := make(map[string]string)
+	server := newBulkTestServer(t, &mu, changed)
+	defer server.Close()
+
+	opts := cmdtest.Options(server, &bytes.Buffer{})
+
+	err := runBulkUpdate(opts, bulkOptions{JQL: "project = PROJ", Max: 2, Concurrency: 2}, []string{"labels+=triaged"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than 2 issues match the query; raise --max")
+	assert.Empty(t, changed)
+
+	require.NoError(t, runBulkUpdate(opts, bulkOptions{JQL: "project = PROJ", Max: 3, DryRun: true}, []string{"labels+=triaged"}))
+}
+
+func TestMatchBulkTransition(t *testing.T) {
+	transitions := []api.Transition{
+		{ID: "21", Name: "Start work", To: api.Status{Name: "In Progress"}},
+		{ID: "31", Name: "Done", To: api.Status{Name: "Closed"}},
+	}
+
+	assert.Equal(t, "21", matchBulkTransition(transitions, "21").ID)
+	assert.Equal(t, "21", matchBulkTransition(transitions, "start work").ID)
+	assert.Equal(t, "21", matchBulkTransition(transitions, "in progress").ID)
+	assert.Equal(t, "31", matchBulkTransition(transitions, "Done").ID)
+	assert.Nil(t, matchBulkTransition(transitions, "Blocked"))
+}
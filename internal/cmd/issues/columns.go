@@ -0,0 +1,265 @@
+package issues
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/config"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// keyColumn selects the issue key, which is not a field
+const keyColumn = "key"
+
+// defaultColumns are shown when neither --columns nor the config sets them
+var defaultColumns = []string{"key", "summary", "status", "assignee", "issuetype"}
+
+// standardColumns are system fields usable as columns without looking up
+// field metadata, with their column headers
+var standardColumns = map[string]string{
+	keyColumn:    "Key",
+	"summary":    "Summary",
+	"status":     "Status",
+	"assignee":   "Assignee",
+	"reporter":   "Reporter",
+	"priority":   "Priority",
+	"issuetype":  "Type",
+	"project":    "Project",
+	"parent":     "Parent",
+	"created":    "Created",
+	"updated":    "Updated",
+	"duedate":    "Due",
+	"labels":     "Labels",
+	"components": "Components",
+	"resolution": "Resolution",
+}
+
+// columnAliases are alternative names for standard columns
+var columnAliases = map[string]string{
+	"type": "issuetype",
+	"due":  "duedate",
+}
+
+// issueColumn is an output column of an issue listing
+type issueColumn struct {
+	Header string
+	ID     string // field ID, or keyColumn
+}
+
+// addColumnFlags registers --columns, and normalizes --fields to it
+func addColumnFlags(cmd *cobra.Command, columns *[]string) {
+	cmd.Flags().StringSliceVar(columns, "columns", nil, "Columns to show: key and field names or IDs (comma-separated; alias --fields)")
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "fields" {
+			name = "columns"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
+
+// resolveIssueColumns resolves field names or IDs to columns. Field metadata
+// is only fetched for names that are not standard columns.
+func resolveIssueColumns(client *api.Client, names []string) ([]issueColumn, error) {
+	var fields []api.Field
+	columns := make([]issueColumn, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		id := strings.ToLower(name)
+		if alias, ok := columnAliases[id]; ok {
+			id = alias
+		}
+		if header, ok := standardColumns[id]; ok {
+			columns = append(columns, issueColumn{Header: header, ID: id})
+			continue
+		}
+
+		if fields == nil {
+			var err error
+			if fields, err = client.GetFields(); err != nil {
+				return nil, err
+			}
+		}
+		id, err := api.ResolveFieldID(fields, name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, issueColumn{Header: api.FindFieldByID(fields, id).Name, ID: id})
+	}
+
+	return columns, nil
+}
+
+// columnFieldIDs returns the fields to request from search for the columns
+func columnFieldIDs(columns []issueColumn) []string {
+	var ids []string
+	for _, c := range columns {
+		if c.ID != keyColumn {
+			ids = append(ids, c.ID)
+		}
+	}
+	if len(ids) == 0 {
+		// The search API returns all navigable fields when none are named
+		ids = []string{"summary"}
+	}
+	return ids
+}
+
+// issueColumnTable renders issues as rows of the selected columns. For
+// terminal tables, headers are upper-cased, empty cells shown as "-" and
+// statuses colored by category; exports get the plain values. Dates are
+// rendered by the view, relative in tables and ISO otherwise.
+func issueColumnTable(v *view.View, issues []api.Issue, columns []issueColumn) ([]string, [][]string) {
+	table := v.Format == view.FormatTable
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+		if table {
+			headers[i] = strings.ToUpper(c.Header)
+		}
+	}
+
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			row[j] = columnValue(v, issue, c.ID)
+			if !table {
+				continue
+			}
+			switch {
+			case c.ID == "assignee" && row[j] == "":
+				row[j] = "Unassigned"
+			case row[j] == "":
+				row[j] = "-"
+			case c.ID == "status" && issue.Fields.Status != nil:
+				row[j] = statusColor(issue.Fields.Status.StatusCategory.Key).Sprint(row[j])
+			}
+		}
+		rows[i] = row
+	}
+
+	return headers, rows
+}
+
+func columnValue(v *view.View, issue api.Issue, id string) string {
+	switch id {
+	case keyColumn:
+		return issue.Key
+	case "created":
+		if issue.Fields.Created != nil {
+			return v.Time(issue.Fields.Created.Time)
+		}
+		return ""
+	case "updated":
+		if issue.Fields.Updated != nil {
+			return v.Time(issue.Fields.Updated.Time)
+		}
+		return ""
+	case "duedate":
+		if issue.Fields.DueDate != nil {
+			return v.Date(issue.Fields.DueDate.Time)
+		}
+		return ""
+	case "project":
+		if issue.Fields.Project != nil {
+			return issue.Fields.Project.Key
+		}
+		return ""
+	case "parent":
+		if issue.Fields.Parent != nil {
+			return issue.Fields.Parent.Key
+		}
+		return ""
+	}
+	return api.FieldValueText(issue.Fields.Value(id))
+}
+
+// statusColor returns the color for a status category, following Jira's
+// palette: grey (uncolored) for to do, blue for in progress, green for done
+func statusColor(category string) *color.Color {
+	switch category {
+	case "indeterminate":
+		return color.New(color.FgBlue)
+	case "done":
+		return color.New(color.FgGreen)
+	default:
+		return color.New(color.Reset)
+	}
+}
+
+// listIssues searches with JQL and renders the issues. names selects the
+// columns; without it the configured or default columns are shown.
+func listIssues(opts *root.Options, jql string, maxResults int, names []string) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	// Data formats output whole issues, so columns only matter when chosen explicitly
+	explicit := len(names) > 0
+	if !explicit {
+		if names = config.GetColumns(); len(names) == 0 {
+			names = defaultColumns
+		}
+	}
+
+	var columns []issueColumn
+	searchFields := api.DefaultSearchFields
+	if explicit || !opts.DataOutput() {
+		if columns, err = resolveIssueColumns(client, names); err != nil {
+			return err
+		}
+		if explicit {
+			searchFields = columnFieldIDs(columns)
+		} else {
+			searchFields = mergeFields(searchFields, columnFieldIDs(columns))
+		}
+	}
+
+	issues, err := client.SearchAllFields(jql, maxResults, searchFields)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		v.Info("No issues found")
+		return nil
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(issues)
+	}
+
+	headers, rows := issueColumnTable(v, issues, columns)
+	return v.Table(headers, rows)
+}
+
+// mergeFields appends the fields of extra missing from base
+func mergeFields(base, extra []string) []string {
+	merged := append([]string{}, base...)
+	for _, id := range extra {
+		found := false
+		for _, b := range base {
+			if b == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
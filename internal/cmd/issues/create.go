@@ -1,26 +1,51 @@
 package issues
 
 import (
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/api"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/issuetemplate"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/prompt"
 )
 
+// createOptions holds the flags of the create command
+type createOptions struct {
+	Project     string
+	IssueType   string
+	Summary     string
+	Description string
+	Fields      []string
+	Interactive bool
+	NoValidate  bool
+	Template    string
+	Vars        []string
+
+	// subtasks are created under the new issue; they come from the template
+	subtasks []issuetemplate.Subtask
+}
+
 func newCreateCmd(opts *root.Options) *cobra.Command {
-	var project string
-	var issueType string
-	var summary string
-	var description string
-	var fields []string
+	var create createOptions
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new issue",
-		Long:  "Create a new Jira issue with the specified fields.",
+		Long: `Create a new Jira issue with the specified fields.
+
+Before submitting, the fields are checked against the project's create screen:
+required fields without a value and values outside a field's allowed values are
+reported by name. Use --no-validate to skip the check.
+
+With --interactive, jtk prompts for the summary, description and every required
+field that has not been given, offering allowed values as choices.
+
+With --from-template, the issue is filled in from a template in the templates
+directory (see "jtk templates list"). Variables are set with --var, and flags
+given on the command line take precedence over the template.`,
 		Example: `  # Create a basic task
   jtk issues create --project MYPROJECT --type Task --summary "Fix login bug"
 
@@ -28,73 +53,162 @@ func newCreateCmd(opts *root.Options) *cobra.Command {
   jtk issues create --project MYPROJECT --type Bug --summary "Login fails" --description "Users cannot log in with SSO"
 
   # Create with custom fields
-  jtk issues create --project MYPROJECT --type Story --summary "New feature" --field priority=High`,
+  jtk issues create --project MYPROJECT --type Story --summary "New feature" --field priority=High
+
+  # Multi-value, user and date fields
+  jtk issues create --project MYPROJECT --summary "Upgrade" --field labels=infra,q3 --field assignee=jane@example.com --field duedate=2024-09-30
+
+  # Prompt for the summary and any required fields
+  jtk issues create --project MYPROJECT --type Bug -i
+
+  # Create from a template
+  jtk issues create --from-template bug --var component=api --var title="Login fails"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreate(opts, project, issueType, summary, description, fields)
+			if len(create.Vars) > 0 && create.Template == "" {
+				return fmt.Errorf("--var can only be used with --from-template")
+			}
+			if create.Template != "" {
+				if err := applyTemplate(&create, cmd.Flags().Changed("type")); err != nil {
+					return err
+				}
+			}
+			return runCreate(opts, create)
 		},
 	}
 
-	cmd.Flags().StringVarP(&project, "project", "p", "", "Project key (required)")
-	cmd.Flags().StringVarP(&issueType, "type", "t", "Task", "Issue type (Task, Bug, Story, etc.)")
-	cmd.Flags().StringVarP(&summary, "summary", "s", "", "Issue summary (required)")
-	cmd.Flags().StringVarP(&description, "description", "d", "", "Issue description")
-	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "Additional fields (key=value)")
-
-	_ = cmd.MarkFlagRequired("project")
-	_ = cmd.MarkFlagRequired("summary")
+	cmd.Flags().StringVarP(&create.Project, "project", "p", "", "Project key (required)")
+	cmd.Flags().StringVarP(&create.IssueType, "type", "t", "Task", "Issue type (Task, Bug, Story, etc.)")
+	cmd.Flags().StringVarP(&create.Summary, "summary", "s", "", "Issue summary (required unless --interactive)")
+	cmd.Flags().StringVarP(&create.Description, "description", "d", "", "Issue description")
+	cmd.Flags().StringArrayVarP(&create.Fields, "field", "f", nil, "Additional fields (key=value, key+=value, key:=json)")
+	cmd.Flags().BoolVarP(&create.Interactive, "interactive", "i", false, "Prompt for the summary and missing required fields")
+	cmd.Flags().BoolVar(&create.NoValidate, "no-validate", false, "Skip checking fields against the project's create screen")
+	cmd.Flags().StringVar(&create.Template, "from-template", "", "Fill in the issue from a template")
+	cmd.Flags().StringArrayVar(&create.Vars, "var", nil, "Template variable (name=value)")
 
 	return cmd
 }
 
-func runCreate(opts *root.Options, project, issueType, summary, description string, fieldArgs []string) error {
+func runCreate(opts *root.Options, create createOptions) error {
 	v := opts.View()
 
+	if create.Project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if create.Interactive && !prompt.IsInteractive(opts.Stdin, opts.Stdout) {
+		return prompt.ErrNotInteractive
+	}
+	if create.Summary == "" && !create.Interactive {
+		return fmt.Errorf("--summary is required (or use --interactive)")
+	}
+
 	client, err := opts.APIClient()
 	if err != nil {
 		return err
 	}
 
 	// Parse additional fields
-	extraFields := make(map[string]interface{})
-	if len(fieldArgs) > 0 {
-		// Get field metadata to resolve names to IDs
-		allFields, err := client.GetFields()
-		if err != nil {
-			return fmt.Errorf("failed to get field metadata: %w", err)
-		}
+	changes, err := client.ResolveFieldArgs(create.Fields)
+	if err != nil {
+		return err
+	}
 
-		for _, f := range fieldArgs {
-			parts := strings.SplitN(f, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid field format: %s (expected key=value)", f)
+	var p *prompt.Prompter
+	if create.Interactive {
+		p = prompt.New(opts.Stdin, opts.Stdout)
+		if create.Summary == "" {
+			if create.Summary, err = p.Required("Summary"); err != nil {
+				return err
+			}
+		}
+		if create.Description == "" {
+			if create.Description, err = p.Input("Description (optional)", ""); err != nil {
+				return err
 			}
+		}
+	}
 
-			key, value := parts[0], parts[1]
+	req := api.BuildCreateRequest(create.Project, create.IssueType, create.Summary, create.Description, changes.Fields)
+	if len(changes.Update) > 0 {
+		req.Update = changes.Update
+	}
 
-			// Try to resolve field name to ID
-			fieldID, err := api.ResolveFieldID(allFields, key)
-			if err != nil {
-				// Use the key as-is if not found
-				fieldID = key
+	if create.Interactive || !create.NoValidate {
+		meta, err := client.GetCreateMeta(create.Project, create.IssueType)
+		switch {
+		case errors.Is(err, api.ErrInvalidIssueType):
+			return err
+		case err != nil && create.Interactive:
+			return fmt.Errorf("failed to get create metadata: %w", err)
+		case err != nil:
+			// Older servers and restricted accounts may not expose createmeta;
+			// let Jira validate the request instead
+			v.Warning("Skipping field validation: %v", err)
+		default:
+			if create.Interactive {
+				if err := promptCreateFields(p, meta, req, client.ResolveUserByEmail); err != nil {
+					return err
+				}
+			}
+			if err := api.ValidateCreateRequest(meta, req); err != nil {
+				return err
 			}
-
-			extraFields[fieldID] = value
 		}
 	}
 
-	req := api.BuildCreateRequest(project, issueType, summary, description, extraFields)
-
 	issue, err := client.CreateIssue(req)
 	if err != nil {
 		return err
 	}
 
-	if opts.Output == "json" {
-		return v.JSON(issue)
+	subtasks, subtaskErr := createSubtasks(client, create, issue.Key)
+
+	if opts.DataOutput() {
+		if create.subtasks == nil {
+			return v.JSON(issue)
+		}
+		if err := v.JSON(map[string]interface{}{"issue": issue, "subtasks": subtasks}); err != nil {
+			return err
+		}
+		return subtaskErr
 	}
 
 	v.Success("Created issue %s", issue.Key)
 	v.Info("URL: %s", client.IssueURL(issue.Key))
+	for _, st := range subtasks {
+		v.Success("Created subtask %s", st.Key)
+	}
 
+	return subtaskErr
+}
+
+// promptCreateFields asks for every required field of the create screen that
+// has no value in req and no server-side default
+func promptCreateFields(p *prompt.Prompter, meta []api.CreateMetaField, req *api.CreateIssueRequest, resolveUser api.UserResolver) error {
+	for _, f := range meta {
+		if !f.Required || f.HasDefaultValue {
+			continue
+		}
+		if _, set := req.Fields[f.ID()]; set {
+			continue
+		}
+		if _, set := req.Update[f.ID()]; set {
+			continue
+		}
+
+		value, ok, err := p.FieldValue(prompt.Field{
+			ID:            f.ID(),
+			Name:          f.Name,
+			Required:      true,
+			Schema:        f.Schema,
+			AllowedValues: f.AllowedValues,
+		}, resolveUser)
+		if err != nil {
+			return err
+		}
+		if ok {
+			req.Fields[f.ID()] = value
+		}
+	}
 	return nil
 }
@@ -0,0 +1,180 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/issuetemplate"
+)
+
+func newCreateTestServer(t *testing.T, created *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10020","name":"Team","custom":true,"schema":{"type":"option"}}]`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes":
+			w.Write([]byte(`{"total":1,"issueTypes":[{"id":"10001","name":"Bug"}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes/10001":
+			w.Write([]byte(`{"total":2,"fields":[
+				{"fieldId":"summary","name":"Summary","required":true},
+				{"fieldId":"customfield_10020","name":"Team","required":true,"schema":{"type":"option"},
+				 "allowedValues":[{"id":"1","value":"Platform"},{"id":"2","value":"Mobile"}]}
+			]}`))
+		case r.URL.Path == "/rest/api/3/issue" && r.Method == http.MethodPost:
+			*created = true
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1","key":"PROJ-1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunCreate_Validation(t *testing.T) {
+	t.Run("missing required field", func(t *testing.T) {
+		var created bool
+		server := newCreateTestServer(t, &created)
+		defer server.Close()
+
+		err := runCreate(cmdtest.Options(server, &bytes.Buffer{}), createOptions{
+			Project: "PROJ", IssueType: "Bug", Summary: "Crash",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing required fields: Team (customfield_10020)")
+		assert.False(t, created)
+	})
+
+	t.Run("value not allowed", func(t *testing.T) {
+		var created bool
+		server := newCreateTestServer(t, &created)
+		defer server.Close()
+
+		err := runCreate(cmdtest.Options(server, &bytes.Buffer{}), createOptions{
+			Project: "PROJ", IssueType: "Bug", Summary: "Crash", Fields: []string{"Team=Web"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value "Web" for Team (allowed: Platform, Mobile)`)
+		assert.False(t, created)
+	})
+
+	t.Run("unknown issue type", func(t *testing.T) {
+		var created bool
+		server := newCreateTestServer(t, &created)
+		defer server.Close()
+
+		err := runCreate(cmdtest.Options(server, &bytes.Buffer{}), createOptions{
+			Project: "PROJ", IssueType: "Story", Summary: "Crash",
+		})
+		require.ErrorIs(t, err, api.ErrInvalidIssueType)
+		assert.False(t, created)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var created bool
+		server := newCreateTestServer(t, &created)
+		defer server.Close()
+
+		var stdout bytes.Buffer
+		err := runCreate(cmdtest.Options(server, &stdout), createOptions{
+			Project: "PROJ", IssueType: "bug", Summary: "Crash", Fields: []string{"Team=platform"},
+		})
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Contains(t, stdout.String(), "Created issue PROJ-1")
+	})
+}
+
+func TestCreateCmd_OutputTemplate(t *testing.T) {
+	var created bool
+	server := newCreateTestServer(t, &created)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	cmd, opts := root.NewCmd()
+	*opts = *cmdtest.Options(server, &stdout)
+	Register(cmd, opts)
+
+	cmd.SetArgs([]string{"issues", "create", "-p", "PROJ", "-t", "Bug", "-s", "Crash", "--no-validate",
+		"-o", "template", "--template", "created {{.Key}}"})
+	require.NoError(t, cmd.Execute())
+
+	assert.True(t, created)
+	assert.Equal(t, "created PROJ-1\n", stdout.String())
+}
+
+func TestRunCreate_RequiresSummary(t *testing.T) {
+	err := runCreate(&root.Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}, createOptions{Project: "PROJ"})
+	assert.ErrorContains(t, err, "--summary is required")
+}
+
+func TestRunCreate_Template(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	dir, err := issuetemplate.Dir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bug.yaml"), []byte(`project: PROJ
+type: Bug
+variables:
+  component: {required: true}
+summary: "[{{.component}}] Crash"
+labels: [bug, "{{.component}}"]
+subtasks:
+  - summary: Add regression test
+`), 0o600))
+
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"labels","name":"Labels","schema":{"type":"array","items":"string"}}]`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes":
+			w.Write([]byte(`{"total":2,"issueTypes":[{"id":"1","name":"Bug"},{"id":"5","name":"Subtask","subtask":true}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes/1":
+			w.Write([]byte(`{"total":0,"fields":[]}`))
+		case r.URL.Path == "/rest/api/3/issue" && r.Method == http.MethodPost:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			requests = append(requests, body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id":"%d","key":"PROJ-%d"}`, len(requests), len(requests))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	create := createOptions{Template: "bug", Vars: []string{"component=api"}, IssueType: "Task"}
+	require.NoError(t, applyTemplate(&create, false))
+	assert.Equal(t, "Bug", create.IssueType)
+
+	var stdout bytes.Buffer
+	require.NoError(t, runCreate(cmdtest.Options(server, &stdout), create))
+
+	require.Len(t, requests, 2)
+	fields := requests[0]["fields"].(map[string]interface{})
+	assert.Equal(t, "[api] Crash", fields["summary"])
+	assert.Equal(t, []interface{}{"bug", "api"}, fields["labels"])
+
+	subtask := requests[1]["fields"].(map[string]interface{})
+	assert.Equal(t, "Add regression test", subtask["summary"])
+	assert.Equal(t, map[string]interface{}{"key": "PROJ-1"}, subtask["parent"])
+	assert.Equal(t, map[string]interface{}{"name": "Subtask"}, subtask["issuetype"])
+	assert.Contains(t, stdout.String(), "Created subtask PROJ-2")
+}
@@ -0,0 +1,425 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/editor"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/prompt"
+)
+
+// frontMatterDelimiter separates the YAML front matter from the description
+const frontMatterDelimiter = "---"
+
+// openEditor opens a file in the user's editor; tests replace it
+var openEditor = editor.Open
+
+// editOptions holds the flags of the edit command
+type editOptions struct {
+	Fields []string
+	Force  bool
+}
+
+// editDocument is the editable representation of an issue: YAML front matter
+// followed by the description as Markdown
+type editDocument struct {
+	Summary     string                 `yaml:"summary"`
+	Assignee    string                 `yaml:"assignee"`
+	Priority    string                 `yaml:"priority"`
+	Labels      []string               `yaml:"labels"`
+	Components  []string               `yaml:"components"`
+	Fields      map[string]interface{} `yaml:"fields,omitempty"`
+	Description string                 `yaml:"-"`
+}
+
+func newEditCmd(opts *root.Options) *cobra.Command {
+	var edit editOptions
+
+	cmd := &cobra.Command{
+		Use:   "edit <issue-key>",
+		Short: "Edit an issue in your editor",
+		Long: `Open an issue in your editor as a Markdown document with YAML front matter.
+
+The front matter holds the summary, assignee, priority, labels, components and
+any custom fields selected with --fields; the body is the description. After
+the editor exits, only the fields you changed are sent to Jira.
+
+The editor is taken from JIRA_EDITOR, VISUAL or EDITOR. If the issue was
+updated by someone else while you were editing, jtk reports the conflict
+instead of saving (use --force to save anyway).
+
+Markdown cannot represent every part of a Jira description: attachments and
+images are left out, and mentions, statuses, emoji, cards and panels become
+plain text. If such content would be lost by saving a changed description,
+jtk refuses and names it (use --force to save anyway).`,
+		Example: `  # Edit summary, description and common fields
+  jtk issues edit PROJ-123
+
+  # Include custom fields in the front matter
+  jtk issues edit PROJ-123 --fields "Story Points,Team"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(opts, args[0], edit)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&edit.Fields, "fields", nil, "Custom fields to include, by name or ID (comma-separated)")
+	cmd.Flags().BoolVar(&edit.Force, "force", false, "Save even if the issue changed while editing or the description loses content Markdown cannot represent")
+
+	return cmd
+}
+
+func runEdit(opts *root.Options, issueKey string, edit editOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.GetIssue(issueKey)
+	if err != nil {
+		return err
+	}
+
+	var custom []api.Field
+	if len(edit.Fields) > 0 {
+		allFields, err := client.GetFields()
+		if err != nil {
+			return fmt.Errorf("failed to get field metadata: %w", err)
+		}
+		for _, name := range edit.Fields {
+			field := api.FindFieldByID(allFields, strings.TrimSpace(name))
+			if field == nil {
+				field = api.FindFieldByName(allFields, strings.TrimSpace(name))
+			}
+			if field == nil {
+				return fmt.Errorf("unknown field: %s", name)
+			}
+			custom = append(custom, *field)
+		}
+	}
+
+	original := issueToEditDocument(issue, custom)
+	content, err := renderEditDocument(issue, original)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "jtk-"+issue.Key+"-*.md")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path) //nolint:errcheck
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := openEditor(path, opts.Stdin, opts.Stdout, opts.Stderr); err != nil {
+		os.Remove(path) //nolint:errcheck
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read edited file: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		os.Remove(path) //nolint:errcheck
+		v.Info("Edit aborted: the file is empty")
+		return nil
+	}
+
+	edited, err := parseEditDocument(data)
+	if err != nil {
+		return fmt.Errorf("%w (your edits are kept in %s)", err, path)
+	}
+
+	args, direct, changed := diffEditDocuments(original, edited, custom)
+	if len(changed) == 0 {
+		os.Remove(path) //nolint:errcheck
+		v.Info("No changes to %s", issue.Key)
+		return nil
+	}
+
+	if lost := lostDescriptionNodes(issue, changed); len(lost) > 0 && !edit.Force {
+		return fmt.Errorf("the description of %s contains %s, which cannot be edited as Markdown and would be removed; your edits are kept in %s (use --force to save anyway)",
+			issue.Key, strings.Join(lost, ", "), path)
+	}
+
+	if !edit.Force {
+		current, err := client.GetIssue(issueKey)
+		if err != nil {
+			return err
+		}
+		if !updatedAt(current).Equal(updatedAt(issue)) {
+			conflict := fmt.Errorf("%s was updated at %s while you were editing; your edits are kept in %s (use --force to save anyway)",
+				issue.Key, current.Fields.Updated, path)
+			if !prompt.IsInteractive(opts.Stdin, opts.Stdout) {
+				return conflict
+			}
+			v.Warning("%s was updated at %s while you were editing", issue.Key, current.Fields.Updated)
+			ok, err := prompt.New(opts.Stdin, opts.Stdout).Confirm("Overwrite the changed fields anyway?", false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return conflict
+			}
+		}
+	}
+
+	changes, err := client.ResolveFieldArgs(args)
+	if err != nil {
+		return fmt.Errorf("%w (your edits are kept in %s)", err, path)
+	}
+	for k, val := range direct {
+		changes.Fields[k] = val
+	}
+
+	req := api.BuildUpdateRequest(changes.Fields)
+	if err := client.UpdateIssue(issue.Key, req); err != nil {
+		return fmt.Errorf("%w (your edits are kept in %s)", err, path)
+	}
+	os.Remove(path) //nolint:errcheck
+
+	v.Success("Updated issue %s (%s)", issue.Key, strings.Join(changed, ", "))
+	return nil
+}
+
+// lostDescriptionNodes returns the types of the description nodes that saving
+// an edited description would lose, or nothing when it was not changed
+func lostDescriptionNodes(issue *api.Issue, changed []string) []string {
+	d := issue.Fields.Description
+	if d == nil || d.ADF == nil {
+		return nil
+	}
+	for _, name := range changed {
+		if name == "description" {
+			return api.LossyADFNodes(d.ADF)
+		}
+	}
+	return nil
+}
+
+// issueToEditDocument extracts the editable fields of an issue
+func issueToEditDocument(issue *api.Issue, custom []api.Field) *editDocument {
+	f := issue.Fields
+	doc := &editDocument{
+		Summary:    f.Summary,
+		Labels:     f.Labels,
+		Components: make([]string, 0, len(f.Components)),
+	}
+	if doc.Labels == nil {
+		doc.Labels = []string{}
+	}
+	if f.Assignee != nil {
+		doc.Assignee = f.Assignee.EmailAddress
+		if doc.Assignee == "" {
+			doc.Assignee = f.Assignee.AccountID
+		}
+	}
+	if f.Priority != nil {
+		doc.Priority = f.Priority.Name
+	}
+	for _, c := range f.Components {
+		doc.Components = append(doc.Components, c.Name)
+	}
+	if f.Description != nil {
+		if f.Description.ADF != nil {
+			doc.Description = api.ADFToMarkdown(f.Description.ADF)
+		} else {
+			doc.Description = f.Description.Text
+		}
+	}
+
+	if len(custom) > 0 {
+		doc.Fields = make(map[string]interface{}, len(custom))
+		for _, field := range custom {
+			doc.Fields[field.Name] = editableValue(f.CustomFields[field.ID])
+		}
+	}
+
+	return doc
+}
+
+// updatedAt returns when an issue was last updated, to detect concurrent edits
+func updatedAt(issue *api.Issue) time.Time {
+	if issue.Fields.Updated == nil {
+		return time.Time{}
+	}
+	return issue.Fields.Updated.Time
+}
+
+// renderEditDocument writes the document as front matter and Markdown. Read-only
+// details are included as YAML comments for reference.
+func renderEditDocument(issue *api.Issue, doc *editDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	fmt.Fprintf(&buf, "# %s", issue.Key)
+	if issue.Fields.IssueType != nil {
+		fmt.Fprintf(&buf, " · %s", issue.Fields.IssueType.Name)
+	}
+	if issue.Fields.Status != nil {
+		fmt.Fprintf(&buf, " · %s", issue.Fields.Status.Name)
+	}
+	buf.WriteString("\n# Only changed fields are saved. The description follows the closing ---.\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to render issue: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render issue: %w", err)
+	}
+
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(doc.Description)
+	return buf.Bytes(), nil
+}
+
+// parseEditDocument splits an edited file into front matter and description
+func parseEditDocument(data []byte) (*editDocument, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return nil, fmt.Errorf("the file must start with a %s front matter line", frontMatterDelimiter)
+	}
+	rest := text[len(frontMatterDelimiter)+1:]
+
+	end := strings.Index(rest, "\n"+frontMatterDelimiter+"\n")
+	var front, body string
+	switch {
+	case end >= 0:
+		front, body = rest[:end+1], rest[end+len(frontMatterDelimiter)+2:]
+	case strings.HasSuffix(rest, "\n"+frontMatterDelimiter):
+		front = strings.TrimSuffix(rest, frontMatterDelimiter)
+	default:
+		return nil, fmt.Errorf("missing closing %s after the front matter", frontMatterDelimiter)
+	}
+
+	var doc editDocument
+	dec := yaml.NewDecoder(strings.NewReader(front))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	doc.Description = strings.TrimLeft(body, "\n")
+	return &doc, nil
+}
+
+// diffEditDocuments compares the original and edited documents. It returns
+// field arguments for changed fields, fields to set directly (summary and
+// description) and the names of everything that changed.
+func diffEditDocuments(original, edited *editDocument, custom []api.Field) (args []string, direct map[string]interface{}, changed []string) {
+	direct = make(map[string]interface{})
+
+	if strings.TrimSpace(edited.Summary) != strings.TrimSpace(original.Summary) {
+		direct["summary"] = strings.TrimSpace(edited.Summary)
+		changed = append(changed, "summary")
+	}
+	if strings.TrimSpace(edited.Description) != strings.TrimSpace(original.Description) {
+		direct["description"] = api.NewADFDocument(strings.TrimSpace(edited.Description))
+		changed = append(changed, "description")
+	}
+
+	simple := []struct {
+		id       string
+		old, new interface{}
+	}{
+		{"assignee", original.Assignee, edited.Assignee},
+		{"priority", original.Priority, edited.Priority},
+		{"labels", original.Labels, edited.Labels},
+		{"components", original.Components, edited.Components},
+	}
+	for _, s := range simple {
+		if valueString(s.old) != valueString(s.new) {
+			args = append(args, s.id+"="+valueString(s.new))
+			changed = append(changed, s.id)
+		}
+	}
+
+	for _, field := range custom {
+		newValue, ok := edited.Fields[field.Name]
+		if !ok {
+			continue
+		}
+		if valueString(original.Fields[field.Name]) != valueString(newValue) {
+			args = append(args, field.ID+"="+valueString(newValue))
+			changed = append(changed, field.Name)
+		}
+	}
+
+	return args, direct, changed
+}
+
+// editableValue converts a field value from the API to a plain value that is
+// convenient to edit: option names, user emails, lists of names
+func editableValue(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, valueString(editableValue(item)))
+		}
+		return items
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			data, _ := json.Marshal(v)
+			var doc api.ADFDocument
+			if err := json.Unmarshal(data, &doc); err == nil {
+				return strings.TrimSpace(api.ADFToMarkdown(&doc))
+			}
+		}
+		for _, key := range []string{"value", "name", "key", "emailAddress", "accountId", "id"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					return s + " -> " + valueString(editableValue(child))
+				}
+				return s
+			}
+		}
+		return ""
+	default:
+		return v
+	}
+}
+
+// valueString renders a value for comparison
+func valueString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []string:
+		return strings.Join(val, ",")
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = valueString(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,227 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+const editIssueJSON = `{
+	"key": "PROJ-1",
+	"fields": {
+		"summary": "Old summary",
+		"updated": "%s",
+		"labels": ["backend"],
+		"priority": {"id": "3", "name": "Medium"},
+		"assignee": {"accountId": "abc", "emailAddress": "jane@example.com"},
+		"issuetype": {"name": "Bug"},
+		"status": {"name": "Open"},
+		"customfield_10016": 3,
+		"description": {"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Keep "}, {"type": "text", "text": "this", "marks": [{"type": "strong"}]}]}
+		]}
+	}
+}`
+
+// stubEditor replaces the editor with a function that rewrites the file
+func stubEditor(t *testing.T, edit func(string) string) {
+	t.Helper()
+	orig := openEditor
+	t.Cleanup(func() { openEditor = orig })
+	openEditor = func(path string, _ io.Reader, _, _ io.Writer) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return os.WriteFile(path, []byte(edit(string(data))), 0o600)
+	}
+}
+
+func newEditTestServer(t *testing.T, updatedAfter string, update *map[string]interface{}) *httptest.Server {
+	gets := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1" && r.Method == http.MethodGet:
+			gets++
+			updated := "2024-01-01T10:00:00.000+0000"
+			if gets > 1 {
+				updated = updatedAfter
+			}
+			w.Write([]byte(strings.Replace(editIssueJSON, "%s", updated, 1)))
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1" && r.Method == http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(update))
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/3/field":
+			w.Write([]byte(`[
+				{"id":"labels","name":"Labels","schema":{"type":"array","items":"string"}},
+				{"id":"priority","name":"Priority","schema":{"type":"priority"}},
+				{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}
+			]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunEdit_SendsOnlyChangedFields(t *testing.T) {
+	var update map[string]interface{}
+	server := newEditTestServer(t, "2024-01-01T10:00:00.000+0000", &update)
+	defer server.Close()
+
+	var rendered string
+	stubEditor(t, func(content string) string {
+		rendered = content
+		content = strings.Replace(content, "summary: Old summary", "summary: New summary", 1)
+		content = strings.Replace(content, "- backend", "- backend\n  - urgent", 1)
+		content = strings.Replace(content, "Story Points: 3", "Story Points: 5", 1)
+		return content
+	})
+
+	var stdout bytes.Buffer
+	require.NoError(t, runEdit(cmdtest.Options(server, &stdout), "PROJ-1", editOptions{Fields: []string{"Story Points"}}))
+
+	assert.Contains(t, rendered, "# PROJ-1 · Bug · Open")
+	assert.Contains(t, rendered, "assignee: jane@example.com")
+	assert.Contains(t, rendered, "---\n\nKeep **this**\n")
+
+	fields := update["fields"].(map[string]interface{})
+	assert.Equal(t, "New summary", fields["summary"])
+	assert.Equal(t, []interface{}{"backend", "urgent"}, fields["labels"])
+	assert.Equal(t, float64(5), fields["customfield_10016"])
+	assert.NotContains(t, fields, "description")
+	assert.NotContains(t, fields, "priority")
+	assert.NotContains(t, fields, "assignee")
+	assert.Contains(t, stdout.String(), "Updated issue PROJ-1 (summary, labels, Story Points)")
+}
+
+func TestRunEdit_NoChanges(t *testing.T) {
+	var update map[string]interface{}
+	server := newEditTestServer(t, "2024-01-01T10:00:00.000+0000", &update)
+	defer server.Close()
+
+	stubEditor(t, func(content string) string { return content })
+
+	var stdout bytes.Buffer
+	require.NoError(t, runEdit(cmdtest.Options(server, &stdout), "PROJ-1", editOptions{}))
+	assert.Nil(t, update)
+	assert.Contains(t, stdout.String(), "No changes to PROJ-1")
+}
+
+func TestRunEdit_Conflict(t *testing.T) {
+	var update map[string]interface{}
+	server := newEditTestServer(t, "2024-01-01T10:05:00.000+0000", &update)
+	defer server.Close()
+
+	stubEditor(t, func(content string) string {
+		return strings.Replace(content, "Keep **this**", "Changed", 1)
+	})
+
+	err := runEdit(cmdtest.Options(server, &bytes.Buffer{}), "PROJ-1", editOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was updated at 2024-01-01T10:05:00Z while you were editing")
+	assert.Nil(t, update)
+
+	// The edits are kept for the user to recover
+	path := err.Error()[strings.Index(err.Error(), "kept in ")+len("kept in "):]
+	path = path[:strings.Index(path, " (")]
+	defer os.Remove(path)
+	data, readErr := os.ReadFile(path)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "Changed")
+
+	// --force saves anyway
+	server2 := newEditTestServer(t, "2024-01-01T10:05:00.000+0000", &update)
+	defer server2.Close()
+	require.NoError(t, runEdit(cmdtest.Options(server2, &bytes.Buffer{}), "PROJ-1", editOptions{Force: true}))
+	assert.Equal(t, api.NewADFDocument("Changed"), decodeADF(t, update["fields"].(map[string]interface{})["description"]))
+}
+
+func TestParseEditDocument(t *testing.T) {
+	doc, err := parseEditDocument([]byte("---\nsummary: Hi\nlabels: [a]\n---\n\nBody\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hi", doc.Summary)
+	assert.Equal(t, []string{"a"}, doc.Labels)
+	assert.Equal(t, "Body\n", doc.Description)
+
+	doc, err = parseEditDocument([]byte("---\nsummary: Hi\n---"))
+	require.NoError(t, err)
+	assert.Equal(t, "", doc.Description)
+
+	_, err = parseEditDocument([]byte("summary: Hi\n"))
+	assert.Error(t, err)
+	_, err = parseEditDocument([]byte("---\nsummary: Hi\n"))
+	assert.Error(t, err)
+	_, err = parseEditDocument([]byte("---\nsumary: Hi\n---\n"))
+	assert.Error(t, err)
+}
+
+func decodeADF(t *testing.T, v interface{}) *api.ADFDocument {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var doc api.ADFDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	return &doc
+}
+
+func TestRunEdit_LossyDescription(t *testing.T) {
+	issueJSON := `{"key": "PROJ-1", "fields": {
+		"summary": "Old summary",
+		"updated": "2024-01-01T10:00:00.000+0000",
+		"issuetype": {"name": "Bug"},
+		"status": {"name": "Open"},
+		"description": {"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [
+				{"type": "text", "text": "See screenshot, cc "},
+				{"type": "mention", "attrs": {"id": "abc", "text": "@Jane"}}
+			]},
+			{"type": "mediaSingle", "content": [{"type": "media", "attrs": {"id": "f1", "type": "file", "collection": "c"}}]}
+		]}
+	}}`
+	var update map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(issueJSON))
+		case http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	stubEditor(t, func(content string) string {
+		return strings.Replace(content, "See screenshot", "See the screenshot", 1)
+	})
+
+	err := runEdit(cmdtest.Options(server, &bytes.Buffer{}), "PROJ-1", editOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the description of PROJ-1 contains mention, mediaSingle, media")
+	assert.Nil(t, update)
+	path := err.Error()[strings.Index(err.Error(), "kept in ")+len("kept in "):]
+	os.Remove(path[:strings.Index(path, " (")]) //nolint:errcheck
+
+	// Other fields can still be edited
+	stubEditor(t, func(content string) string {
+		return strings.Replace(content, "summary: Old summary", "summary: New summary", 1)
+	})
+	require.NoError(t, runEdit(cmdtest.Options(server, &bytes.Buffer{}), "PROJ-1", editOptions{}))
+	assert.NotContains(t, update["fields"], "description")
+
+	// --force saves anyway
+	stubEditor(t, func(content string) string {
+		return strings.Replace(content, "See screenshot", "See the screenshot", 1)
+	})
+	require.NoError(t, runEdit(cmdtest.Options(server, &bytes.Buffer{}), "PROJ-1", editOptions{Force: true}))
+	assert.Contains(t, update["fields"], "description")
+}
@@ -85,7 +85,7 @@ func runFieldOptions(opts *root.Options, fieldNameOrID, issueKey string) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(options)
 	}
 
@@ -52,7 +52,7 @@ func runFields(opts *root.Options, issueKey string, customOnly bool) error {
 			return err
 		}
 
-		if opts.Output == "json" {
+		if opts.DataOutput() {
 			return v.JSON(meta)
 		}
 
@@ -102,7 +102,7 @@ func runFields(opts *root.Options, issueKey string, customOnly bool) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(fields)
 	}
 
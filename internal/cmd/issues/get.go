@@ -1,42 +1,74 @@
 package issues
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/jira-ticket-cli/api"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
 )
 
 func newGetCmd(opts *root.Options) *cobra.Command {
-	return &cobra.Command{
+	var offline bool
+
+	cmd := &cobra.Command{
 		Use:   "get <issue-key>",
 		Short: "Get issue details",
-		Long:  "Retrieve and display details for a specific issue.",
+		Long: `Retrieve and display details for a specific issue.
+
+With --offline, the issue is read from the local store filled by 'jtk sync'
+instead of from Jira. Without it, the store is used as a fallback when Jira
+cannot be reached, with a warning that the copy may be out of date.`,
 		Example: `  jtk issues get PROJ-123
-  jtk issues get PROJ-123 -o json`,
+  jtk issues get PROJ-123 -o json
+  jtk issues get PROJ-123 --offline`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGet(opts, args[0])
+			return runGet(opts, args[0], offline)
 		},
 	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "Read the issue from the local store instead of Jira")
+
+	return cmd
 }
 
-func runGet(opts *root.Options, issueKey string) error {
+func runGet(opts *root.Options, issueKey string, offline bool) error {
 	v := opts.View()
 
-	client, err := opts.APIClient()
-	if err != nil {
-		return err
-	}
+	// Offline, the client is only used for the issue URL and may be missing
+	client, clientErr := opts.APIClient()
 
-	issue, err := client.GetIssue(issueKey)
-	if err != nil {
-		return err
+	var issue *api.Issue
+	if offline {
+		record, err := offlineIssue(opts, issueKey)
+		if err != nil {
+			return err
+		}
+		issue = &record.Issue
+	} else {
+		if clientErr != nil {
+			return clientErr
+		}
+		var err error
+		if issue, err = client.GetIssue(issueKey); err != nil {
+			record, ok := fallbackIssue(opts, issueKey, err)
+			if !ok {
+				return err
+			}
+			v.Warning("Could not reach Jira; showing the copy of %s synced %s", record.Issue.Key, v.Time(record.SyncedAt))
+			issue = &record.Issue
+		}
 	}
 
 	// For JSON output, return the full issue
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(issue)
 	}
 
@@ -68,10 +100,7 @@ func runGet(opts *root.Options, issueKey string) error {
 
 	description := ""
 	if issue.Fields.Description != nil {
-		description = issue.Fields.Description.ToPlainText()
-		if len(description) > 200 {
-			description = description[:200] + "..."
-		}
+		description = view.Truncate(issue.Fields.Description.ToPlainText(), 200)
 	}
 
 	v.Println("Key:         %s", issue.Key)
@@ -81,43 +110,52 @@ func runGet(opts *root.Options, issueKey string) error {
 	v.Println("Priority:    %s", priority)
 	v.Println("Assignee:    %s", assignee)
 	v.Println("Project:     %s", project)
+	if issue.Fields.Created != nil {
+		v.Println("Created:     %s", v.Time(issue.Fields.Created.Time))
+	}
+	if issue.Fields.Updated != nil {
+		v.Println("Updated:     %s", v.Time(issue.Fields.Updated.Time))
+	}
+	if issue.Fields.DueDate != nil {
+		v.Println("Due:         %s", v.Date(issue.Fields.DueDate.Time))
+	}
 	if description != "" {
 		v.Println("Description: %s", description)
 	}
-	v.Println("URL:         %s", client.IssueURL(issue.Key))
+	if clientErr == nil {
+		v.Println("URL:         %s", client.IssueURL(issue.Key))
+	}
 
 	return nil
 }
 
-func formatAssignee(name string) string {
-	if name == "" {
-		return "Unassigned"
+// offlineIssue reads an issue from the local store
+func offlineIssue(opts *root.Options, issueKey string) (*store.Record, error) {
+	s, err := opts.Store()
+	if err != nil {
+		return nil, err
 	}
-	return name
-}
+	defer s.Close()
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+	record, err := s.Get(strings.ToUpper(issueKey))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("%s is not in the local store; run 'jtk sync' with a query that includes it", issueKey)
 	}
-	return s[:max-3] + "..."
+	return record, err
 }
 
-func orDash(s string) string {
-	if s == "" {
-		return "-"
+// fallbackIssue reads an issue from the local store when err shows that Jira
+// could not be reached. Errors returned by Jira itself are not masked.
+func fallbackIssue(opts *root.Options, issueKey string, err error) (*store.Record, bool) {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return nil, false
 	}
-	return s
-}
-
-func formatIssueRow(key, summary, status, assignee, issueType string) []string {
-	return []string{
-		key,
-		truncate(summary, 50),
-		orDash(status),
-		formatAssignee(assignee),
-		orDash(issueType),
+	record, err := offlineIssue(opts, issueKey)
+	if err != nil {
+		return nil, false
 	}
+	return record, true
 }
 
 // Helper to safely extract string fields
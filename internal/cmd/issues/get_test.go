@@ -0,0 +1,81 @@
+package issues
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
+)
+
+func TestRunGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/PROJ-1", r.URL.Path)
+		w.Write([]byte(`{"key":"PROJ-1","fields":{"summary":"Online summary","status":{"name":"To Do"}}}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runGet(opts, "PROJ-1", false))
+	assert.Contains(t, stdout.String(), "Online summary")
+	assert.Contains(t, stdout.String(), "/browse/PROJ-1")
+}
+
+func TestRunGet_Offline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+
+	s, err := opts.Store()
+	require.NoError(t, err)
+	require.NoError(t, s.Put(store.Record{Issue: api.Issue{Key: "PROJ-1", Fields: api.IssueFields{
+		Summary: "Offline summary",
+		Status:  &api.Status{Name: "In Progress"},
+	}}}))
+	require.NoError(t, s.Close())
+
+	require.NoError(t, runGet(opts, "proj-1", true))
+	assert.Contains(t, stdout.String(), "Offline summary")
+	assert.Contains(t, stdout.String(), "In Progress")
+
+	err = runGet(opts, "PROJ-2", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PROJ-2 is not in the local store")
+	assert.Contains(t, err.Error(), "jtk sync")
+}
+
+func TestRunGet_FallsBackToStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+	server.Close()
+
+	s, err := opts.Store()
+	require.NoError(t, err)
+	require.NoError(t, s.Put(store.Record{Issue: api.Issue{Key: "PROJ-1", Fields: api.IssueFields{Summary: "Cached summary"}}}))
+	require.NoError(t, s.Close())
+
+	require.NoError(t, runGet(opts, "PROJ-1", false))
+	assert.Contains(t, stdout.String(), "Cached summary")
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Could not reach Jira")
+
+	// Without a stored copy the network error is returned
+	err = runGet(opts, "PROJ-2", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "request failed")
+}
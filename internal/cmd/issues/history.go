@@ -0,0 +1,202 @@
+package issues
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// historyOptions holds the flags of issues history
+type historyOptions struct {
+	Fields       []string
+	Since        string
+	TimeInStatus bool
+}
+
+// historyChange is one field change in an issue's history
+type historyChange struct {
+	ID        string   `json:"id"`
+	Created   api.Time `json:"created"`
+	Author    string   `json:"author"`
+	AccountID string   `json:"accountId,omitempty"`
+	Field     string   `json:"field"`
+	FieldID   string   `json:"fieldId,omitempty"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+}
+
+// statusTimeRow is the time an issue spent in a status, for JSON output
+type statusTimeRow struct {
+	Status   string  `json:"status"`
+	Seconds  float64 `json:"seconds"`
+	Duration string  `json:"duration"`
+	Visits   int     `json:"visits"`
+}
+
+func newHistoryCmd(opts *root.Options) *cobra.Command {
+	var history historyOptions
+
+	cmd := &cobra.Command{
+		Use:   "history <issue-key>",
+		Short: "Show an issue's change history",
+		Long: `Show who changed which fields of an issue, from what to what, and when.
+
+--time-in-status instead breaks down how long the issue has spent in each
+status since it was created.`,
+		Example: `  # Full history
+  jtk issues history PROJ-123
+
+  # Status changes in the last two weeks
+  jtk issues history PROJ-123 --field status --since 2w
+
+  # How long the issue spent in each status
+  jtk issues history PROJ-123 --time-in-status`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(opts, args[0], history)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&history.Fields, "field", nil, "Only show changes to these fields (name or ID, comma-separated)")
+	cmd.Flags().StringVar(&history.Since, "since", "", "Only show changes after a date (2024-03-01), timestamp or span (7d, 12h)")
+	cmd.Flags().BoolVar(&history.TimeInStatus, "time-in-status", false, "Show the time spent in each status instead of the changes")
+
+	return cmd
+}
+
+func runHistory(opts *root.Options, issueKey string, history historyOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	changelog, err := client.GetChangelog(issueKey)
+	if err != nil {
+		return err
+	}
+
+	if history.TimeInStatus {
+		return runTimeInStatus(opts, client, issueKey, changelog)
+	}
+
+	var since time.Time
+	if history.Since != "" {
+		if since, err = api.ParseSince(history.Since, time.Now().In(v.Location)); err != nil {
+			return err
+		}
+	}
+
+	changes := filterHistory(changelog, history.Fields, since)
+
+	if opts.DataOutput() {
+		return v.JSON(changes)
+	}
+
+	if len(changes) == 0 {
+		v.Info("No changes found for %s", issueKey)
+		return nil
+	}
+
+	headers := []string{"WHEN", "AUTHOR", "FIELD", "FROM", "TO"}
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		from, to := c.From, c.To
+		if from == "" {
+			from = "-"
+		}
+		if to == "" {
+			to = "-"
+		}
+		rows[i] = []string{v.Time(c.Created.Time), c.Author, c.Field, from, to}
+	}
+
+	return v.Table(headers, rows)
+}
+
+// filterHistory flattens a changelog into single field changes, keeping
+// those to the given fields (all when empty) made after since
+func filterHistory(changelog []api.ChangelogEntry, fields []string, since time.Time) []historyChange {
+	changes := []historyChange{}
+	for _, entry := range changelog {
+		if !since.IsZero() && entry.Created.Before(since) {
+			continue
+		}
+
+		author, accountID := "", ""
+		if entry.Author != nil {
+			author, accountID = entry.Author.DisplayName, entry.Author.AccountID
+		}
+
+		for _, item := range entry.Items {
+			if !matchesAnyField(item, fields) {
+				continue
+			}
+			changes = append(changes, historyChange{
+				ID:        entry.ID,
+				Created:   entry.Created,
+				Author:    author,
+				AccountID: accountID,
+				Field:     item.Field,
+				FieldID:   item.FieldID,
+				From:      item.FromString,
+				To:        item.ToString,
+			})
+		}
+	}
+	return changes
+}
+
+func matchesAnyField(item api.ChangelogItem, fields []string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, f := range fields {
+		if item.Matches(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func runTimeInStatus(opts *root.Options, client *api.Client, issueKey string, changelog []api.ChangelogEntry) error {
+	v := opts.View()
+
+	issue, err := client.GetIssue(issueKey)
+	if err != nil {
+		return err
+	}
+
+	var created time.Time
+	if issue.Fields.Created != nil {
+		created = issue.Fields.Created.Time
+	}
+	current := ""
+	if issue.Fields.Status != nil {
+		current = issue.Fields.Status.Name
+	}
+
+	times := api.TimeInStatus(created, current, changelog, time.Now())
+
+	if opts.DataOutput() {
+		rows := make([]statusTimeRow, len(times))
+		for i, t := range times {
+			rows[i] = statusTimeRow{Status: t.Status, Seconds: t.Duration.Seconds(), Duration: t.Duration.String(), Visits: t.Visits}
+		}
+		return v.JSON(rows)
+	}
+
+	headers := []string{"STATUS", "TIME", "VISITS"}
+	rows := make([][]string, len(times))
+	for i, t := range times {
+		rows[i] = []string{t.Status, view.Duration(t.Duration), strconv.Itoa(t.Visits)}
+	}
+
+	return v.Table(headers, rows)
+}
@@ -0,0 +1,98 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func newHistoryTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-1/changelog":
+			w.Write([]byte(`{"total":2,"isLast":true,"values":[
+				{"id":"10","author":{"accountId":"a1","displayName":"Ada"},"created":"2024-03-01T12:00:00.000+0000","items":[
+					{"field":"status","fieldId":"status","fromString":"To Do","toString":"In Progress"},
+					{"field":"assignee","fieldId":"assignee","toString":"Ada"}
+				]},
+				{"id":"11","author":{"accountId":"b2","displayName":"Bob"},"created":"2024-03-05T12:00:00.000+0000","items":[
+					{"field":"Story Points","fieldId":"customfield_10016","fromString":"3","toString":"5"}
+				]}
+			]}`))
+		case "/rest/api/3/issue/PROJ-1":
+			w.Write([]byte(`{"key":"PROJ-1","fields":{"created":"2024-03-01T09:00:00.000+0000","status":{"name":"In Progress"}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunHistory(t *testing.T) {
+	server := newHistoryTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "csv"
+	opts.TZ = "UTC"
+
+	require.NoError(t, runHistory(opts, "PROJ-1", historyOptions{}))
+	assert.Equal(t, "WHEN,AUTHOR,FIELD,FROM,TO\n"+
+		"2024-03-01T12:00:00Z,Ada,status,To Do,In Progress\n"+
+		"2024-03-01T12:00:00Z,Ada,assignee,-,Ada\n"+
+		"2024-03-05T12:00:00Z,Bob,Story Points,3,5\n", stdout.String())
+}
+
+func TestRunHistory_Filters(t *testing.T) {
+	server := newHistoryTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	// Fields match by name or ID
+	require.NoError(t, runHistory(opts, "PROJ-1", historyOptions{Fields: []string{"STATUS", "customfield_10016"}}))
+	var changes []historyChange
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	require.Len(t, changes, 2)
+	assert.Equal(t, "In Progress", changes[0].To)
+	assert.Equal(t, "a1", changes[0].AccountID)
+	assert.Equal(t, "Story Points", changes[1].Field)
+
+	stdout.Reset()
+	require.NoError(t, runHistory(opts, "PROJ-1", historyOptions{Since: "2024-03-02"}))
+	changes = nil
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "Bob", changes[0].Author)
+
+	err := runHistory(opts, "PROJ-1", historyOptions{Since: "someday"})
+	assert.Error(t, err)
+}
+
+func TestRunHistory_TimeInStatus(t *testing.T) {
+	server := newHistoryTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runHistory(opts, "PROJ-1", historyOptions{TimeInStatus: true}))
+	var rows []statusTimeRow
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "To Do", rows[0].Status)
+	assert.Equal(t, float64(3*60*60), rows[0].Seconds)
+	assert.Equal(t, "In Progress", rows[1].Status)
+	assert.Equal(t, 1, rows[1].Visits)
+}
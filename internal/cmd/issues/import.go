@@ -0,0 +1,467 @@
+package issues
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+)
+
+// issueKeyPattern matches issue keys such as PROJ-123
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// Import result statuses
+const (
+	importCreated = "created"
+	importFailed  = "failed"
+	importValid   = "valid"
+)
+
+// importOptions holds the flags of the import command
+type importOptions struct {
+	File      string
+	Format    string
+	Mapping   string
+	Project   string
+	IssueType string
+	Results   string
+	DryRun    bool
+}
+
+// importItem tracks one row through validation and creation
+type importItem struct {
+	Row       importRow
+	Ref       string
+	Summary   string
+	ParentRef *importItem
+	Request   *api.CreateIssueRequest
+	Result    importResult
+}
+
+// importResult is the outcome for one row, as written to the results file
+type importResult struct {
+	Row     int    `json:"row"`
+	Ref     string `json:"ref,omitempty"`
+	Summary string `json:"summary"`
+	Key     string `json:"key,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newImportCmd(opts *root.Options) *cobra.Command {
+	var imp importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create issues from a CSV, TSV, JSON Lines or YAML file",
+		Long: `Create issues in bulk from a file with one issue per row.
+
+Columns are matched to fields by header name: project, type, summary,
+description and parent are recognized, and any other header is resolved as a
+field name or ID. A mapping file can rename columns:
+
+  columns:
+    Title: summary
+    Estimate: Story Points
+    Notes: ""          # ignore this column
+  id: Ref              # column with a row reference
+  parent: Parent       # column with a parent row reference or issue key
+
+A parent may refer to another row of the same file through its reference, so
+epics and their children or stories and their subtasks can be imported
+together. Issues are created in batches of 50; rows are only sent once their
+parent exists.
+
+Every row's outcome is written to a results file (by default next to the input
+as <name>.results.csv, or JSON when the name ends in .json).`,
+		Example: `  # Import a spreadsheet export
+  jtk issues import --file requirements.csv --project PROJ
+
+  # Check the file without creating anything
+  jtk issues import --file requirements.csv --project PROJ --dry-run
+
+  # Use a mapping file and write results as JSON
+  jtk issues import --file backlog.yaml --mapping mapping.yaml --results created.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(opts, imp)
+		},
+	}
+
+	cmd.Flags().StringVar(&imp.File, "file", "", "File to import (required)")
+	cmd.Flags().StringVar(&imp.Format, "format", "", "File format: csv, tsv, jsonl or yaml (default: from extension)")
+	cmd.Flags().StringVar(&imp.Mapping, "mapping", "", "YAML or JSON file mapping columns to fields")
+	cmd.Flags().StringVarP(&imp.Project, "project", "p", "", "Project for rows without a project column")
+	cmd.Flags().StringVarP(&imp.IssueType, "type", "t", "Task", "Issue type for rows without a type column")
+	cmd.Flags().StringVar(&imp.Results, "results", "", "Results file (default: <file>.results.csv)")
+	cmd.Flags().BoolVar(&imp.DryRun, "dry-run", false, "Validate rows without creating issues")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runImport(opts *root.Options, imp importOptions) error {
+	v := opts.View()
+
+	format, err := detectImportFormat(imp.File, imp.Format)
+	if err != nil {
+		return err
+	}
+
+	rows, err := readImportFile(imp.File, format)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no rows found in %s", imp.File)
+	}
+
+	var mapping *importMapping
+	if imp.Mapping != "" {
+		if mapping, err = loadImportMapping(imp.Mapping); err != nil {
+			return err
+		}
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	fields, err := client.GetFields()
+	if err != nil {
+		return fmt.Errorf("failed to get field metadata: %w", err)
+	}
+
+	columns, err := resolveImportColumns(rows, mapping, fields)
+	if err != nil {
+		return err
+	}
+
+	items := planImport(rows, columns, imp, fields, client.ResolveUserByEmail)
+	validateImport(client, items, v.Warning)
+
+	if imp.DryRun {
+		for _, it := range items {
+			if it.Result.Status == "" {
+				it.Result.Status = importValid
+			}
+		}
+	} else {
+		createImport(client, items)
+	}
+
+	results := make([]importResult, len(items))
+	failed := 0
+	for i, it := range items {
+		results[i] = it.Result
+		if it.Result.Status == importFailed {
+			failed++
+		}
+	}
+
+	resultsPath := imp.Results
+	if resultsPath == "" && !imp.DryRun {
+		resultsPath = strings.TrimSuffix(imp.File, filepath.Ext(imp.File)) + ".results.csv"
+	}
+	if resultsPath != "" {
+		if err := writeImportResults(resultsPath, results); err != nil {
+			return err
+		}
+	}
+
+	if opts.DataOutput() {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"ROW", "REF", "KEY", "STATUS", "SUMMARY", "ERROR"}
+		tableRows := make([][]string, len(results))
+		for i, r := range results {
+			tableRows[i] = []string{strconv.Itoa(r.Row), r.Ref, r.Key, r.Status, r.Summary, r.Error}
+		}
+		if err := v.Table(headers, tableRows); err != nil {
+			return err
+		}
+
+		if imp.DryRun {
+			v.Info("%d valid, %d invalid (dry run, nothing created)", len(results)-failed, failed)
+		} else {
+			v.Info("%d created, %d failed", len(results)-failed, failed)
+		}
+		if resultsPath != "" {
+			v.Info("Results written to %s", resultsPath)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d row(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// planImport builds a create request for every row and links rows to the
+// rows they name as parent. Rows that cannot be built are marked failed.
+func planImport(rows []importRow, columns map[string]importColumn, imp importOptions, fields []api.Field, resolveUser api.UserResolver) []*importItem {
+	items := make([]*importItem, len(rows))
+	refs := make(map[string]*importItem)
+	parents := make(map[*importItem]string)
+
+	for i, row := range rows {
+		it := &importItem{Row: row}
+		it.Result.Row = row.Number
+		items[i] = it
+
+		project, issueType := imp.Project, imp.IssueType
+		var description, parent string
+		values := make(map[string]interface{})
+
+		for name, value := range row.Values {
+			text := strings.TrimSpace(fmt.Sprint(value))
+			switch target := columns[name].Target; target {
+			case "":
+			case "project":
+				project = text
+			case "issuetype":
+				issueType = text
+			case "summary":
+				it.Summary = text
+			case "description":
+				description = fmt.Sprint(value)
+			case "parent":
+				parent = text
+			case "ref":
+				it.Ref = text
+			default:
+				values[target] = value
+			}
+		}
+		it.Result.Summary = it.Summary
+		it.Result.Ref = it.Ref
+
+		if it.Ref != "" {
+			if other, dup := refs[it.Ref]; dup {
+				it.fail(fmt.Errorf("reference %q is also used by row %d", it.Ref, other.Row.Number))
+				continue
+			}
+			refs[it.Ref] = it
+		}
+		if parent != "" {
+			parents[it] = parent
+		}
+
+		if it.Summary == "" {
+			it.fail(fmt.Errorf("summary is required"))
+			continue
+		}
+		if project == "" {
+			it.fail(fmt.Errorf("project is required (add a project column or use --project)"))
+			continue
+		}
+
+		args, err := api.FieldArgsFromValues(values)
+		if err != nil {
+			it.fail(err)
+			continue
+		}
+		changes, err := api.BuildFieldChanges(fields, args, resolveUser)
+		if err != nil {
+			it.fail(err)
+			continue
+		}
+
+		it.Request = api.BuildCreateRequest(project, issueType, it.Summary, description, changes.Fields)
+		if len(changes.Update) > 0 {
+			it.Request.Update = changes.Update
+		}
+	}
+
+	// Parents are linked once all references are known, so a row may name a
+	// parent that appears later in the file
+	for it, parent := range parents {
+		if it.Result.Status == importFailed {
+			continue
+		}
+		switch {
+		case refs[parent] != nil && refs[parent] != it:
+			it.ParentRef = refs[parent]
+		case issueKeyPattern.MatchString(parent):
+			it.Request.Fields["parent"] = map[string]string{"key": parent}
+		default:
+			it.fail(fmt.Errorf("unknown parent %q: not a row reference or issue key", parent))
+		}
+	}
+
+	return items
+}
+
+// validateImport checks rows against the create metadata of their project
+// and issue type. Metadata is fetched once per combination.
+func validateImport(client *api.Client, items []*importItem, warn func(string, ...interface{})) {
+	type metaKey struct{ project, issueType string }
+	metas := make(map[metaKey][]api.CreateMetaField)
+	metaErrs := make(map[metaKey]error)
+
+	for _, it := range items {
+		if it.Request == nil || it.Result.Status == importFailed {
+			continue
+		}
+
+		key := metaKey{requestRef(it.Request, "project", "key"), requestRef(it.Request, "issuetype", "name")}
+		meta, fetched := metas[key]
+		err := metaErrs[key]
+		if !fetched && err == nil {
+			meta, err = client.GetCreateMeta(key.project, key.issueType)
+			if err != nil && !errors.Is(err, api.ErrInvalidIssueType) {
+				warn("Skipping field validation for %s %s: %v", key.project, key.issueType, err)
+			}
+			metas[key], metaErrs[key] = meta, err
+		}
+
+		switch {
+		case errors.Is(err, api.ErrInvalidIssueType):
+			it.fail(err)
+		case err != nil:
+			// Validation is unavailable; Jira checks the request on create
+		default:
+			req := it.Request
+			if it.ParentRef != nil {
+				// The parent key is only known after creation; count it as set
+				req = &api.CreateIssueRequest{Fields: copyFields(req.Fields), Update: req.Update}
+				req.Fields["parent"] = map[string]string{"key": "PENDING-1"}
+			}
+			if err := api.ValidateCreateRequest(meta, req); err != nil {
+				it.fail(err)
+			}
+		}
+	}
+
+	// Rows below an invalid parent cannot be created either
+	for changed := true; changed; {
+		changed = false
+		for _, it := range items {
+			if it.Result.Status != importFailed && it.ParentRef != nil && it.ParentRef.Result.Status == importFailed {
+				it.fail(fmt.Errorf("parent row %d failed", it.ParentRef.Row.Number))
+				changed = true
+			}
+		}
+	}
+}
+
+// createImport creates issues in batches of up to api.BulkCreateMaxIssues.
+// Rows whose parent is another row wait until that row has been created.
+func createImport(client *api.Client, items []*importItem) {
+	for {
+		var batch []*importItem
+		for _, it := range items {
+			if it.Result.Status != "" {
+				continue
+			}
+			if p := it.ParentRef; p != nil {
+				if p.Result.Status == importFailed {
+					it.fail(fmt.Errorf("parent row %d failed", p.Row.Number))
+					continue
+				}
+				if p.Result.Key == "" {
+					continue
+				}
+				it.Request.Fields["parent"] = map[string]string{"key": p.Result.Key}
+			}
+			batch = append(batch, it)
+			if len(batch) == api.BulkCreateMaxIssues {
+				break
+			}
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		reqs := make([]*api.CreateIssueRequest, len(batch))
+		for i, it := range batch {
+			reqs[i] = it.Request
+		}
+
+		results, err := client.BulkCreateIssues(reqs)
+		for i, it := range batch {
+			switch {
+			case err != nil:
+				it.fail(err)
+			case results[i].Err != nil:
+				it.fail(results[i].Err)
+			default:
+				it.Result.Status = importCreated
+				it.Result.Key = results[i].Issue.Key
+			}
+		}
+	}
+
+	// Anything still pending is part of a parent cycle
+	for _, it := range items {
+		if it.Result.Status == "" {
+			it.fail(fmt.Errorf("parent references form a cycle"))
+		}
+	}
+}
+
+func (it *importItem) fail(err error) {
+	it.Result.Status = importFailed
+	it.Result.Error = err.Error()
+}
+
+// requestRef reads a reference such as fields.project.key from a create request
+func requestRef(req *api.CreateIssueRequest, field, key string) string {
+	if ref, ok := req.Fields[field].(map[string]string); ok {
+		return ref[key]
+	}
+	return ""
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = v
+	}
+	return out
+}
+
+// writeImportResults writes results as CSV, or JSON when the path ends in .json
+func writeImportResults(path string, results []importResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create results file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(results)
+	} else {
+		w := csv.NewWriter(f)
+		_ = w.Write([]string{"row", "ref", "summary", "key", "status", "error"})
+		sort.SliceStable(results, func(i, j int) bool { return results[i].Row < results[j].Row })
+		for _, r := range results {
+			_ = w.Write([]string{strconv.Itoa(r.Row), r.Ref, r.Summary, r.Key, r.Status, r.Error})
+		}
+		w.Flush()
+		err = w.Error()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
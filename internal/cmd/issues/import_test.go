@@ -0,0 +1,140 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func newImportTestServer(t *testing.T, batches *[][]api.CreateIssueRequest) *httptest.Server {
+	next := 1
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes":
+			w.Write([]byte(`{"total":2,"issueTypes":[{"id":"1","name":"Epic"},{"id":"2","name":"Story"}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes/1",
+			r.URL.Path == "/rest/api/3/issue/createmeta/PROJ/issuetypes/2":
+			w.Write([]byte(`{"total":1,"fields":[{"fieldId":"summary","name":"Summary","required":true}]}`))
+		case r.URL.Path == "/rest/api/3/issue/bulk" && r.Method == http.MethodPost:
+			var body struct {
+				IssueUpdates []api.CreateIssueRequest `json:"issueUpdates"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			*batches = append(*batches, body.IssueUpdates)
+
+			var issues []string
+			for range body.IssueUpdates {
+				issues = append(issues, fmt.Sprintf(`{"id":"%d","key":"PROJ-%d"}`, next, next))
+				next++
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"issues":[%s],"errors":[]}`, joinJSON(issues))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func joinJSON(items []string) string {
+	var buf bytes.Buffer
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(item)
+	}
+	return buf.String()
+}
+
+func TestRunImport(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "backlog.csv")
+	require.NoError(t, os.WriteFile(file, []byte(
+		"Ref,Type,Summary,Parent,Story Points\n"+
+			"s1,Story,Login page,e1,3\n"+
+			"e1,Epic,Accounts,,\n"+
+			"s2,Story,,e1,\n"+
+			"s3,Story,Logout,PROJ-99,1\n"), 0o644))
+
+	var batches [][]api.CreateIssueRequest
+	server := newImportTestServer(t, &batches)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	err := runImport(cmdtest.Options(server, &stdout), importOptions{File: file, Project: "PROJ", IssueType: "Task"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 4 row(s) failed")
+
+	// The epic goes in the first batch; the story under it waits for its key
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "Accounts", batches[0][0].Fields["summary"])
+	assert.Equal(t, map[string]interface{}{"key": "PROJ-99"}, batches[0][1].Fields["parent"])
+	assert.Equal(t, float64(1), batches[0][1].Fields["customfield_10016"])
+	require.Len(t, batches[1], 1)
+	assert.Equal(t, "Login page", batches[1][0].Fields["summary"])
+	assert.Equal(t, map[string]interface{}{"key": "PROJ-1"}, batches[1][0].Fields["parent"])
+
+	data, err := os.ReadFile(filepath.Join(dir, "backlog.results.csv"))
+	require.NoError(t, err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"row", "ref", "summary", "key", "status", "error"}, records[0])
+	assert.Equal(t, []string{"1", "s1", "Login page", "PROJ-3", "created", ""}, records[1])
+	assert.Equal(t, []string{"2", "e1", "Accounts", "PROJ-1", "created", ""}, records[2])
+	assert.Equal(t, []string{"3", "s2", "", "", "failed", "summary is required"}, records[3])
+	assert.Equal(t, []string{"4", "s3", "Logout", "PROJ-2", "created", ""}, records[4])
+}
+
+func TestRunImport_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "backlog.jsonl")
+	require.NoError(t, os.WriteFile(file, []byte(
+		`{"Title":"Accounts","type":"Epic"}`+"\n"+
+			`{"Title":"Login","type":"Bug"}`+"\n"), 0o644))
+	mapping := filepath.Join(dir, "mapping.yaml")
+	require.NoError(t, os.WriteFile(mapping, []byte("columns:\n  Title: summary\n"), 0o644))
+
+	var batches [][]api.CreateIssueRequest
+	server := newImportTestServer(t, &batches)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+	err := runImport(opts, importOptions{File: file, Mapping: mapping, Project: "PROJ", IssueType: "Task", DryRun: true})
+	require.Error(t, err)
+	assert.Empty(t, batches)
+
+	var results []importResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, importValid, results[0].Status)
+	assert.Equal(t, importFailed, results[1].Status)
+	assert.Contains(t, results[1].Error, "Bug")
+
+	_, err = os.Stat(filepath.Join(dir, "backlog.results.csv"))
+	assert.True(t, os.IsNotExist(err), "dry run should not write a results file by default")
+}
+
+func TestResolveImportColumns_Unknown(t *testing.T) {
+	rows := []importRow{{Number: 1, Values: map[string]interface{}{"Summary": "x", "Mystery": "y"}}}
+	_, err := resolveImportColumns(rows, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown column(s): Mystery")
+}
@@ -0,0 +1,268 @@
+package issues
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+// Import file formats
+const (
+	importCSV   = "csv"
+	importTSV   = "tsv"
+	importJSONL = "jsonl"
+	importYAML  = "yaml"
+)
+
+// importRow is one issue read from an import file
+type importRow struct {
+	Number int // 1-based position among the rows of the file
+	Values map[string]interface{}
+}
+
+// importMapping maps file columns to issue fields
+type importMapping struct {
+	// Columns maps a column name to a field name or ID. An empty target ignores the column.
+	Columns map[string]string `yaml:"columns"`
+	// ID names the column holding a reference that other rows can use as parent
+	ID string `yaml:"id"`
+	// Parent names the column holding the parent: a row reference or an issue key
+	Parent string `yaml:"parent"`
+}
+
+// importColumn is what a column is used for
+type importColumn struct {
+	Target string // project, issuetype, summary, description, parent, ref, a field ID or "" to ignore
+}
+
+// Well-known column names, matched case-insensitively when no mapping applies
+var importColumnAliases = map[string]string{
+	"project":     "project",
+	"project key": "project",
+	"type":        "issuetype",
+	"issue type":  "issuetype",
+	"issuetype":   "issuetype",
+	"summary":     "summary",
+	"title":       "summary",
+	"description": "description",
+	"parent":      "parent",
+	"parent key":  "parent",
+	"id":          "ref",
+	"ref":         "ref",
+}
+
+// detectImportFormat returns the format given explicitly or implied by the file extension
+func detectImportFormat(path, format string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = importCSV
+		case ".tsv", ".tab":
+			format = importTSV
+		case ".jsonl", ".ndjson":
+			format = importJSONL
+		case ".yaml", ".yml":
+			format = importYAML
+		default:
+			return "", fmt.Errorf("cannot tell the format of %s; use --format csv, tsv, jsonl or yaml", path)
+		}
+	}
+
+	switch format {
+	case importCSV, importTSV, importJSONL, importYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use csv, tsv, jsonl or yaml)", format)
+	}
+}
+
+// readImportFile reads the rows of an import file
+func readImportFile(path, format string) ([]importRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case importCSV:
+		return readDelimitedRows(f, ',')
+	case importTSV:
+		return readDelimitedRows(f, '\t')
+	case importJSONL:
+		return readJSONLRows(f)
+	default:
+		return readYAMLRows(f)
+	}
+}
+
+// readDelimitedRows reads CSV or TSV with a header row. Empty cells are left unset.
+func readDelimitedRows(r io.Reader, comma rune) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("import file is empty")
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read row %d: %w", len(rows)+1, err)
+		}
+
+		values := make(map[string]interface{})
+		for i, cell := range record {
+			if i >= len(header) || header[i] == "" || strings.TrimSpace(cell) == "" {
+				continue
+			}
+			values[header[i]] = cell
+		}
+		if len(values) == 0 {
+			continue
+		}
+		rows = append(rows, importRow{Number: len(rows) + 1, Values: values})
+	}
+	return rows, nil
+}
+
+// readJSONLRows reads one JSON object per line, skipping blank lines
+func readJSONLRows(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var rows []importRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal(text, &values); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rows = append(rows, importRow{Number: len(rows) + 1, Values: values})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	return rows, nil
+}
+
+// readYAMLRows reads a YAML list of mappings
+func readYAMLRows(r io.Reader) ([]importRow, error) {
+	var list []map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&list); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse import file: %w", err)
+	}
+
+	rows := make([]importRow, 0, len(list))
+	for i, values := range list {
+		rows = append(rows, importRow{Number: i + 1, Values: values})
+	}
+	return rows, nil
+}
+
+// loadImportMapping reads a YAML or JSON mapping file
+func loadImportMapping(path string) (*importMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m importMapping
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+	return &m, nil
+}
+
+// resolveImportColumns decides what every column is used for. Columns named in
+// the mapping use its target; the others are matched against well-known names
+// and then resolved as field names or IDs.
+func resolveImportColumns(rows []importRow, mapping *importMapping, fields []api.Field) (map[string]importColumn, error) {
+	if mapping == nil {
+		mapping = &importMapping{}
+	}
+
+	columns := make(map[string]importColumn)
+	var unknown []string
+
+	for _, row := range rows {
+		for name := range row.Values {
+			if _, done := columns[name]; done {
+				continue
+			}
+
+			target, err := resolveImportColumn(name, mapping, fields)
+			if err != nil {
+				unknown = append(unknown, name)
+				columns[name] = importColumn{}
+				continue
+			}
+			columns[name] = importColumn{Target: target}
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown column(s): %s; map them to fields with --mapping",
+			strings.Join(unknown, ", "))
+	}
+	return columns, nil
+}
+
+func resolveImportColumn(name string, mapping *importMapping, fields []api.Field) (string, error) {
+	switch {
+	case mapping.ID != "" && strings.EqualFold(name, mapping.ID):
+		return "ref", nil
+	case mapping.Parent != "" && strings.EqualFold(name, mapping.Parent):
+		return "parent", nil
+	}
+
+	target := name
+	for col, t := range mapping.Columns {
+		if strings.EqualFold(col, name) {
+			if t == "" || t == "-" {
+				return "", nil
+			}
+			target = t
+			break
+		}
+	}
+
+	if alias, ok := importColumnAliases[strings.ToLower(target)]; ok {
+		return alias, nil
+	}
+	return api.ResolveFieldID(fields, target)
+}
@@ -20,6 +20,11 @@ func Register(parent *cobra.Command, opts *root.Options) {
 	cmd.AddCommand(newSearchCmd(opts))
 	cmd.AddCommand(newCreateCmd(opts))
 	cmd.AddCommand(newUpdateCmd(opts))
+	cmd.AddCommand(newEditCmd(opts))
+	cmd.AddCommand(newHistoryCmd(opts))
+	cmd.AddCommand(newWatchCmd(opts))
+	cmd.AddCommand(newImportCmd(opts))
+	cmd.AddCommand(newBulkCmd(opts))
 	cmd.AddCommand(newDeleteCmd(opts))
 	cmd.AddCommand(newAssignCmd(opts))
 	cmd.AddCommand(newFieldsCmd(opts))
@@ -12,6 +12,7 @@ func newListCmd(opts *root.Options) *cobra.Command {
 	var project string
 	var sprint string
 	var maxResults int
+	var columns []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -24,27 +25,30 @@ func newListCmd(opts *root.Options) *cobra.Command {
   jtk issues list --project MYPROJECT --sprint current
 
   # List issues with custom limit
-  jtk issues list --project MYPROJECT --max 100`,
+  jtk issues list --project MYPROJECT --max 100
+
+  # Show when issues were last updated and when they are due
+  jtk issues list --project MYPROJECT --columns key,summary,updated,due
+
+  # Show absolute timestamps in UTC instead of relative times
+  jtk issues list --project MYPROJECT --columns key,created --date-format iso --tz UTC
+
+  # Show chosen columns as a Markdown table
+  jtk issues list --project MYPROJECT -o markdown --columns key,summary,assignee,priority`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(opts, project, sprint, maxResults)
+			return runList(opts, project, sprint, maxResults, columns)
 		},
 	}
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Filter by project key")
 	cmd.Flags().StringVarP(&sprint, "sprint", "s", "", "Filter by sprint (use 'current' for active sprint)")
 	cmd.Flags().IntVarP(&maxResults, "max", "m", 50, "Maximum number of results")
+	addColumnFlags(cmd, &columns)
 
 	return cmd
 }
 
-func runList(opts *root.Options, project, sprint string, maxResults int) error {
-	v := opts.View()
-
-	client, err := opts.APIClient()
-	if err != nil {
-		return err
-	}
-
+func runList(opts *root.Options, project, sprint string, maxResults int, columns []string) error {
 	// Build JQL query
 	var jql string
 	if project != "" {
@@ -72,42 +76,5 @@ func runList(opts *root.Options, project, sprint string, maxResults int) error {
 		jql += " ORDER BY updated DESC"
 	}
 
-	issues, err := client.SearchAll(jql, maxResults)
-	if err != nil {
-		return err
-	}
-
-	if len(issues) == 0 {
-		v.Info("No issues found")
-		return nil
-	}
-
-	// For JSON output
-	if opts.Output == "json" {
-		return v.JSON(issues)
-	}
-
-	headers := []string{"KEY", "SUMMARY", "STATUS", "ASSIGNEE", "TYPE"}
-	var rows [][]string
-
-	for _, issue := range issues {
-		status := ""
-		if issue.Fields.Status != nil {
-			status = issue.Fields.Status.Name
-		}
-
-		assignee := ""
-		if issue.Fields.Assignee != nil {
-			assignee = issue.Fields.Assignee.DisplayName
-		}
-
-		issueType := ""
-		if issue.Fields.IssueType != nil {
-			issueType = issue.Fields.IssueType.Name
-		}
-
-		rows = append(rows, formatIssueRow(issue.Key, issue.Fields.Summary, status, assignee, issueType))
-	}
-
-	return v.Table(headers, rows)
+	return listIssues(opts, jql, maxResults, columns)
 }
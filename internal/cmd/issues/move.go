@@ -220,7 +220,7 @@ func runMoveStatus(opts *root.Options, taskID string) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(status)
 	}
 
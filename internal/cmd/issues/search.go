@@ -9,6 +9,7 @@ import (
 func newSearchCmd(opts *root.Options) *cobra.Command {
 	var jql string
 	var maxResults int
+	var columns []string
 
 	cmd := &cobra.Command{
 		Use:   "search",
@@ -21,62 +22,26 @@ func newSearchCmd(opts *root.Options) *cobra.Command {
   jtk issues search --jql "project = MYPROJECT AND updated >= -7d"
 
   # Search issues assigned to current user
-  jtk issues search --jql "assignee = currentUser() AND resolution = Unresolved"`,
+  jtk issues search --jql "assignee = currentUser() AND resolution = Unresolved"
+
+  # Show priority and story points alongside the key and status
+  jtk issues search --jql "project = MYPROJECT" --columns key,priority,status,updated,customfield_10016
+
+  # Export chosen columns, including custom fields by name, to a spreadsheet
+  jtk issues search --jql "project = MYPROJECT" -o csv --fields key,summary,status,"Story Points" > issues.csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSearch(opts, jql, maxResults)
+			return runSearch(opts, jql, maxResults, columns)
 		},
 	}
 
 	cmd.Flags().StringVar(&jql, "jql", "", "JQL query string (required)")
 	cmd.Flags().IntVarP(&maxResults, "max", "m", 50, "Maximum number of results")
+	addColumnFlags(cmd, &columns)
 	_ = cmd.MarkFlagRequired("jql")
 
 	return cmd
 }
 
-func runSearch(opts *root.Options, jql string, maxResults int) error {
-	v := opts.View()
-
-	client, err := opts.APIClient()
-	if err != nil {
-		return err
-	}
-
-	issues, err := client.SearchAll(jql, maxResults)
-	if err != nil {
-		return err
-	}
-
-	if len(issues) == 0 {
-		v.Info("No issues found")
-		return nil
-	}
-
-	if opts.Output == "json" {
-		return v.JSON(issues)
-	}
-
-	headers := []string{"KEY", "SUMMARY", "STATUS", "ASSIGNEE", "TYPE"}
-	var rows [][]string
-
-	for _, issue := range issues {
-		status := ""
-		if issue.Fields.Status != nil {
-			status = issue.Fields.Status.Name
-		}
-
-		assignee := ""
-		if issue.Fields.Assignee != nil {
-			assignee = issue.Fields.Assignee.DisplayName
-		}
-
-		issueType := ""
-		if issue.Fields.IssueType != nil {
-			issueType = issue.Fields.IssueType.Name
-		}
-
-		rows = append(rows, formatIssueRow(issue.Key, issue.Fields.Summary, status, assignee, issueType))
-	}
-
-	return v.Table(headers, rows)
+func runSearch(opts *root.Options, jql string, maxResults int, columns []string) error {
+	return listIssues(opts, jql, maxResults, columns)
 }
@@ -0,0 +1,211 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+func TestRunSearch_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/field":
+			w.Write([]byte(`[
+				{"id":"summary","name":"Summary","schema":{"type":"string"}},
+				{"id":"status","name":"Status","schema":{"type":"status"}},
+				{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}
+			]`))
+		case "/rest/api/3/search/jql":
+			var req struct {
+				Fields []string `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []string{"summary", "status", "customfield_10016"}, req.Fields)
+
+			w.Write([]byte(`{"total":2,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"Export, with comma","status":{"name":"Open"},"customfield_10016":3}},
+				{"key":"PROJ-2","fields":{"summary":"No estimate","status":{"name":"Done"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "csv"
+
+	err := runSearch(opts, "project = PROJ", 50, []string{"key", "summary", "Status", "Story Points"})
+	require.NoError(t, err)
+	assert.Equal(t, "Key,Summary,Status,Story Points\n"+
+		"PROJ-1,\"Export, with comma\",Open,3\n"+
+		"PROJ-2,No estimate,Done,\n", stdout.String())
+}
+
+func TestRunSearch_UnknownField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	err := runSearch(cmdtest.Options(server, &bytes.Buffer{}), "project = PROJ", 50, []string{"Velocity"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field not found: Velocity")
+}
+
+func TestRunSearch_Template(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"total":1,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"Templated","status":{"name":"Open"},"customfield_10016":5}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "template"
+	opts.Template = `{{.Key}} {{.Fields.Summary}} {{field "Story Points" .}} {{field "status" .}}`
+
+	require.NoError(t, runSearch(opts, "project = PROJ", 50, nil))
+	assert.Equal(t, "PROJ-1 Templated 5 Open\n", stdout.String())
+
+	stdout.Reset()
+	opts.Output = "jsonpath=$[*].fields.status.name"
+	require.NoError(t, runSearch(opts, "project = PROJ", 50, nil))
+	assert.Equal(t, "Open\n", stdout.String())
+}
+
+func TestRunSearch_Columns(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case "/rest/api/3/search/jql":
+			var req struct {
+				Fields []string `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			requested = req.Fields
+
+			w.Write([]byte(`{"total":2,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"Café crème","status":{"name":"Open","statusCategory":{"key":"new"}},"priority":{"name":"High"},"customfield_10016":3}},
+				{"key":"PROJ-22","fields":{"summary":"Unestimated","status":{"name":"Done","statusCategory":{"key":"done"}}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("COLUMNS", "")
+
+	t.Run("default columns need no field metadata", func(t *testing.T) {
+		t.Setenv("JIRA_COLUMNS", "")
+
+		var stdout bytes.Buffer
+		require.NoError(t, runSearch(cmdtest.Options(server, &stdout), "project = PROJ", 50, nil))
+		assert.Equal(t, "KEY      SUMMARY      STATUS  ASSIGNEE    TYPE\n"+
+			"PROJ-1   Café crème   Open    Unassigned  -\n"+
+			"PROJ-22  Unestimated  Done    Unassigned  -\n", stdout.String())
+		assert.Subset(t, requested, []string{"summary", "status", "assignee", "issuetype"})
+	})
+
+	t.Run("configured columns", func(t *testing.T) {
+		t.Setenv("JIRA_COLUMNS", "key,priority,customfield_10016")
+
+		var stdout bytes.Buffer
+		require.NoError(t, runSearch(cmdtest.Options(server, &stdout), "project = PROJ", 50, nil))
+		assert.Equal(t, "KEY      PRIORITY  STORY POINTS\n"+
+			"PROJ-1   High      3\n"+
+			"PROJ-22  -         -\n", stdout.String())
+		assert.Contains(t, requested, "customfield_10016")
+	})
+
+	t.Run("flag overrides config", func(t *testing.T) {
+		t.Setenv("JIRA_COLUMNS", "key,priority")
+
+		var stdout bytes.Buffer
+		require.NoError(t, runSearch(cmdtest.Options(server, &stdout), "project = PROJ", 50, []string{"type", "key"}))
+		assert.Equal(t, "TYPE  KEY\n"+
+			"-     PROJ-1\n"+
+			"-     PROJ-22\n", stdout.String())
+		assert.Equal(t, []string{"issuetype"}, requested)
+	})
+}
+
+func TestAddColumnFlags_FieldsAlias(t *testing.T) {
+	var columns []string
+	cmd := &cobra.Command{Use: "search"}
+	addColumnFlags(cmd, &columns)
+
+	require.NoError(t, cmd.ParseFlags([]string{"--fields", "key,summary", "--columns", "status"}))
+	assert.Equal(t, []string{"key", "summary", "status"}, columns)
+	assert.Equal(t, "columns", cmd.Flags().Lookup("fields").Name)
+}
+
+func TestIssueColumnTable_StatusColor(t *testing.T) {
+	prev := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = prev }()
+
+	issues := []api.Issue{
+		{Key: "PROJ-1", Fields: api.IssueFields{Status: &api.Status{Name: "In Progress", StatusCategory: api.StatusCategory{Key: "indeterminate"}}}},
+		{Key: "PROJ-2", Fields: api.IssueFields{Status: &api.Status{Name: "Done", StatusCategory: api.StatusCategory{Key: "done"}}}},
+	}
+	columns := []issueColumn{{Header: "Status", ID: "status"}}
+
+	_, rows := issueColumnTable(&view.View{Format: view.FormatTable}, issues, columns)
+	assert.Equal(t, color.New(color.FgBlue).Sprint("In Progress"), rows[0][0])
+	assert.Equal(t, color.New(color.FgGreen).Sprint("Done"), rows[1][0])
+
+	_, rows = issueColumnTable(&view.View{Format: view.FormatCSV}, issues, columns)
+	assert.Equal(t, "In Progress", rows[0][0])
+}
+
+func TestRunSearch_DateColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total":2,"issues":[
+			{"key":"PROJ-1","fields":{"created":"2024-03-05T10:15:30.000+0000","updated":"2024-03-06T23:30:00.000+0000","duedate":"2024-03-31"}},
+			{"key":"PROJ-2","fields":{"created":"2024-03-07T08:00:00.000+0000"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "csv"
+	opts.TZ = "Europe/Berlin"
+
+	require.NoError(t, runSearch(opts, "project = PROJ", 50, []string{"key", "created", "updated", "due"}))
+	assert.Equal(t, "Key,Created,Updated,Due\n"+
+		"PROJ-1,2024-03-05T11:15:30+01:00,2024-03-07T00:30:00+01:00,2024-03-31\n"+
+		"PROJ-2,2024-03-07T09:00:00+01:00,,\n", stdout.String())
+
+	stdout.Reset()
+	opts.DateFormat = "2006-01-02"
+	require.NoError(t, runSearch(opts, "project = PROJ", 50, []string{"key", "updated"}))
+	assert.Equal(t, "Key,Updated\nPROJ-1,2024-03-07\nPROJ-2,\n", stdout.String())
+}
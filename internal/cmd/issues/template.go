@@ -0,0 +1,121 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/issuetemplate"
+)
+
+// defaultSubtaskType is used when a project's subtask issue type cannot be determined
+const defaultSubtaskType = "Sub-task"
+
+// applyTemplate fills in create options from a template. Values given on the
+// command line win over the template; template fields are applied before
+// --field arguments so those can override them.
+func applyTemplate(create *createOptions, typeSet bool) error {
+	t, err := issuetemplate.Load(create.Template)
+	if err != nil {
+		return err
+	}
+
+	vars, err := issuetemplate.ParseVars(create.Vars)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := t.Render(vars)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", t.Name, err)
+	}
+
+	if create.Project == "" {
+		create.Project = rendered.Project
+	}
+	if !typeSet && rendered.Type != "" {
+		create.IssueType = rendered.Type
+	}
+	if create.Summary == "" {
+		create.Summary = rendered.Summary
+	}
+	if create.Description == "" {
+		create.Description = rendered.Description
+	}
+
+	args, err := issuetemplate.FieldArgs(rendered.Labels, rendered.Fields)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	create.Fields = append(args, create.Fields...)
+	create.subtasks = rendered.Subtasks
+
+	return nil
+}
+
+// createSubtasks creates the template's subtasks under parentKey. Every
+// subtask is attempted; the error reports how many failed.
+func createSubtasks(client *api.Client, create createOptions, parentKey string) ([]*api.Issue, error) {
+	if len(create.subtasks) == 0 {
+		return nil, nil
+	}
+
+	var defaultType string
+	var created []*api.Issue
+	var failures []string
+
+	for _, st := range create.subtasks {
+		issueType := st.Type
+		if issueType == "" {
+			if defaultType == "" {
+				defaultType = subtaskType(client, create.Project)
+			}
+			issueType = defaultType
+		}
+
+		issue, err := createSubtask(client, create.Project, issueType, parentKey, st)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%q: %v", st.Summary, err))
+			continue
+		}
+		created = append(created, issue)
+	}
+
+	if len(failures) > 0 {
+		return created, fmt.Errorf("%d of %d subtask(s) failed: %s",
+			len(failures), len(create.subtasks), strings.Join(failures, "; "))
+	}
+	return created, nil
+}
+
+func createSubtask(client *api.Client, project, issueType, parentKey string, st issuetemplate.Subtask) (*api.Issue, error) {
+	args, err := issuetemplate.FieldArgs(st.Labels, st.Fields)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := client.ResolveFieldArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	changes.Fields["parent"] = map[string]string{"key": parentKey}
+
+	req := api.BuildCreateRequest(project, issueType, st.Summary, st.Description, changes.Fields)
+	if len(changes.Update) > 0 {
+		req.Update = changes.Update
+	}
+	return client.CreateIssue(req)
+}
+
+// subtaskType returns the name of the project's subtask issue type
+func subtaskType(client *api.Client, project string) string {
+	types, err := client.GetCreateMetaIssueTypes(project)
+	if err != nil {
+		return defaultSubtaskType
+	}
+	for _, t := range types {
+		if t.Subtask {
+			return t.Name
+		}
+	}
+	return defaultSubtaskType
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
 )
 
 func newTypesCmd(opts *root.Options) *cobra.Command {
@@ -42,7 +43,7 @@ func runTypes(opts *root.Options, project string) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(projectDetail.IssueTypes)
 	}
 
@@ -59,7 +60,7 @@ func runTypes(opts *root.Options, project string) error {
 		if t.Subtask {
 			subtask = "yes"
 		}
-		rows = append(rows, []string{t.ID, t.Name, subtask, truncate(t.Description, 60)})
+		rows = append(rows, []string{t.ID, t.Name, subtask, view.Truncate(t.Description, 60)})
 	}
 
 	return v.Table(headers, rows)
@@ -2,7 +2,6 @@ package issues
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -26,7 +25,14 @@ func newUpdateCmd(opts *root.Options) *cobra.Command {
   jtk issues update PROJ-123 --description "Updated description"
 
   # Update custom fields
-  jtk issues update PROJ-123 --field priority=High --field "Story Points"=5`,
+  jtk issues update PROJ-123 --field priority=High --field "Story Points"=5
+
+  # Add and remove values of multi-value fields
+  jtk issues update PROJ-123 --field labels+=triaged --field labels-=needs-info --field components+=API
+
+  # Set a cascading select, a user by email, or a raw JSON value
+  jtk issues update PROJ-123 --field "Region=EMEA -> Germany" --field assignee=jane@example.com
+  jtk issues update PROJ-123 --field 'customfield_10020:={"id":"10100"}'`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runUpdate(opts, args[0], summary, description, fields)
@@ -35,7 +41,7 @@ func newUpdateCmd(opts *root.Options) *cobra.Command {
 
 	cmd.Flags().StringVarP(&summary, "summary", "s", "", "New summary")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "New description")
-	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "Fields to update (key=value)")
+	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "Fields to update (key=value, key+=value, key-=value, key:=json)")
 
 	return cmd
 }
@@ -48,54 +54,28 @@ func runUpdate(opts *root.Options, issueKey, summary, description string, fieldA
 		return err
 	}
 
-	fields := make(map[string]interface{})
+	// Parse additional fields
+	changes, err := client.ResolveFieldArgs(fieldArgs)
+	if err != nil {
+		return err
+	}
 
 	if summary != "" {
-		fields["summary"] = summary
+		changes.Fields["summary"] = summary
 	}
 
 	if description != "" {
-		fields["description"] = api.NewADFDocument(description)
+		changes.Fields["description"] = api.NewADFDocument(description)
 	}
 
-	// Parse additional fields
-	if len(fieldArgs) > 0 {
-		allFields, err := client.GetFields()
-		if err != nil {
-			return fmt.Errorf("failed to get field metadata: %w", err)
-		}
-
-		for _, f := range fieldArgs {
-			parts := strings.SplitN(f, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid field format: %s (expected key=value)", f)
-			}
-
-			key, value := parts[0], parts[1]
-
-			// Try to resolve field name to ID and get field info
-			var fieldID string
-			var field *api.Field
-			if resolved := api.FindFieldByName(allFields, key); resolved != nil {
-				fieldID = resolved.ID
-				field = resolved
-			} else if resolved := api.FindFieldByID(allFields, key); resolved != nil {
-				fieldID = resolved.ID
-				field = resolved
-			} else {
-				fieldID = key
-			}
-
-			// Format value based on field type
-			fields[fieldID] = api.FormatFieldValue(field, value)
-		}
-	}
-
-	if len(fields) == 0 {
+	if changes.IsEmpty() {
 		return fmt.Errorf("no fields specified to update")
 	}
 
-	req := api.BuildUpdateRequest(fields)
+	req := api.BuildUpdateRequest(changes.Fields)
+	if len(changes.Update) > 0 {
+		req.Update = changes.Update
+	}
 
 	if err := client.UpdateIssue(issueKey, req); err != nil {
 		return err
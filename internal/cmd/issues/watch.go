@@ -0,0 +1,382 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/hook"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/notify"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/throttle"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// Watch event types
+const (
+	eventNew      = "new"
+	eventStatus   = "status"
+	eventAssignee = "assignee"
+	eventComment  = "comment"
+)
+
+// minWatchInterval keeps polling from hammering the API
+const minWatchInterval = 5 * time.Second
+
+// watchBurst is the number of requests a poll may make before throttling
+const watchBurst = 5
+
+// watchSnapshotMax is the number of issues the first poll reads, whatever
+// --max is, so later polls can tell new issues from ones not seen before
+const watchSnapshotMax = 10000
+
+// watchFields are the fields read on each poll
+var watchFields = []string{"summary", "status", "assignee", "comment", "created"}
+
+// watchOptions holds the flags of issues watch
+type watchOptions struct {
+	JQL      string
+	Interval time.Duration
+	Exec     string
+	Notify   bool
+	Rate     float64
+	Max      int
+}
+
+// watchEvent is a change seen while watching
+type watchEvent struct {
+	Type    string    `json:"type"`
+	Key     string    `json:"key"`
+	Summary string    `json:"summary"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to,omitempty"`
+	Author  string    `json:"author,omitempty"`
+	Comment string    `json:"comment,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// watchHandler reacts to an event: printing it, running a hook, notifying
+type watchHandler func(watchEvent) error
+
+// issueState is what a watch remembers of an issue between polls
+type issueState struct {
+	Status   string
+	Assignee string
+	Comments int
+}
+
+// watcher polls a query and turns the differences between polls into events
+type watcher struct {
+	client   *api.Client
+	jql      string
+	max      int
+	handlers []watchHandler
+	stderr   io.Writer
+
+	snapshot map[string]issueState
+	partial  bool // the first poll did not read every matching issue
+	started  time.Time
+	lastPoll time.Time
+	now      func() time.Time
+}
+
+func newWatchCmd(opts *root.Options) *cobra.Command {
+	var watch watchOptions
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Follow changes to the issues matching a query",
+		Long: `Poll a JQL query and print new issues, status changes, assignee changes
+and new comments as they happen, like tail -f. Press Ctrl+C to stop.
+
+--exec runs a command for every event. The command is split into arguments
+like a shell would, but is not run by one; each argument is a Go template
+over the event: {{.Type}} (new, status, assignee or comment), {{.Key}},
+{{.Summary}}, {{.From}}, {{.To}}, {{.Author}} and {{.Comment}}.
+
+--notify shows a desktop notification for every event, using notify-send on
+Linux or osascript on macOS.
+
+The first poll reads every matching issue (up to 10000) to learn their state;
+later polls read at most --max issues changed since the previous poll. API
+requests are throttled to --rate per second, with short bursts allowed.`,
+		Example: `  # Follow a project
+  jtk issues watch --jql "project = PROJ"
+
+  # Poll every 30 seconds and show desktop notifications
+  jtk issues watch --jql "assignee = currentUser()" --interval 30s --notify
+
+  # Run a hook for every change
+  jtk issues watch --jql "project = PROJ" --exec 'notify-team {{.Key}} "{{.Type}}: {{.To}}"'
+
+  # Stream events as JSON lines
+  jtk issues watch --jql "project = PROJ" -o ndjson`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(opts, watch)
+		},
+	}
+
+	cmd.Flags().StringVarP(&watch.JQL, "jql", "q", "", "JQL query to watch (required)")
+	cmd.Flags().DurationVarP(&watch.Interval, "interval", "i", time.Minute, "Time between polls")
+	cmd.Flags().StringVar(&watch.Exec, "exec", "", "Command to run for every event (arguments are templates)")
+	cmd.Flags().BoolVar(&watch.Notify, "notify", false, "Show a desktop notification for every event")
+	cmd.Flags().Float64Var(&watch.Rate, "rate", 2, "Maximum API requests per second")
+	cmd.Flags().IntVarP(&watch.Max, "max", "m", 100, "Maximum number of changed issues read per poll")
+
+	_ = cmd.MarkFlagRequired("jql")
+
+	return cmd
+}
+
+func runWatch(opts *root.Options, watch watchOptions) error {
+	v := opts.View()
+
+	if watch.Interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+	if watch.Rate <= 0 {
+		return fmt.Errorf("--rate must be positive")
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	handlers := []watchHandler{printEvent(opts, v)}
+	if watch.Exec != "" {
+		hook, err := execHook(watch.Exec, opts.Stdout, opts.Stderr)
+		if err != nil {
+			return err
+		}
+		handlers = append(handlers, hook)
+	}
+	if watch.Notify {
+		desktop, err := notify.Desktop()
+		if err != nil {
+			return err
+		}
+		handlers = append(handlers, notifyEvent(desktop))
+	}
+
+	w := newWatcher(throttledClient(client, watch.Rate), watch.JQL, watch.Max, handlers, opts.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := w.poll(); err != nil {
+		return err
+	}
+	if !opts.DataOutput() {
+		v.Info("Watching %d issue(s) matching %s; press Ctrl+C to stop", len(w.snapshot), watch.JQL)
+	}
+
+	ticker := time.NewTicker(watch.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.poll(); err != nil {
+				v.Warning("Poll failed: %v", err)
+			}
+		}
+	}
+}
+
+// throttledClient returns a copy of client whose requests are limited to
+// rate per second
+func throttledClient(client *api.Client, rate float64) *api.Client {
+	throttled := *client
+	throttled.HTTPClient = throttle.Client(client.HTTPClient, throttle.NewBucket(rate, watchBurst))
+	return &throttled
+}
+
+func newWatcher(client *api.Client, jql string, max int, handlers []watchHandler, stderr io.Writer) *watcher {
+	return &watcher{
+		client:   client,
+		jql:      jql,
+		max:      max,
+		handlers: handlers,
+		stderr:   stderr,
+		now:      time.Now,
+	}
+}
+
+// poll reads the issues updated since the last poll and reports how they
+// changed. The first poll reads all matching issues, up to watchSnapshotMax,
+// and reports nothing.
+func (w *watcher) poll() error {
+	started := w.now()
+
+	if w.snapshot == nil {
+		issues, err := w.client.SearchAllFields(w.jql, watchSnapshotMax, watchFields)
+		if err != nil {
+			return err
+		}
+		w.snapshot = make(map[string]issueState, len(issues))
+		for _, issue := range issues {
+			w.snapshot[issue.Key] = stateOf(issue)
+		}
+		if len(issues) >= watchSnapshotMax {
+			w.partial = true
+			fmt.Fprintf(w.stderr, "Warning: only the first %d matching issues are known; changes to older issues are reported but not as new\n", watchSnapshotMax)
+		}
+		w.started, w.lastPoll = started, started
+		return nil
+	}
+
+	jql := api.UpdatedWithin(w.jql, started.Sub(w.lastPoll))
+	issues, err := w.client.SearchAllFields(jql, w.max, watchFields)
+	if err != nil {
+		return err
+	}
+	if len(issues) >= w.max {
+		fmt.Fprintf(w.stderr, "Warning: %d or more issues changed since the last poll; changes beyond --max %d were missed\n", len(issues), w.max)
+	}
+
+	for _, issue := range issues {
+		events, err := w.diff(issue)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			w.emit(event)
+		}
+	}
+	w.lastPoll = started
+	return nil
+}
+
+// diff compares an issue with its last known state and records the new one
+func (w *watcher) diff(issue api.Issue) ([]watchEvent, error) {
+	state := stateOf(issue)
+	event := func(typ, from, to string) watchEvent {
+		return watchEvent{Type: typ, Key: issue.Key, Summary: issue.Fields.Summary, From: from, To: to, Time: w.now()}
+	}
+
+	old, known := w.snapshot[issue.Key]
+	if !known {
+		w.snapshot[issue.Key] = state
+		// Beyond a partial snapshot, an issue created before the watch is
+		// not new; its changes are reported from the next poll on
+		if w.partial && issue.Fields.Created != nil && issue.Fields.Created.Before(w.started) {
+			return nil, nil
+		}
+		return []watchEvent{event(eventNew, "", state.Status)}, nil
+	}
+
+	var events []watchEvent
+	if state.Status != old.Status {
+		events = append(events, event(eventStatus, old.Status, state.Status))
+	}
+	if state.Assignee != old.Assignee {
+		events = append(events, event(eventAssignee, old.Assignee, state.Assignee))
+	}
+	if state.Comments > old.Comments {
+		comments, err := w.client.GetComments(issue.Key, old.Comments, state.Comments-old.Comments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comments of %s: %w", issue.Key, err)
+		}
+		for _, c := range comments.Comments {
+			e := event(eventComment, "", "")
+			e.Author, e.Time = c.Author.DisplayName, c.Created.Time
+			if c.Body != nil {
+				e.Comment = c.Body.ToPlainText()
+			}
+			events = append(events, e)
+		}
+	}
+
+	w.snapshot[issue.Key] = state
+	return events, nil
+}
+
+// emit passes an event to every handler. A failing handler is reported and
+// does not stop the watch.
+func (w *watcher) emit(event watchEvent) {
+	for _, handle := range w.handlers {
+		if err := handle(event); err != nil {
+			fmt.Fprintf(w.stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+func stateOf(issue api.Issue) issueState {
+	state := issueState{Assignee: "Unassigned", Comments: commentTotal(issue)}
+	if s := issue.Fields.Status; s != nil {
+		state.Status = s.Name
+	}
+	if a := issue.Fields.Assignee; a != nil {
+		state.Assignee = a.DisplayName
+	}
+	return state
+}
+
+// commentTotal reads the number of comments from the comment field
+func commentTotal(issue api.Issue) int {
+	comment, ok := issue.Fields.CustomFields["comment"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	total, _ := comment["total"].(float64)
+	return int(total)
+}
+
+// printEvent writes events to stdout: one line each, or one JSON value each
+// in the data formats
+func printEvent(opts *root.Options, v *view.View) watchHandler {
+	return func(e watchEvent) error {
+		if opts.DataOutput() {
+			return v.JSON(e)
+		}
+		v.Println("%s  %s  %s", e.Time.In(v.Location).Format("15:04:05"), e.Key, describeEvent(e))
+		return nil
+	}
+}
+
+// describeEvent says what happened in an event, in a few words
+func describeEvent(e watchEvent) string {
+	switch e.Type {
+	case eventNew:
+		return fmt.Sprintf("new (%s): %s", e.To, e.Summary)
+	case eventStatus:
+		return fmt.Sprintf("status %s -> %s", e.From, e.To)
+	case eventAssignee:
+		return fmt.Sprintf("assignee %s -> %s", e.From, e.To)
+	case eventComment:
+		return fmt.Sprintf("comment by %s: %s", e.Author, view.Truncate(strings.Join(strings.Fields(e.Comment), " "), 80))
+	}
+	return e.Type
+}
+
+// notifyEvent shows events as notifications
+func notifyEvent(n notify.Notifier) watchHandler {
+	return func(e watchEvent) error {
+		return n.Notify(e.Key+" "+e.Summary, describeEvent(e))
+	}
+}
+
+// execHook runs --exec for each event
+func execHook(command string, stdout, stderr io.Writer) (watchHandler, error) {
+	hookCmd, err := hook.Parse(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --exec: %w", err)
+	}
+
+	return func(e watchEvent) error {
+		if err := hookCmd.Run(e, stdout, stderr); err != nil {
+			return fmt.Errorf("--exec failed for %s: %w", e.Key, err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,178 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func watchIssueJSON(key, status, assignee string, comments int) string {
+	a := `null`
+	if assignee != "" {
+		a = `{"displayName":"` + assignee + `"}`
+	}
+	return `{"key":"` + key + `","fields":{"summary":"` + key + ` summary","status":{"name":"` + status + `"},` +
+		`"assignee":` + a + `,"comment":{"total":` + string(rune('0'+comments)) + `,"comments":[]}}}`
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	var jqls []string
+	poll := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, watchFields, req.Fields)
+			jqls = append(jqls, req.JQL)
+
+			var issues []string
+			if poll == 0 {
+				issues = []string{watchIssueJSON("PROJ-1", "To Do", "", 1), watchIssueJSON("PROJ-2", "To Do", "Bob", 0)}
+			} else {
+				issues = []string{
+					watchIssueJSON("PROJ-1", "In Progress", "Alice", 2),
+					watchIssueJSON("PROJ-2", "To Do", "Bob", 0),
+					watchIssueJSON("PROJ-3", "To Do", "", 0),
+				}
+			}
+			poll++
+			w.Write([]byte(`{"total":` + string(rune('0'+len(issues))) + `,"issues":[` + strings.Join(issues, ",") + `]}`))
+		case "/rest/api/3/issue/PROJ-1/comment":
+			assert.Equal(t, "1", r.URL.Query().Get("startAt"))
+			assert.Equal(t, "1", r.URL.Query().Get("maxResults"))
+			w.Write([]byte(`{"total":2,"comments":[{"id":"2","author":{"displayName":"Alice"},"created":"2024-03-01T10:03:00.000+0000",
+				"body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"On it"}]}]}}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	client, err := opts.APIClient()
+	require.NoError(t, err)
+
+	var events []watchEvent
+	w := newWatcher(client, "project = PROJ ORDER BY updated DESC", 50, []watchHandler{
+		func(e watchEvent) error { events = append(events, e); return nil },
+	}, &bytes.Buffer{})
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.poll())
+	assert.Empty(t, events)
+
+	now = now.Add(90 * time.Second)
+	require.NoError(t, w.poll())
+
+	assert.Equal(t, []string{
+		"project = PROJ ORDER BY updated DESC",
+		"(project = PROJ) AND updated >= -3m ORDER BY updated DESC",
+	}, jqls)
+
+	require.Len(t, events, 4)
+	assert.Equal(t, watchEvent{Type: "status", Key: "PROJ-1", Summary: "PROJ-1 summary", From: "To Do", To: "In Progress", Time: now}, events[0])
+	assert.Equal(t, watchEvent{Type: "assignee", Key: "PROJ-1", Summary: "PROJ-1 summary", From: "Unassigned", To: "Alice", Time: now}, events[1])
+	assert.Equal(t, "comment", events[2].Type)
+	assert.Equal(t, "Alice", events[2].Author)
+	assert.Equal(t, "On it", strings.TrimSpace(events[2].Comment))
+	assert.Equal(t, watchEvent{Type: "new", Key: "PROJ-3", Summary: "PROJ-3 summary", To: "To Do", Time: now}, events[3])
+}
+
+func TestWatcher_FirstPollIgnoresMax(t *testing.T) {
+	poll := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if poll == 0 {
+			w.Write([]byte(`{"total":2,"issues":[` + watchIssueJSON("PROJ-1", "To Do", "", 0) + `,` + watchIssueJSON("PROJ-2", "To Do", "", 0) + `]}`))
+		} else {
+			w.Write([]byte(`{"total":1,"issues":[` + watchIssueJSON("PROJ-2", "Done", "", 0) + `]}`))
+		}
+		poll++
+	}))
+	defer server.Close()
+
+	client, err := cmdtest.Options(server, &bytes.Buffer{}).APIClient()
+	require.NoError(t, err)
+
+	var events []watchEvent
+	var stderr bytes.Buffer
+	w := newWatcher(client, "project = PROJ", 1, []watchHandler{
+		func(e watchEvent) error { events = append(events, e); return nil },
+	}, &stderr)
+
+	require.NoError(t, w.poll())
+	assert.Len(t, w.snapshot, 2)
+	assert.Empty(t, stderr.String())
+
+	// PROJ-2 was beyond --max but is known, so its change is not reported as new
+	require.NoError(t, w.poll())
+	require.Len(t, events, 1)
+	assert.Equal(t, "status", events[0].Type)
+	assert.Equal(t, "PROJ-2", events[0].Key)
+	assert.Contains(t, stderr.String(), "changes beyond --max 1 were missed")
+}
+
+func TestWatcher_PartialSnapshot(t *testing.T) {
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	w := &watcher{snapshot: map[string]issueState{}, partial: true, started: started, now: time.Now}
+
+	issue := func(key string, created time.Time) api.Issue {
+		return api.Issue{Key: key, Fields: api.IssueFields{Created: &api.Time{Time: created}, Status: &api.Status{Name: "To Do"}}}
+	}
+
+	// Older issues beyond the snapshot are learned silently
+	events, err := w.diff(issue("PROJ-1", started.Add(-time.Hour)))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Contains(t, w.snapshot, "PROJ-1")
+
+	// Issues created since the watch started are new
+	events, err = w.diff(issue("PROJ-2", started.Add(time.Minute)))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "new", events[0].Type)
+}
+
+func TestDescribeEvent(t *testing.T) {
+	assert.Equal(t, "status To Do -> Done", describeEvent(watchEvent{Type: "status", From: "To Do", To: "Done"}))
+	assert.Equal(t, "new (Open): Crash", describeEvent(watchEvent{Type: "new", To: "Open", Summary: "Crash"}))
+	assert.Equal(t, "comment by Bob: two lines", describeEvent(watchEvent{Type: "comment", Author: "Bob", Comment: "two\nlines"}))
+}
+
+func TestExecHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the hook")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s|' \"$@\" > \""+out+"\"\n"), 0o700))
+
+	hook, err := execHook(script+` {{.Key}} "{{.Type}}: {{.Summary}}"`, &bytes.Buffer{}, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.NoError(t, hook(watchEvent{Type: "new", Key: "PROJ-1", Summary: "Crash; rm -rf $HOME"}))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-1|new: Crash; rm -rf $HOME|", string(data))
+
+	_, err = execHook(`say {{.Nope`, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
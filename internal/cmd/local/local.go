@@ -0,0 +1,110 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// Register registers the local commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:   "local",
+		Short: "Work with locally synced issues",
+		Long: `Commands that read the local issue store without contacting Jira.
+
+Fill the store with 'jtk sync --jql ...'.`,
+	}
+
+	cmd.AddCommand(newSearchCmd(opts))
+
+	parent.AddCommand(cmd)
+}
+
+func newSearchCmd(opts *root.Options) *cobra.Command {
+	var project string
+	var maxResults int
+
+	cmd := &cobra.Command{
+		Use:   "search <query>...",
+		Short: "Search synced issues offline",
+		Long: `Search the summaries, labels, descriptions and comments of synced issues.
+
+Results are ranked by relevance: matches in the summary count more than
+matches in labels, which count more than matches in the description and
+comments. Issues containing the query as a phrase rank higher, and an issue
+key in the query puts that issue first.`,
+		Example: `  jtk local search "flaky test"
+  jtk local search timeout --project PROJ --max 5
+  jtk local search login error -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(opts, strings.Join(args, " "), project, maxResults)
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only search issues of this project")
+	cmd.Flags().IntVarP(&maxResults, "max", "m", 20, "Maximum number of results")
+
+	return cmd
+}
+
+func runSearch(opts *root.Options, query, project string, maxResults int) error {
+	v := opts.View()
+
+	s, err := opts.Store()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	results, err := s.Search(query, store.SearchOptions{Project: project, Limit: maxResults})
+	if err != nil {
+		return err
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(results)
+	}
+
+	if len(results) == 0 {
+		n, err := s.Count()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			v.Info("The local store is empty; fill it with 'jtk sync --jql ...'")
+		} else {
+			v.Info("No synced issues match %q", query)
+		}
+		return nil
+	}
+
+	headers := []string{"KEY", "SUMMARY", "STATUS", "UPDATED", "SCORE", "MATCHED"}
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := ""
+		if r.Issue.Fields.Status != nil {
+			status = r.Issue.Fields.Status.Name
+		}
+		updated := ""
+		if r.Issue.Fields.Updated != nil {
+			updated = v.Time(r.Issue.Fields.Updated.Time)
+		}
+		rows = append(rows, []string{
+			r.Issue.Key,
+			view.Truncate(r.Issue.Fields.Summary, 50),
+			status,
+			updated,
+			fmt.Sprintf("%.2f", r.Score),
+			strings.Join(r.Matched, ","),
+		})
+	}
+
+	return v.Table(headers, rows)
+}
@@ -0,0 +1,96 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
+)
+
+func newTestOptions(t *testing.T, stdout *bytes.Buffer, records ...store.Record) *root.Options {
+	opts := &root.Options{
+		Output: "table",
+		TZ:     "UTC",
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+
+	if len(records) > 0 {
+		s, err := opts.Store()
+		require.NoError(t, err)
+		require.NoError(t, s.Put(records...))
+		require.NoError(t, s.Close())
+	}
+	return opts
+}
+
+func record(key, project, summary string) store.Record {
+	return store.Record{Issue: api.Issue{Key: key, Fields: api.IssueFields{
+		Summary: summary,
+		Project: &api.Project{Key: project},
+		Status:  &api.Status{Name: "To Do"},
+	}}}
+}
+
+func TestRunSearch(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := newTestOptions(t, &stdout,
+		record("PROJ-1", "PROJ", "Flaky test in CI"),
+		record("PROJ-2", "PROJ", "Upgrade test runner"),
+		record("OPS-1", "OPS", "Flaky test alerts"),
+	)
+
+	require.NoError(t, runSearch(opts, "flaky test", "PROJ", 20))
+
+	out := stdout.String()
+	assert.Contains(t, out, "KEY")
+	assert.Contains(t, out, "MATCHED")
+	assert.Contains(t, out, "PROJ-1")
+	assert.Contains(t, out, "PROJ-2")
+	assert.NotContains(t, out, "OPS-1")
+	assert.Less(t, bytes.Index(stdout.Bytes(), []byte("PROJ-1")), bytes.Index(stdout.Bytes(), []byte("PROJ-2")))
+}
+
+func TestRunSearch_JSON(t *testing.T) {
+	var stdout bytes.Buffer
+	opts := newTestOptions(t, &stdout,
+		record("PROJ-1", "PROJ", "Flaky test in CI"),
+		record("PROJ-2", "PROJ", "Upgrade test runner"),
+	)
+	opts.Output = "json"
+
+	require.NoError(t, runSearch(opts, "flaky", "", 1))
+
+	var results []store.Result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "PROJ-1", results[0].Issue.Key)
+	assert.Equal(t, []string{"summary"}, results[0].Matched)
+	assert.Positive(t, results[0].Score)
+}
+
+func TestRunSearch_NoResults(t *testing.T) {
+	t.Run("empty store", func(t *testing.T) {
+		var stdout bytes.Buffer
+		opts := newTestOptions(t, &stdout)
+
+		require.NoError(t, runSearch(opts, "flaky", "", 20))
+		assert.Contains(t, stdout.String(), "jtk sync")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		var stdout bytes.Buffer
+		opts := newTestOptions(t, &stdout, record("PROJ-1", "PROJ", "Slow build"))
+
+		require.NoError(t, runSearch(opts, "flaky", "", 20))
+		assert.Contains(t, stdout.String(), `No synced issues match "flaky"`)
+	})
+}
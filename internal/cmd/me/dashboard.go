@@ -0,0 +1,301 @@
+package me
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// dashboardFields are the fields the dashboard reads
+var dashboardFields = []string{"summary", "status", "priority", "updated"}
+
+// categoryOrder is the order assigned issues are grouped in, with the
+// heading of each status category
+var categoryOrder = []struct {
+	key   string
+	title string
+}{
+	{"indeterminate", "In Progress"},
+	{"new", "To Do"},
+	{"done", "Done"},
+}
+
+// dashboardOptions holds the flags of me dashboard
+type dashboardOptions struct {
+	Max   int
+	Since string
+}
+
+// dashboard is the JSON form of the dashboard
+type dashboard struct {
+	User     *api.User          `json:"user"`
+	Counts   map[string]int     `json:"counts"`
+	Sections []dashboardSection `json:"sections"`
+}
+
+// dashboardSection is the result of one query
+type dashboardSection struct {
+	ID     string           `json:"id"`
+	Title  string           `json:"title"`
+	JQL    string           `json:"jql"`
+	Count  int              `json:"count"`
+	Issues []dashboardIssue `json:"issues"`
+}
+
+// dashboardIssue is an issue as shown on the dashboard
+type dashboardIssue struct {
+	Key            string    `json:"key"`
+	Summary        string    `json:"summary"`
+	Status         string    `json:"status"`
+	StatusCategory string    `json:"statusCategory"`
+	Priority       string    `json:"priority,omitempty"`
+	Updated        *api.Time `json:"updated,omitempty"`
+}
+
+func newDashboardCmd(opts *root.Options) *cobra.Command {
+	var dash dashboardOptions
+
+	cmd := &cobra.Command{
+		Use:     "dashboard",
+		Aliases: []string{"dash"},
+		Short:   "Show your open work at a glance",
+		Long: `Show your open work in one view:
+
+  - issues assigned to you and unresolved, grouped by status category
+  - unresolved issues you reported
+  - issues you watch that were updated recently
+  - your issues in open sprints
+  - issues whose recent comments mention you
+
+The queries run concurrently. Counts are the number of matching issues; at
+most --max issues are listed per section. Mentions are found by a text search
+of comments for your display name, so the section also lists comments that
+merely contain your name without an @-mention.`,
+		Example: `  # Your dashboard
+  jtk me dashboard
+
+  # Look back two weeks for watched issues and mentions
+  jtk me dashboard --since 2w
+
+  # Number of issues assigned to you, for a shell prompt
+  jtk me dashboard -o jsonpath='{.counts.assigned}'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDashboard(opts, dash)
+		},
+	}
+
+	cmd.Flags().IntVarP(&dash.Max, "max", "m", 10, "Maximum number of issues listed per section")
+	cmd.Flags().StringVar(&dash.Since, "since", "7d", "How far back to look for watched updates and mentions (7d, 2w or a date)")
+
+	return cmd
+}
+
+func runDashboard(opts *root.Options, dash dashboardOptions) error {
+	v := opts.View()
+
+	since, err := jqlSince(dash.Since)
+	if err != nil {
+		return err
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	sections := dashboardSections(user, since)
+	errs := make([]error, len(sections))
+	var wg sync.WaitGroup
+	for i := range sections {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fillSection(client, &sections[i], dash.Max)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to search %s: %w", strings.ToLower(sections[i].Title), err)
+		}
+	}
+
+	board := dashboard{User: user, Counts: map[string]int{}, Sections: sections}
+	for _, s := range sections {
+		board.Counts[s.ID] = s.Count
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(board)
+	}
+	if v.Format != view.FormatTable {
+		return v.Table(dashboardTable(v, sections))
+	}
+	return renderDashboard(v, board)
+}
+
+// dashboardSections returns the queries of the dashboard. since is a JQL date
+// or relative period.
+func dashboardSections(user *api.User, since string) []dashboardSection {
+	return []dashboardSection{
+		{ID: "assigned", Title: "Assigned to me", JQL: "assignee = currentUser() AND resolution = EMPTY ORDER BY priority DESC, updated DESC"},
+		{ID: "reported", Title: "Reported by me", JQL: "reporter = currentUser() AND resolution = EMPTY ORDER BY updated DESC"},
+		{ID: "watching", Title: "Watching, updated recently", JQL: fmt.Sprintf("watcher = currentUser() AND updated >= %s ORDER BY updated DESC", since)},
+		{ID: "sprint", Title: "In open sprints", JQL: "assignee = currentUser() AND sprint in openSprints() ORDER BY rank"},
+		{ID: "mentioned", Title: "Mentioned in recent comments", JQL: fmt.Sprintf(`comment ~ "\"%s\"" AND updated >= %s ORDER BY updated DESC`,
+			jqlPhrase(user.DisplayName), since)},
+	}
+}
+
+// fillSection runs a section's query
+func fillSection(client *api.Client, s *dashboardSection, max int) error {
+	result, err := client.Search(api.SearchOptions{JQL: s.JQL, MaxResults: max, Fields: dashboardFields})
+	if err != nil {
+		return err
+	}
+
+	s.Issues = make([]dashboardIssue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		d := dashboardIssue{Key: issue.Key, Summary: issue.Fields.Summary, Updated: issue.Fields.Updated}
+		if st := issue.Fields.Status; st != nil {
+			d.Status, d.StatusCategory = st.Name, st.StatusCategory.Key
+		}
+		if p := issue.Fields.Priority; p != nil {
+			d.Priority = p.Name
+		}
+		s.Issues = append(s.Issues, d)
+	}
+
+	s.Count = result.Total
+	if s.Count < len(s.Issues) {
+		s.Count = len(s.Issues)
+	}
+	return nil
+}
+
+// jqlSince turns --since into a JQL date: spans such as 7d (or -7d) become
+// relative dates, anything else must be a date
+func jqlSince(since string) (string, error) {
+	since = strings.TrimSpace(since)
+	if _, err := api.ParseSince(since, time.Now()); err != nil {
+		return "", fmt.Errorf("invalid --since %q: use a span such as 7d or a date such as 2024-03-01", since)
+	}
+	if span := strings.TrimPrefix(since, "-"); len(span) > 1 && strings.ContainsRune("mhdw", rune(span[len(span)-1])) {
+		if n, err := strconv.Atoi(span[:len(span)-1]); err == nil && n >= 0 {
+			return fmt.Sprintf("-%d%c", n, span[len(span)-1]), nil
+		}
+	}
+	if _, err := api.ParseDate(since); err != nil {
+		return "", fmt.Errorf("invalid --since %q: use a span such as 7d or a date such as 2024-03-01", since)
+	}
+	return fmt.Sprintf("%q", since), nil
+}
+
+// jqlPhrase drops the characters that would end a quoted phrase in a JQL
+// text search; they are ignored by the search anyway
+func jqlPhrase(s string) string {
+	return strings.NewReplacer(`\`, "", `"`, "").Replace(s)
+}
+
+func renderDashboard(v *view.View, board dashboard) error {
+	bold := color.New(color.Bold)
+	v.Println("%s", bold.Sprint(board.User.DisplayName))
+
+	for _, s := range board.Sections {
+		v.Println("\n%s", bold.Sprintf("%s (%d)", s.Title, s.Count))
+		if len(s.Issues) == 0 {
+			v.Println("  none")
+			continue
+		}
+
+		if s.ID != "assigned" {
+			if err := v.Table(issueRows(v, s.Issues)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, category := range categoryOrder {
+			var issues []dashboardIssue
+			for _, issue := range s.Issues {
+				if issue.StatusCategory == category.key || (category.key == "new" && !knownCategory(issue.StatusCategory)) {
+					issues = append(issues, issue)
+				}
+			}
+			if len(issues) == 0 {
+				continue
+			}
+			v.Println("%s (%d)", category.title, len(issues))
+			if err := v.Table(issueRows(v, issues)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hiddenIssues(board.Sections) {
+		v.Info("\nSome sections have more issues than shown; use --max to list more")
+	}
+	return nil
+}
+
+func knownCategory(key string) bool {
+	for _, c := range categoryOrder {
+		if c.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// hiddenIssues reports whether any section has more issues than it lists
+func hiddenIssues(sections []dashboardSection) bool {
+	for _, s := range sections {
+		if s.Count > len(s.Issues) {
+			return true
+		}
+	}
+	return false
+}
+
+func issueRows(v *view.View, issues []dashboardIssue) ([]string, [][]string) {
+	headers := []string{"KEY", "SUMMARY", "STATUS", "UPDATED"}
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		updated := "-"
+		if issue.Updated != nil {
+			updated = v.Time(issue.Updated.Time)
+		}
+		rows[i] = []string{issue.Key, view.Truncate(issue.Summary, 60), issue.Status, updated}
+	}
+	return headers, rows
+}
+
+// dashboardTable flattens the sections into one table, for formats other
+// than the terminal table
+func dashboardTable(v *view.View, sections []dashboardSection) ([]string, [][]string) {
+	headers := []string{"SECTION", "KEY", "SUMMARY", "STATUS", "UPDATED"}
+	var rows [][]string
+	for _, s := range sections {
+		_, issueRows := issueRows(v, s.Issues)
+		for _, row := range issueRows {
+			rows = append(rows, append([]string{s.ID}, row...))
+		}
+	}
+	return headers, rows
+}
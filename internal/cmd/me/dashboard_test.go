@@ -0,0 +1,121 @@
+package me
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func dashboardIssueJSON(key, status, category string) string {
+	return `{"key":"` + key + `","fields":{"summary":"` + key + ` summary","status":{"name":"` + status +
+		`","statusCategory":{"key":"` + category + `"}},"updated":"2024-03-01T10:00:00.000+0000"}}`
+}
+
+func newDashboardTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/myself":
+			w.Write([]byte(`{"accountId":"abc","displayName":"Alice \"Al\" Example","active":true}`))
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, 2, req.MaxResults)
+
+			mu.Lock()
+			queries = append(queries, req.JQL)
+			mu.Unlock()
+
+			switch {
+			case strings.HasPrefix(req.JQL, "assignee = currentUser() AND resolution"):
+				w.Write([]byte(`{"total":5,"issues":[` + dashboardIssueJSON("PROJ-1", "To Do", "new") + `,` +
+					dashboardIssueJSON("PROJ-2", "In Review", "indeterminate") + `]}`))
+			case strings.HasPrefix(req.JQL, "reporter"):
+				w.Write([]byte(`{"total":1,"issues":[` + dashboardIssueJSON("PROJ-3", "Done", "done") + `]}`))
+			default:
+				w.Write([]byte(`{"total":0,"issues":[]}`))
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &queries
+}
+
+func TestRunDashboard_JSON(t *testing.T) {
+	server, queries := newDashboardTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runDashboard(opts, dashboardOptions{Max: 2, Since: "2w"}))
+
+	var board dashboard
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &board))
+
+	assert.Equal(t, map[string]int{"assigned": 5, "reported": 1, "watching": 0, "sprint": 0, "mentioned": 0}, board.Counts)
+	require.Len(t, board.Sections, 5)
+	assert.Equal(t, "PROJ-2", board.Sections[0].Issues[1].Key)
+	assert.Equal(t, "indeterminate", board.Sections[0].Issues[1].StatusCategory)
+	assert.Empty(t, board.Sections[2].Issues)
+
+	assert.Len(t, *queries, 5)
+	assert.Contains(t, *queries, "watcher = currentUser() AND updated >= -2w ORDER BY updated DESC")
+	assert.Contains(t, *queries, `comment ~ "\"Alice Al Example\"" AND updated >= -2w ORDER BY updated DESC`)
+}
+
+func TestRunDashboard_Table(t *testing.T) {
+	server, _ := newDashboardTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	require.NoError(t, runDashboard(cmdtest.Options(server, &stdout), dashboardOptions{Max: 2, Since: "7d"}))
+
+	out := stdout.String()
+	assert.Contains(t, out, "Assigned to me (5)\nIn Progress (1)\n")
+	assert.Less(t, strings.Index(out, "PROJ-2"), strings.Index(out, "PROJ-1"), "in progress is listed before to do")
+	assert.Contains(t, out, "To Do (1)\n")
+	assert.Contains(t, out, "Watching, updated recently (0)\n  none\n")
+}
+
+func TestJQLSince(t *testing.T) {
+	tests := []struct {
+		since string
+		want  string
+	}{
+		{"7d", "-7d"},
+		{"12h", "-12h"},
+		{"2w", "-2w"},
+		{"-7d", "-7d"},
+		{"+3d", "-3d"},
+		{" 30m ", "-30m"},
+		{"2024-03-01", `"2024-03-01"`},
+	}
+	for _, tt := range tests {
+		got, err := jqlSince(tt.since)
+		require.NoError(t, err, tt.since)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := jqlSince("yesterday")
+	assert.ErrorContains(t, err, `invalid --since "yesterday"`)
+	_, err = jqlSince("--7d")
+	assert.Error(t, err)
+	_, err = jqlSince("2024-03-01T10:00:00Z")
+	assert.Error(t, err)
+}
@@ -16,12 +16,17 @@ func Register(parent *cobra.Command, opts *root.Options) {
   jtk me
 
   # Show just the account ID (for scripting)
-  jtk me -o plain`,
+  jtk me -o plain
+
+  # Your open work at a glance
+  jtk me dashboard`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return run(opts)
 		},
 	}
 
+	cmd.AddCommand(newDashboardCmd(opts))
+
 	parent.AddCommand(cmd)
 }
 
@@ -38,7 +43,7 @@ func run(opts *root.Options) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(user)
 	}
 
@@ -0,0 +1,378 @@
+package releasenotes
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// Formats of the built-in templates; -o table renders Markdown and -o plain text
+const (
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+	formatText     = "text"
+)
+
+// Groupings for --group-by
+const (
+	groupByType      = "type"
+	groupByComponent = "component"
+)
+
+// Names of the groups for issues without an issue type or component
+const (
+	noType      = "Other"
+	noComponent = "No component"
+)
+
+// noteFields are the issue fields release notes need
+var noteFields = []string{"summary", "issuetype", "status", "components", "parent"}
+
+// markdownEscaper escapes characters that would format Markdown text
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`,
+	`[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`,
+)
+
+// notesOptions holds the flags of release-notes
+type notesOptions struct {
+	Project    string
+	Version    string
+	JQL        string
+	Title      string
+	GroupBy    []string
+	Epics      bool
+	Links      bool
+	Unresolved bool
+	Max        int
+}
+
+// notes is the data release notes templates render
+type notes struct {
+	Title       string       `json:"title"`
+	Version     *api.Version `json:"version,omitempty"`
+	JQL         string       `json:"jql"`
+	Issues      []note       `json:"issues"`
+	ByType      []group      `json:"byType,omitempty"`
+	ByComponent []group      `json:"byComponent,omitempty"`
+}
+
+// note is one issue in release notes
+type note struct {
+	Key        string   `json:"key"`
+	Summary    string   `json:"summary"`
+	Type       string   `json:"type"`
+	Status     string   `json:"status"`
+	Components []string `json:"components"`
+	Epic       *epic    `json:"epic,omitempty"`
+	URL        string   `json:"url,omitempty"`
+}
+
+// epic is the epic an issue belongs to
+type epic struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	URL     string `json:"url,omitempty"`
+}
+
+// group is the issues of one issue type or component
+type group struct {
+	Name   string `json:"name"`
+	Issues []note `json:"issues"`
+}
+
+// Register registers the release-notes command
+func Register(parent *cobra.Command, opts *root.Options) {
+	var n notesOptions
+
+	cmd := &cobra.Command{
+		Use:     "release-notes",
+		Aliases: []string{"changelog"},
+		Short:   "Generate release notes",
+		Long: `Generate release notes from the resolved issues of a fix version or a JQL query.
+
+Issues are grouped by issue type and by component, and rendered as Markdown
+(the default, or -o markdown), HTML (-o html) or plain text (-o plain). Use
+-o template with --template for your own Go template; it receives the same
+data as -o json: .Title, .Version, .Issues, .ByType and .ByComponent,
+where each group has a .Name and .Issues, and each issue a .Key, .Summary,
+.Type, .Status, .Components, .Epic (with --epics) and .URL (with --links).
+Templates can use join (e.g. {{join ", " .Components}}) and md to escape
+Markdown text.`,
+		Example: `  # Markdown notes for a version
+  jtk release-notes --project PROJ --version 2.3.0 > RELEASE.md
+
+  # HTML with epics and issue links
+  jtk release-notes -p PROJ --version 2.3.0 -o html --epics --links
+
+  # Notes for a JQL query, grouped by component only
+  jtk release-notes --jql "project = PROJ AND resolved >= -14d" --group-by component
+
+  # Your own layout
+  jtk release-notes -p PROJ --version 2.3.0 -o template --template "$(cat notes.tmpl)"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReleaseNotes(opts, n)
+		},
+	}
+
+	cmd.Flags().StringVarP(&n.Project, "project", "p", "", "Project key")
+	cmd.Flags().StringVar(&n.Version, "version", "", "Fix version name or ID (requires --project)")
+	cmd.Flags().StringVar(&n.JQL, "jql", "", "JQL query selecting the issues, instead of or in addition to --version")
+	cmd.Flags().StringVar(&n.Title, "title", "", "Title of the notes (default: project and version)")
+	cmd.Flags().StringSliceVar(&n.GroupBy, "group-by", []string{groupByType, groupByComponent}, "Groupings: type, component")
+	cmd.Flags().BoolVar(&n.Epics, "epics", false, "Include each issue's epic")
+	cmd.Flags().BoolVar(&n.Links, "links", false, "Include a link to each issue")
+	cmd.Flags().BoolVar(&n.Unresolved, "include-unresolved", false, "Include issues that are not resolved")
+	cmd.Flags().IntVarP(&n.Max, "max", "m", 1000, "Maximum number of issues")
+
+	parent.AddCommand(cmd)
+}
+
+func runReleaseNotes(opts *root.Options, n notesOptions) error {
+	v := opts.View()
+
+	render, err := notesRenderer(opts.Output, opts.Template)
+	if err != nil {
+		return err
+	}
+	byType, byComponent, err := parseGroupBy(n.GroupBy)
+	if err != nil {
+		return err
+	}
+	n.Project = strings.ToUpper(n.Project)
+	if n.Version == "" && n.JQL == "" {
+		return fmt.Errorf("--version or --jql is required")
+	}
+	if n.Version != "" && n.Project == "" {
+		return fmt.Errorf("--version requires --project")
+	}
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	var version *api.Version
+	if n.Version != "" {
+		versions, err := client.GetProjectVersions(n.Project)
+		if err != nil {
+			return err
+		}
+		if version = api.FindVersion(versions, n.Version); version == nil {
+			return fmt.Errorf("version %q not found in project %s", n.Version, n.Project)
+		}
+	}
+
+	jql := notesJQL(n, version)
+	issues, err := client.SearchAllFields(jql, n.Max, noteFields)
+	if err != nil {
+		return err
+	}
+
+	rn := notes{Title: n.Title, Version: version, JQL: jql, Issues: make([]note, 0, len(issues))}
+	if rn.Title == "" {
+		rn.Title = notesTitle(n.Project, version)
+	}
+	for _, issue := range issues {
+		rn.Issues = append(rn.Issues, newNote(client, issue, n.Epics, n.Links))
+	}
+	sort.SliceStable(rn.Issues, func(i, j int) bool { return keyLess(rn.Issues[i].Key, rn.Issues[j].Key) })
+
+	if byType {
+		rn.ByType = groupNotes(rn.Issues, noType, func(i note) []string { return []string{i.Type} })
+	}
+	if byComponent {
+		rn.ByComponent = groupNotes(rn.Issues, noComponent, func(i note) []string { return i.Components })
+	}
+
+	if render == nil {
+		return v.JSON(rn)
+	}
+	return render(opts.Stdout, rn)
+}
+
+// notesRenderer parses the template the -o and --template flags select into
+// a function writing notes. It returns nil for the formats View.JSON renders.
+func notesRenderer(output, custom string) (func(io.Writer, notes) error, error) {
+	f, arg := view.ParseFormat(output)
+	format := string(f)
+	switch f {
+	case view.FormatJSON, view.FormatNDJSON, view.FormatJSONPath:
+		return nil, nil
+	case view.FormatTemplate:
+		if arg != "" {
+			custom = arg
+		}
+		if custom == "" {
+			return nil, fmt.Errorf("no template given: use --template or -o template=...")
+		}
+	case "", view.FormatTable, view.FormatMarkdown:
+		format = formatMarkdown
+	case view.FormatPlain:
+		format = formatText
+	case formatHTML:
+	default:
+		return nil, fmt.Errorf("-o %s is not supported by release-notes: use markdown, plain, html, json or template", f)
+	}
+
+	text := builtinTemplates[format]
+	if f == view.FormatTemplate {
+		text = custom
+	}
+
+	var execute func(io.Writer, interface{}) error
+	if format == formatHTML {
+		tmpl, err := htmltemplate.New(format).Funcs(htmltemplate.FuncMap{"join": join}).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		execute = tmpl.Execute
+	} else {
+		tmpl, err := template.New(format).Funcs(template.FuncMap{"join": join, "md": markdownEscaper.Replace}).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		execute = tmpl.Execute
+	}
+
+	return func(w io.Writer, rn notes) error {
+		var sb strings.Builder
+		if err := execute(&sb, rn); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		_, err := fmt.Fprintln(w, strings.TrimRight(sb.String(), "\n"))
+		return err
+	}, nil
+}
+
+// parseGroupBy reads the --group-by values
+func parseGroupBy(values []string) (byType, byComponent bool, err error) {
+	for _, value := range values {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case groupByType:
+			byType = true
+		case groupByComponent:
+			byComponent = true
+		default:
+			return false, false, fmt.Errorf("invalid --group-by %q: must be type or component", value)
+		}
+	}
+	return byType, byComponent, nil
+}
+
+// notesJQL builds the query selecting the issues of the notes
+func notesJQL(n notesOptions, version *api.Version) string {
+	jql := n.JQL
+	if version != nil {
+		jql = api.AndJQL(jql, fmt.Sprintf("project = %q AND fixVersion = %s", n.Project, version.ID))
+	} else if n.Project != "" {
+		jql = api.AndJQL(jql, fmt.Sprintf("project = %q", n.Project))
+	}
+	if !n.Unresolved {
+		jql = api.AndJQL(jql, "resolution IS NOT EMPTY")
+	}
+	return jql
+}
+
+func notesTitle(project string, version *api.Version) string {
+	switch {
+	case version != nil && project != "":
+		return fmt.Sprintf("%s %s", project, version.Name)
+	case version != nil:
+		return version.Name
+	default:
+		return "Release notes"
+	}
+}
+
+func newNote(client *api.Client, issue api.Issue, epics, links bool) note {
+	n := note{Key: issue.Key, Summary: issue.Fields.Summary, Type: noType, Components: []string{}}
+	if issue.Fields.IssueType != nil {
+		n.Type = issue.Fields.IssueType.Name
+	}
+	if issue.Fields.Status != nil {
+		n.Status = issue.Fields.Status.Name
+	}
+	for _, c := range issue.Fields.Components {
+		n.Components = append(n.Components, c.Name)
+	}
+
+	if links {
+		n.URL = client.IssueURL(issue.Key)
+	}
+	if p := issue.Fields.Parent; epics && p != nil && isEpic(p.Fields.IssueType) {
+		n.Epic = &epic{Key: p.Key, Summary: p.Fields.Summary}
+		if links {
+			n.Epic.URL = client.IssueURL(p.Key)
+		}
+	}
+	return n
+}
+
+// isEpic reports whether an issue type is at the epic level of the hierarchy
+func isEpic(t *api.IssueType) bool {
+	return t != nil && (t.HierarchyLevel == 1 || strings.EqualFold(t.Name, "Epic"))
+}
+
+// groupNotes groups issues by the names keys returns for them, in name order
+// with the group for issues without a name last. An issue is in every group
+// it names.
+func groupNotes(issues []note, none string, keys func(note) []string) []group {
+	byName := map[string][]note{}
+	for _, i := range issues {
+		names := keys(i)
+		if len(names) == 0 {
+			names = []string{none}
+		}
+		for _, name := range names {
+			byName[name] = append(byName[name], i)
+		}
+	}
+
+	groups := make([]group, 0, len(byName))
+	for name, members := range byName {
+		groups = append(groups, group{Name: name, Issues: members})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].Name == none) != (groups[j].Name == none) {
+			return groups[j].Name == none
+		}
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+	return groups
+}
+
+// keyLess orders issue keys by project, then numerically
+func keyLess(a, b string) bool {
+	pa, na := splitKey(a)
+	pb, nb := splitKey(b)
+	if pa != pb {
+		return pa < pb
+	}
+	return na < nb
+}
+
+func splitKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+	n, _ := strconv.Atoi(key[i+1:])
+	return key[:i], n
+}
+
+// join joins values with a separator; values come last for pipelines
+func join(sep string, values []string) string {
+	return strings.Join(values, sep)
+}
@@ -0,0 +1,239 @@
+package releasenotes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+// newNotesTestServer serves version 2.3.0 of PROJ with three resolved issues
+func newNotesTestServer(t *testing.T, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/project/PROJ/versions":
+			w.Write([]byte(`[
+				{"id":"10000","name":"2.2.0","released":true},
+				{"id":"10001","name":"2.3.0","description":"Spring release","released":true,"releaseDate":"2024-05-01"}
+			]`))
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*queries = append(*queries, req.JQL)
+			w.Write([]byte(`{"total":3,"issues":[
+				{"key":"PROJ-12","fields":{"summary":"Crash on <empty> input","issuetype":{"name":"Bug"},"status":{"name":"Done"},
+					"components":[{"name":"API"}],
+					"parent":{"key":"PROJ-1","fields":{"summary":"Hardening","issuetype":{"name":"Epic","hierarchyLevel":1}}}}},
+				{"key":"PROJ-9","fields":{"summary":"Export to *CSV*","issuetype":{"name":"Story"},"status":{"name":"Done"},
+					"components":[{"name":"UI"},{"name":"API"}]}},
+				{"key":"PROJ-10","fields":{"summary":"Fix typo","issuetype":{"name":"Bug"},"status":{"name":"Done"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunReleaseNotes_Markdown(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{
+		Project: "PROJ", Version: "2.3.0",
+		GroupBy: []string{"type", "component"}, Epics: true, Links: true, Max: 100,
+	}))
+
+	assert.Equal(t, []string{`(project = "PROJ" AND fixVersion = 10001) AND resolution IS NOT EMPTY`}, queries)
+	assert.Equal(t, `# PROJ 2.3.0
+
+Released 2024-05-01
+
+Spring release
+
+## By type
+
+### Bug
+
+- [PROJ-10](https://example.atlassian.net/browse/PROJ-10) Fix typo
+- [PROJ-12](https://example.atlassian.net/browse/PROJ-12) Crash on \<empty\> input ([Hardening](https://example.atlassian.net/browse/PROJ-1))
+
+### Story
+
+- [PROJ-9](https://example.atlassian.net/browse/PROJ-9) Export to \*CSV\*
+
+## By component
+
+### API
+
+- [PROJ-9](https://example.atlassian.net/browse/PROJ-9) Export to \*CSV\*
+- [PROJ-12](https://example.atlassian.net/browse/PROJ-12) Crash on \<empty\> input ([Hardening](https://example.atlassian.net/browse/PROJ-1))
+
+### UI
+
+- [PROJ-9](https://example.atlassian.net/browse/PROJ-9) Export to \*CSV\*
+
+### No component
+
+- [PROJ-10](https://example.atlassian.net/browse/PROJ-10) Fix typo
+`, stdout.String())
+}
+
+func TestRunReleaseNotes_HTML(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	opts.Output = "html"
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{
+		Project: "PROJ", Version: "2.3.0", Title: "Widgets 2.3",
+		GroupBy: []string{"type"}, Max: 100,
+	}))
+
+	out := stdout.String()
+	assert.Contains(t, out, "<h1>Widgets 2.3</h1>\n<p>Released 2024-05-01</p>")
+	assert.Contains(t, out, "<h3>Bug</h3>\n<ul>\n  <li>PROJ-10 Fix typo</li>\n  <li>PROJ-12 Crash on &lt;empty&gt; input</li>\n</ul>")
+	assert.NotContains(t, out, "By component")
+	assert.NotContains(t, out, "Hardening")
+}
+
+func TestRunReleaseNotes_Text(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	opts.Output = "plain"
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{
+		JQL:     "project = PROJ AND resolved >= -14d ORDER BY key",
+		GroupBy: []string{"component"}, Epics: true, Max: 100,
+	}))
+
+	assert.Equal(t, []string{"(project = PROJ AND resolved >= -14d) AND resolution IS NOT EMPTY ORDER BY key"}, queries)
+	assert.Equal(t, `Release notes
+
+BY COMPONENT
+
+API
+  - PROJ-9 Export to *CSV*
+  - PROJ-12 Crash on <empty> input [PROJ-1 Hardening]
+
+UI
+  - PROJ-9 Export to *CSV*
+
+No component
+  - PROJ-10 Fix typo
+`, stdout.String())
+}
+
+func TestRunReleaseNotes_Template(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "template"
+	opts.Template = `{{.Title}}: {{len .Issues}} issue(s){{range .Issues}}
+{{.Key}} [{{join ", " .Components}}]{{end}}`
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{
+		Project: "PROJ", JQL: "fixVersion = 2.3.0",
+		GroupBy: []string{"type"}, Unresolved: true, Max: 100,
+	}))
+
+	assert.Equal(t, []string{`(fixVersion = 2.3.0) AND project = "PROJ"`}, queries)
+	assert.Equal(t, "Release notes: 3 issue(s)\nPROJ-9 [UI, API]\nPROJ-10 []\nPROJ-12 [API]\n", stdout.String())
+}
+
+func TestRunReleaseNotes_JSON(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{
+		Project: "proj", Version: "10001", GroupBy: []string{"type"}, Max: 100,
+	}))
+
+	var rn notes
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &rn))
+	assert.Equal(t, "PROJ 2.3.0", rn.Title)
+	require.NotNil(t, rn.Version)
+	assert.Equal(t, "2024-05-01", rn.Version.ReleaseDate)
+	require.Len(t, rn.Issues, 3)
+	assert.Equal(t, []string{"Bug", "Story"}, []string{rn.ByType[0].Name, rn.ByType[1].Name})
+	assert.Nil(t, rn.ByComponent)
+}
+
+func TestRunReleaseNotes_Errors(t *testing.T) {
+	var queries []string
+	server := newNotesTestServer(t, &queries)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		output string
+		n      notesOptions
+		want   string
+	}{
+		{"no selection", "", notesOptions{}, "--version or --jql is required"},
+		{"version without project", "", notesOptions{Version: "2.3.0"}, "--version requires --project"},
+		{"unknown version", "", notesOptions{Project: "PROJ", Version: "9.9"}, `version "9.9" not found in project PROJ`},
+		{"unsupported output", "csv", notesOptions{JQL: "a = b"}, "-o csv is not supported by release-notes"},
+		{"bad grouping", "plain", notesOptions{JQL: "a = b", GroupBy: []string{"assignee"}}, `invalid --group-by "assignee"`},
+		{"missing template", "template", notesOptions{JQL: "a = b"}, "no template given"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := cmdtest.Options(server, &bytes.Buffer{})
+			if tt.output != "" {
+				opts.Output = tt.output
+			}
+			err := runReleaseNotes(opts, tt.n)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+	assert.Empty(t, queries)
+}
+
+func TestRunReleaseNotes_NoIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total":0,"issues":[]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runReleaseNotes(opts, notesOptions{JQL: "a = b", GroupBy: []string{"type"}, Max: 100}))
+	assert.Equal(t, "# Release notes\n\nNo issues.\n", stdout.String())
+}
+
+func TestKeyLess(t *testing.T) {
+	assert.True(t, keyLess("PROJ-9", "PROJ-10"))
+	assert.False(t, keyLess("PROJ-10", "PROJ-9"))
+	assert.True(t, keyLess("ABC-100", "PROJ-1"))
+}
@@ -0,0 +1,119 @@
+package releasenotes
+
+// The built-in templates, by --format. Each renders a notes value; the
+// "issue" template renders one line per issue.
+var builtinTemplates = map[string]string{
+	formatMarkdown: markdownTemplate,
+	formatHTML:     htmlTemplate,
+	formatText:     textTemplate,
+}
+
+const markdownTemplate = `# {{.Title}}
+{{- with .Version}}{{if .ReleaseDate}}
+
+Released {{.ReleaseDate}}{{end}}{{if .Description}}
+
+{{md .Description}}{{end}}{{end}}
+{{- if not .Issues}}
+
+No issues.
+{{- end}}
+{{- if .ByType}}
+
+## By type
+{{- range .ByType}}
+
+### {{md .Name}}
+{{range .Issues}}
+- {{template "issue" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .ByComponent}}
+
+## By component
+{{- range .ByComponent}}
+
+### {{md .Name}}
+{{range .Issues}}
+- {{template "issue" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{define "issue" -}}
+{{if .URL}}[{{.Key}}]({{.URL}}){{else}}{{.Key}}{{end}} {{md .Summary}}
+{{- with .Epic}} ({{if .URL}}[{{md .Summary}}]({{.URL}}){{else}}{{md .Summary}}{{end}}){{end}}
+{{- end}}
+`
+
+const htmlTemplate = `<h1>{{.Title}}</h1>
+{{- with .Version}}{{if .ReleaseDate}}
+<p>Released {{.ReleaseDate}}</p>{{end}}{{if .Description}}
+<p>{{.Description}}</p>{{end}}{{end}}
+{{- if not .Issues}}
+<p>No issues.</p>
+{{- end}}
+{{- if .ByType}}
+<h2>By type</h2>
+{{- range .ByType}}
+<h3>{{.Name}}</h3>
+<ul>
+{{- range .Issues}}
+  <li>{{template "issue" .}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+{{- if .ByComponent}}
+<h2>By component</h2>
+{{- range .ByComponent}}
+<h3>{{.Name}}</h3>
+<ul>
+{{- range .Issues}}
+  <li>{{template "issue" .}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+{{define "issue" -}}
+{{if .URL}}<a href="{{.URL}}">{{.Key}}</a>{{else}}{{.Key}}{{end}} {{.Summary}}
+{{- with .Epic}} ({{if .URL}}<a href="{{.URL}}">{{.Summary}}</a>{{else}}{{.Summary}}{{end}}){{end}}
+{{- end}}
+`
+
+const textTemplate = `{{.Title}}
+{{- with .Version}}{{if .ReleaseDate}}
+Released {{.ReleaseDate}}{{end}}{{if .Description}}
+{{.Description}}{{end}}{{end}}
+{{- if not .Issues}}
+
+No issues.
+{{- end}}
+{{- if .ByType}}
+
+BY TYPE
+{{- range .ByType}}
+
+{{.Name}}
+{{- range .Issues}}
+  - {{template "issue" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .ByComponent}}
+
+BY COMPONENT
+{{- range .ByComponent}}
+
+{{.Name}}
+{{- range .Issues}}
+  - {{template "issue" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{define "issue" -}}
+{{.Key}} {{.Summary}}
+{{- with .Epic}} [{{.Key}} {{.Summary}}]{{end}}
+{{- with .URL}} {{.}}{{end}}
+{{- end}}
+`
@@ -0,0 +1,416 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// Status categories marking the start and end of work by default
+const (
+	categoryInProgress = "indeterminate"
+	categoryDone       = "done"
+)
+
+// allTypes labels the summary row covering every issue type
+const allTypes = "All"
+
+// cycleTimeFields are the issue fields the report needs
+var cycleTimeFields = []string{"summary", "issuetype", "status", "created"}
+
+// cycleTimeOptions holds the flags of report cycle-time
+type cycleTimeOptions struct {
+	JQL         string
+	Start       []string
+	End         []string
+	Max         int
+	Concurrency int
+	Issues      bool
+}
+
+// cycleTimeIssue is the lead and cycle time of one completed issue
+type cycleTimeIssue struct {
+	Key       string    `json:"key"`
+	Type      string    `json:"type"`
+	Summary   string    `json:"summary"`
+	Created   api.Time  `json:"created"`
+	Started   *api.Time `json:"started,omitempty"`
+	Completed api.Time  `json:"completed"`
+	LeadDays  float64   `json:"leadDays"`
+	CycleDays *float64  `json:"cycleDays,omitempty"`
+
+	lead, cycle time.Duration
+}
+
+// flowStats summarizes lead or cycle times. Durations are in days.
+type flowStats struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"averageDays"`
+	P50     float64 `json:"p50Days"`
+	P85     float64 `json:"p85Days"`
+	P95     float64 `json:"p95Days"`
+}
+
+// cycleTimeSummary holds the statistics for one issue type
+type cycleTimeSummary struct {
+	Type      string    `json:"type"`
+	Issues    int       `json:"issues"`
+	CycleTime flowStats `json:"cycleTime"`
+	LeadTime  flowStats `json:"leadTime"`
+}
+
+// cycleTimeReport is the JSON form of the report
+type cycleTimeReport struct {
+	Summary    []cycleTimeSummary `json:"summary"`
+	Issues     []cycleTimeIssue   `json:"issues"`
+	Incomplete []string           `json:"incomplete"`
+	// Truncated is set when --max was reached, so issues may be missing
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func newCycleTimeCmd(opts *root.Options) *cobra.Command {
+	var ct cycleTimeOptions
+
+	cmd := &cobra.Command{
+		Use:   "cycle-time",
+		Short: "Report lead and cycle time of completed issues",
+		Long: `Report lead and cycle time for the issues matching a JQL query.
+
+Lead time runs from an issue's creation until it was completed, cycle time from
+when work started until it was completed. Work starts on the first move into a
+--start status and completes on the last move into an --end status. Without
+them, the status categories decide: any "In Progress" category status starts
+work and any "Done" category status completes it.
+
+Issues that are not completed are left out. The table shows the average and
+50th, 85th and 95th percentiles per issue type; --issues lists each issue
+instead. JSON output includes both.`,
+		Example: `  # Cycle time of last quarter's work
+  jtk report cycle-time --jql "project = PROJ AND resolved >= -90d"
+
+  # Measure from a specific status to another
+  jtk report cycle-time --jql "project = PROJ AND resolved >= -30d" --start "In Progress" --end Done
+
+  # Per-issue times as a spreadsheet
+  jtk report cycle-time --jql "project = PROJ AND resolved >= -30d" --issues -o csv > cycle-time.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCycleTime(opts, ct)
+		},
+	}
+
+	cmd.Flags().StringVar(&ct.JQL, "jql", "", "JQL query selecting the issues (required)")
+	cmd.Flags().StringSliceVar(&ct.Start, "start", nil, "Statuses where work starts (default: In Progress category)")
+	cmd.Flags().StringSliceVar(&ct.End, "end", nil, "Statuses where work is complete (default: Done category)")
+	cmd.Flags().IntVarP(&ct.Max, "max", "m", 1000, "Maximum number of issues to analyze")
+	cmd.Flags().IntVar(&ct.Concurrency, "concurrency", 4, "Number of changelogs to fetch in parallel")
+	cmd.Flags().BoolVar(&ct.Issues, "issues", false, "List each issue instead of the summary")
+	_ = cmd.MarkFlagRequired("jql")
+
+	return cmd
+}
+
+func runCycleTime(opts *root.Options, ct cycleTimeOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	isStart, isEnd, err := flowMatchers(client, ct.Start, ct.End)
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.SearchAllWithChangelog(ct.JQL, ct.Max, cycleTimeFields)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		v.Info("No issues found")
+		return nil
+	}
+
+	changelogs, err := fetchChangelogs(opts, client, issues, ct.Concurrency)
+	if err != nil {
+		return err
+	}
+
+	report := cycleTimeReport{Issues: []cycleTimeIssue{}, Incomplete: []string{}}
+	if ct.Max > 0 && len(issues) >= ct.Max {
+		report.Truncated = true
+		v.Warning("Stopped at --max %d issues; the figures may be based on a partial sample", ct.Max)
+	}
+	for i, issue := range issues {
+		var created time.Time
+		if issue.Fields.Created != nil {
+			created = issue.Fields.Created.Time
+		}
+
+		times, ok := api.ComputeFlowTimes(created, api.StatusChanges(changelogs[i]), isStart, isEnd)
+		if !ok {
+			report.Incomplete = append(report.Incomplete, issue.Key)
+			continue
+		}
+		report.Issues = append(report.Issues, newCycleTimeIssue(issue, times))
+	}
+	report.Summary = summarizeCycleTimes(report.Issues)
+
+	if opts.DataOutput() {
+		return v.JSON(report)
+	}
+
+	if len(report.Issues) == 0 {
+		v.Info("None of the %d issue(s) are completed", len(issues))
+		return nil
+	}
+
+	table := v.Format == view.FormatTable
+	if ct.Issues {
+		headers, rows := cycleTimeIssueTable(v, report.Issues, table)
+		if err := v.Table(headers, rows); err != nil {
+			return err
+		}
+	} else {
+		headers, rows := cycleTimeSummaryTable(report.Summary, table)
+		if err := v.Table(headers, rows); err != nil {
+			return err
+		}
+	}
+
+	if table && len(report.Incomplete) > 0 {
+		v.Info("\n%d issue(s) not completed were left out", len(report.Incomplete))
+	}
+	return nil
+}
+
+// flowMatchers builds the start and end conditions. Named statuses match by
+// name; without names, statuses match by category, which needs the status list.
+func flowMatchers(client *api.Client, start, end []string) (isStart, isEnd func(api.StatusChange) bool, err error) {
+	var categories api.StatusCategories
+	if len(start) == 0 || len(end) == 0 {
+		statuses, err := client.GetStatuses()
+		if err != nil {
+			return nil, nil, err
+		}
+		categories = api.NewStatusCategories(statuses)
+	}
+
+	match := func(names []string, category string) func(api.StatusChange) bool {
+		if len(names) > 0 {
+			return func(c api.StatusChange) bool {
+				for _, name := range names {
+					if strings.EqualFold(strings.TrimSpace(name), c.To) {
+						return true
+					}
+				}
+				return false
+			}
+		}
+		return func(c api.StatusChange) bool {
+			return categories.Of(c.ToID, c.To) == category
+		}
+	}
+
+	return match(start, categoryInProgress), match(end, categoryDone), nil
+}
+
+// fetchChangelogs returns the full changelog of each issue. Changelogs that
+// search embedded in full are used as they are; the rest are fetched
+// concurrently.
+func fetchChangelogs(opts *root.Options, client *api.Client, issues []api.Issue, concurrency int) ([][]api.ChangelogEntry, error) {
+	missing := 0
+	for i := range issues {
+		if !issues[i].HasFullChangelog() {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return client.IssueChangelogs(issues, concurrency, nil)
+	}
+
+	bar := progress.NewCount(opts.Stderr, fmt.Sprintf("Fetching %d changelog(s)", missing), missing)
+	changelogs, err := client.IssueChangelogs(issues, concurrency, func() { bar.Add(1) })
+	bar.Finish()
+	return changelogs, err
+}
+
+func newCycleTimeIssue(issue api.Issue, times api.FlowTimes) cycleTimeIssue {
+	result := cycleTimeIssue{
+		Key:       issue.Key,
+		Summary:   issue.Fields.Summary,
+		Created:   api.Time{Time: times.Created},
+		Completed: api.Time{Time: times.Completed},
+		LeadDays:  days(times.Lead),
+		lead:      times.Lead,
+		cycle:     -1,
+	}
+	if issue.Fields.IssueType != nil {
+		result.Type = issue.Fields.IssueType.Name
+	}
+	if !times.Started.IsZero() {
+		cycle := days(times.Cycle)
+		result.Started = &api.Time{Time: times.Started}
+		result.CycleDays = &cycle
+		result.cycle = times.Cycle
+	}
+	return result
+}
+
+// summarizeCycleTimes computes statistics per issue type, sorted by type,
+// followed by a row for all issues
+func summarizeCycleTimes(issues []cycleTimeIssue) []cycleTimeSummary {
+	byType := map[string][]cycleTimeIssue{}
+	var types []string
+	for _, issue := range issues {
+		if _, ok := byType[issue.Type]; !ok {
+			types = append(types, issue.Type)
+		}
+		byType[issue.Type] = append(byType[issue.Type], issue)
+	}
+	sort.Strings(types)
+
+	summary := make([]cycleTimeSummary, 0, len(types)+1)
+	for _, t := range types {
+		summary = append(summary, summarize(t, byType[t]))
+	}
+	if len(types) > 1 {
+		summary = append(summary, summarize(allTypes, issues))
+	}
+	return summary
+}
+
+func summarize(issueType string, issues []cycleTimeIssue) cycleTimeSummary {
+	var lead, cycle []time.Duration
+	for _, issue := range issues {
+		lead = append(lead, issue.lead)
+		if issue.cycle >= 0 {
+			cycle = append(cycle, issue.cycle)
+		}
+	}
+	return cycleTimeSummary{
+		Type:      issueType,
+		Issues:    len(issues),
+		CycleTime: newFlowStats(cycle),
+		LeadTime:  newFlowStats(lead),
+	}
+}
+
+func newFlowStats(durations []time.Duration) flowStats {
+	if len(durations) == 0 {
+		return flowStats{}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return flowStats{
+		Count:   len(sorted),
+		Average: days(total / time.Duration(len(sorted))),
+		P50:     days(percentile(sorted, 50)),
+		P85:     days(percentile(sorted, 85)),
+		P95:     days(percentile(sorted, 95)),
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations, interpolating
+// linearly between the closest ranks
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	frac := rank - float64(lower)
+	return sorted[lower] + time.Duration(frac*float64(sorted[upper]-sorted[lower]))
+}
+
+// days converts a duration to days, rounded to two decimals
+func days(d time.Duration) float64 {
+	return math.Round(d.Hours()/24*100) / 100
+}
+
+// formatDays renders a duration given in days: compactly for terminal
+// tables, as a plain number for exports
+func formatDays(value float64, count int, table bool) string {
+	if count == 0 {
+		return ""
+	}
+	if table {
+		return view.Duration(time.Duration(value * 24 * float64(time.Hour)))
+	}
+	return view.Number(value)
+}
+
+func cycleTimeSummaryTable(summary []cycleTimeSummary, table bool) ([]string, [][]string) {
+	headers := []string{"TYPE", "ISSUES", "CYCLE AVG", "CYCLE P50", "CYCLE P85", "CYCLE P95", "LEAD AVG", "LEAD P50", "LEAD P85", "LEAD P95"}
+	if !table {
+		for i := 2; i < len(headers); i++ {
+			headers[i] += " (DAYS)"
+		}
+	}
+
+	rows := make([][]string, len(summary))
+	for i, s := range summary {
+		c, l := s.CycleTime, s.LeadTime
+		rows[i] = []string{
+			s.Type,
+			strconv.Itoa(s.Issues),
+			formatDays(c.Average, c.Count, table),
+			formatDays(c.P50, c.Count, table),
+			formatDays(c.P85, c.Count, table),
+			formatDays(c.P95, c.Count, table),
+			formatDays(l.Average, l.Count, table),
+			formatDays(l.P50, l.Count, table),
+			formatDays(l.P85, l.Count, table),
+			formatDays(l.P95, l.Count, table),
+		}
+	}
+	return headers, rows
+}
+
+func cycleTimeIssueTable(v *view.View, issues []cycleTimeIssue, table bool) ([]string, [][]string) {
+	headers := []string{"KEY", "TYPE", "SUMMARY", "STARTED", "COMPLETED", "CYCLE", "LEAD"}
+	if !table {
+		headers[5], headers[6] = "CYCLE (DAYS)", "LEAD (DAYS)"
+	}
+
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		started, cycle := "", ""
+		if issue.Started != nil {
+			started = v.Time(issue.Started.Time)
+			cycle = formatDays(*issue.CycleDays, 1, table)
+		}
+		if table && started == "" {
+			started, cycle = "-", "-"
+		}
+		rows[i] = []string{
+			issue.Key,
+			issue.Type,
+			issue.Summary,
+			started,
+			v.Time(issue.Completed.Time),
+			cycle,
+			formatDays(issue.LeadDays, 1, table),
+		}
+	}
+	return headers, rows
+}
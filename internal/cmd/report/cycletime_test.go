@@ -0,0 +1,145 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+// statusChange renders a changelog entry moving an issue between statuses
+func statusChange(id, created, from, fromID, to, toID string) string {
+	return `{"id":"` + id + `","created":"` + created + `","items":[{"field":"status","fieldId":"status",` +
+		`"from":"` + fromID + `","fromString":"` + from + `","to":"` + toID + `","toString":"` + to + `"}]}`
+}
+
+func newCycleTimeTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/status":
+			w.Write([]byte(`[
+				{"id":"1","name":"To Do","statusCategory":{"key":"new"}},
+				{"id":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}},
+				{"id":"4","name":"In Review","statusCategory":{"key":"indeterminate"}},
+				{"id":"10","name":"Done","statusCategory":{"key":"done"}}
+			]`))
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "changelog", req.Expand)
+
+			w.Write([]byte(`{"total":4,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"Story one","issuetype":{"name":"Story"},"created":"2024-03-01T00:00:00.000+0000"},
+				 "changelog":{"total":2,"histories":[
+					` + statusChange("2", "2024-03-05T00:00:00.000+0000", "In Progress", "3", "Done", "10") + `,
+					` + statusChange("1", "2024-03-02T00:00:00.000+0000", "To Do", "1", "In Progress", "3") + `
+				 ]}},
+				{"key":"PROJ-2","fields":{"summary":"Story two","issuetype":{"name":"Story"},"created":"2024-03-01T00:00:00.000+0000"},
+				 "changelog":{"total":150,"histories":[]}},
+				{"key":"PROJ-3","fields":{"summary":"Bug","issuetype":{"name":"Bug"},"created":"2024-03-01T00:00:00.000+0000"},
+				 "changelog":{"total":1,"histories":[
+					` + statusChange("5", "2024-03-02T00:00:00.000+0000", "To Do", "1", "Done", "10") + `
+				 ]}},
+				{"key":"PROJ-4","fields":{"summary":"Open","issuetype":{"name":"Bug"},"created":"2024-03-01T00:00:00.000+0000"},
+				 "changelog":{"total":0,"histories":[]}}
+			]}`))
+		case "/rest/api/3/issue/PROJ-2/changelog":
+			w.Write([]byte(`{"total":2,"isLast":true,"values":[
+				` + statusChange("3", "2024-03-03T00:00:00.000+0000", "To Do", "1", "In Review", "4") + `,
+				` + statusChange("4", "2024-03-04T00:00:00.000+0000", "In Review", "4", "Done", "10") + `
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunCycleTime_JSON(t *testing.T) {
+	server := newCycleTimeTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runCycleTime(opts, cycleTimeOptions{JQL: "project = PROJ", Concurrency: 2}))
+
+	var report cycleTimeReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+
+	assert.Equal(t, []string{"PROJ-4"}, report.Incomplete)
+	require.Len(t, report.Issues, 3)
+
+	// Started on the category, not the status name; changelog fetched in full
+	assert.Equal(t, "PROJ-2", report.Issues[1].Key)
+	require.NotNil(t, report.Issues[1].CycleDays)
+	assert.Equal(t, 1.0, *report.Issues[1].CycleDays)
+	assert.Equal(t, 3.0, report.Issues[1].LeadDays)
+
+	// Never started, so only lead time
+	assert.Nil(t, report.Issues[2].CycleDays)
+	assert.Equal(t, 1.0, report.Issues[2].LeadDays)
+
+	require.Len(t, report.Summary, 3)
+	assert.Equal(t, cycleTimeSummary{
+		Type:      "Story",
+		Issues:    2,
+		CycleTime: flowStats{Count: 2, Average: 2, P50: 2, P85: 2.7, P95: 2.9},
+		LeadTime:  flowStats{Count: 2, Average: 3.5, P50: 3.5, P85: 3.85, P95: 3.95},
+	}, report.Summary[1])
+	assert.Equal(t, "Bug", report.Summary[0].Type)
+	assert.Equal(t, 0, report.Summary[0].CycleTime.Count)
+	assert.Equal(t, allTypes, report.Summary[2].Type)
+	assert.Equal(t, 3, report.Summary[2].Issues)
+}
+
+func TestRunCycleTime_Truncated(t *testing.T) {
+	server := newCycleTimeTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runCycleTime(opts, cycleTimeOptions{JQL: "project = PROJ", Max: 4, Concurrency: 2}))
+
+	var report cycleTimeReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.True(t, report.Truncated)
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Stopped at --max 4 issues")
+}
+
+func TestRunCycleTime_NamedStatuses(t *testing.T) {
+	server := newCycleTimeTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "csv"
+	opts.TZ = "UTC"
+
+	ct := cycleTimeOptions{JQL: "project = PROJ", Start: []string{"in review"}, End: []string{"Done"}, Issues: true}
+	require.NoError(t, runCycleTime(opts, ct))
+	assert.Equal(t, "KEY,TYPE,SUMMARY,STARTED,COMPLETED,CYCLE (DAYS),LEAD (DAYS)\n"+
+		"PROJ-1,Story,Story one,,2024-03-05T00:00:00Z,,4\n"+
+		"PROJ-2,Story,Story two,2024-03-03T00:00:00Z,2024-03-04T00:00:00Z,1,3\n"+
+		"PROJ-3,Bug,Bug,,2024-03-02T00:00:00Z,,1\n", stdout.String())
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(1), percentile(sorted, 0))
+	assert.Equal(t, time.Duration(5), percentile(sorted, 50)) // 5.5 truncated
+	assert.Equal(t, time.Duration(10), percentile(sorted, 100))
+	assert.Equal(t, time.Duration(7), percentile([]time.Duration{7}, 95))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+)
+
+// Register registers the report commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:     "report",
+		Aliases: []string{"reports"},
+		Short:   "Analyze issues",
+		Long:    "Commands for reporting on how work flows through Jira.",
+	}
+
+	cmd.AddCommand(newCycleTimeCmd(opts))
+
+	parent.AddCommand(cmd)
+}
@@ -1,28 +1,37 @@
 package root
 
 import (
+	"fmt"
 	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cache"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/config"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/version"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
 )
 
 // Options contains global options for commands
 type Options struct {
-	Output  string
-	NoColor bool
-	Verbose bool
-	Stdin   io.Reader
-	Stdout  io.Writer
-	Stderr  io.Writer
+	Output     string
+	Template   string
+	TZ         string
+	DateFormat string
+	NoColor    bool
+	Verbose    bool
+	NoCache    bool
+	Stdin      io.Reader
+	Stdout     io.Writer
+	Stderr     io.Writer
 
 	// testClient is used for testing; if set, APIClient() returns this instead
 	testClient *api.Client
+	// storePath is used for testing; if set, Store() opens this file instead
+	storePath string
 }
 
 // View returns a configured View instance
@@ -30,20 +39,71 @@ func (o *Options) View() *view.View {
 	v := view.New(o.Output, o.NoColor)
 	v.Out = o.Stdout
 	v.Err = o.Stderr
+	if v.Format == view.FormatTable {
+		v.MaxWidth = view.TerminalWidth(o.Stdout)
+	}
+	// The zone is validated before commands run, so an error cannot occur here
+	v.Location, _ = view.ParseLocation(o.TZ)
+	v.DateFormat = o.DateFormat
+	if v.Format == view.FormatTemplate {
+		if o.Template != "" {
+			v.Template = o.Template
+		}
+		v.TemplateFuncs = map[string]interface{}{"field": o.templateFieldFunc()}
+	}
 	return v
 }
 
+// DataOutput reports whether the output format renders a command's data
+// (json, ndjson, template, jsonpath) rather than table rows. Commands pass
+// their data to View.JSON when it does.
+func (o *Options) DataOutput() bool {
+	format, _ := view.ParseFormat(o.Output)
+	return format.IsData()
+}
+
 // APIClient creates a new API client from config
 func (o *Options) APIClient() (*api.Client, error) {
 	if o.testClient != nil {
 		return o.testClient, nil
 	}
-	return api.New(api.ClientConfig{
+	cfg := api.ClientConfig{
 		URL:      config.GetURL(),
 		Email:    config.GetEmail(),
 		APIToken: config.GetAPIToken(),
 		Verbose:  o.Verbose,
-	})
+	}
+
+	// The metadata cache is best-effort; without a cache dir requests just go to the server
+	if !o.NoCache && cfg.URL != "" {
+		if c, err := cache.New(cfg.URL, config.GetCacheTTL()); err == nil {
+			cfg.Cache = c
+		}
+	}
+
+	return api.New(cfg)
+}
+
+// Store opens the local issue store of the configured instance. The caller
+// must close it.
+func (o *Options) Store() (*store.Store, error) {
+	path := o.storePath
+	if path == "" {
+		url := config.GetURL()
+		if url == "" {
+			return nil, fmt.Errorf("no Jira URL configured; run 'jtk init' first")
+		}
+		var err error
+		if path, err = store.DefaultPath(url); err != nil {
+			return nil, err
+		}
+	}
+	return store.Open(path)
+}
+
+// SetStorePath sets the local store file (for testing only)
+func (o *Options) SetStorePath(path string) {
+	o.storePath = path
 }
 
 // SetAPIClient sets a test client (for testing only)
@@ -64,17 +124,23 @@ func NewCmd() (*cobra.Command, *Options) {
 		Short:   "A CLI for managing Jira tickets",
 		Long:    "jtk is a command-line interface for managing Jira Cloud tickets.",
 		Version: version.Info(),
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			// Setup is done in flag binding
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Other setup is done in flag binding
+			_, err := view.ParseLocation(opts.TZ)
+			return err
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
 
 	// Global flags - bound to opts struct
-	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", "table", "Output format: table, json, plain")
+	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", "table", "Output format: table, json, plain, csv, tsv, markdown, ndjson, template, jsonpath=<expr>")
+	cmd.PersistentFlags().StringVar(&opts.Template, "template", "", "Go template for -o template, e.g. '{{.Key}} {{.Fields.Summary}}' (or use -o template=<tmpl>)")
+	cmd.PersistentFlags().StringVar(&opts.TZ, "tz", "", "Time zone for timestamps, e.g. UTC or Europe/Berlin (default: local)")
+	cmd.PersistentFlags().StringVar(&opts.DateFormat, "date-format", "", "Date format: relative, iso or a Go layout such as 2006-01-02 (default: relative in tables, iso otherwise)")
 	cmd.PersistentFlags().BoolVar(&opts.NoColor, "no-color", false, "Disable colored output")
 	cmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output")
+	cmd.PersistentFlags().BoolVar(&opts.NoCache, "no-cache", false, "Bypass the local metadata cache")
 
 	return cmd, opts
 }
@@ -89,15 +155,23 @@ func RegisterCommands(root *cobra.Command, opts *Options, registrars ...func(*co
 // GetOptions extracts Options from a root command
 func GetOptions(cmd *cobra.Command) *Options {
 	output, _ := cmd.Root().PersistentFlags().GetString("output")
+	tmpl, _ := cmd.Root().PersistentFlags().GetString("template")
+	tz, _ := cmd.Root().PersistentFlags().GetString("tz")
+	dateFormat, _ := cmd.Root().PersistentFlags().GetString("date-format")
 	noColor, _ := cmd.Root().PersistentFlags().GetBool("no-color")
 	verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
+	noCache, _ := cmd.Root().PersistentFlags().GetBool("no-cache")
 
 	return &Options{
-		Output:  output,
-		NoColor: noColor,
-		Verbose: verbose,
-		Stdin:   os.Stdin,
-		Stdout:  os.Stdout,
-		Stderr:  os.Stderr,
+		Output:     output,
+		Template:   tmpl,
+		TZ:         tz,
+		DateFormat: dateFormat,
+		NoColor:    noColor,
+		Verbose:    verbose,
+		NoCache:    noCache,
+		Stdin:      os.Stdin,
+		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
 	}
 }
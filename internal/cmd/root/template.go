@@ -0,0 +1,53 @@
+package root
+
+import (
+	"fmt"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+// templateFieldFunc returns the "field" template helper, which reads a field of
+// an issue by name or ID: {{field "Story Points" .}}. Field metadata is only
+// fetched the first time a name needs resolving.
+func (o *Options) templateFieldFunc() func(string, interface{}) (string, error) {
+	var fields []api.Field
+
+	return func(name string, data interface{}) (string, error) {
+		var issueFields *api.IssueFields
+		switch d := data.(type) {
+		case api.Issue:
+			issueFields = &d.Fields
+		case *api.Issue:
+			issueFields = &d.Fields
+		case api.IssueFields:
+			issueFields = &d
+		case *api.IssueFields:
+			issueFields = d
+		default:
+			return "", fmt.Errorf("field %q: %T is not an issue", name, data)
+		}
+		if issueFields == nil {
+			return "", nil
+		}
+
+		// IDs of fields present on the issue need no lookup
+		if v := issueFields.Value(name); v != nil {
+			return api.FieldValueText(v), nil
+		}
+
+		if fields == nil {
+			client, err := o.APIClient()
+			if err != nil {
+				return "", err
+			}
+			if fields, err = client.GetFields(); err != nil {
+				return "", err
+			}
+		}
+		id, err := api.ResolveFieldID(fields, name)
+		if err != nil {
+			return "", err
+		}
+		return api.FieldValueText(issueFields.Value(id)), nil
+	}
+}
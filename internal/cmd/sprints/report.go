@@ -0,0 +1,365 @@
+package sprints
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
+)
+
+// burndownWidth is the width of the bars in the burndown chart
+const burndownWidth = 40
+
+// reportOptions holds the flags of sprints report
+type reportOptions struct {
+	PointsField string
+	Max         int
+	Concurrency int
+}
+
+// sprintReport is the JSON form of a sprint report
+type sprintReport struct {
+	Sprint       api.Sprint      `json:"sprint"`
+	PointsField  string          `json:"pointsField,omitempty"`
+	Committed    scopeTotal      `json:"committed"`
+	Added        scopeTotal      `json:"added"`
+	Removed      scopeTotal      `json:"removed"`
+	Completed    scopeTotal      `json:"completed"`
+	NotCompleted scopeTotal      `json:"notCompleted"`
+	Issues       []sprintIssue   `json:"issues"`
+	Burndown     []burndownPoint `json:"burndown"`
+	// Truncated is set when --max was reached, so issues may be missing
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// scopeTotal counts issues and their story points
+type scopeTotal struct {
+	Issues int     `json:"issues"`
+	Points float64 `json:"points"`
+}
+
+func (t *scopeTotal) add(points float64) {
+	t.Issues++
+	t.Points += points
+}
+
+// sprintIssue is an issue that was part of the sprint at some point
+type sprintIssue struct {
+	Key       string  `json:"key"`
+	Summary   string  `json:"summary"`
+	Status    string  `json:"status"`
+	Points    float64 `json:"points"`
+	Committed bool    `json:"committed"`
+	Added     bool    `json:"added"`
+	Removed   bool    `json:"removed"`
+	Completed bool    `json:"completed"`
+}
+
+// burndownPoint is the remaining work at the end of a sprint day
+type burndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining float64 `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+func newReportCmd(opts *root.Options) *cobra.Command {
+	var report reportOptions
+
+	cmd := &cobra.Command{
+		Use:   "report <sprint-id>",
+		Short: "Summarize a sprint's commitment, scope change and burndown",
+		Long: `Report on a sprint: the issues and story points committed at its start,
+those added or removed afterwards, what was completed, and a day-by-day
+burndown of the remaining work.
+
+The story points field is found by name; use --points-field when your site
+names it differently. Without story points, issues are counted instead.
+
+Sprint membership and status are read from each issue's changelog. Removed
+issues are found among the issues of the sprint's projects updated since it
+started; raise --max when the report says it stopped early.`,
+		Example: `  # Report on a sprint
+  jtk sprints report 456
+
+  # Use a custom estimate field
+  jtk sprints report 456 --points-field "Estimate"
+
+  # Feed a dashboard
+  jtk sprints report 456 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sprintID int
+			if _, err := fmt.Sscanf(args[0], "%d", &sprintID); err != nil {
+				return fmt.Errorf("invalid sprint ID: %s", args[0])
+			}
+			return runReport(opts, sprintID, report)
+		},
+	}
+
+	cmd.Flags().StringVar(&report.PointsField, "points-field", "", "Story points field name or ID (default: found automatically)")
+	cmd.Flags().IntVarP(&report.Max, "max", "m", 500, "Maximum number of issues to analyze")
+	cmd.Flags().IntVar(&report.Concurrency, "concurrency", 4, "Number of changelogs to fetch in parallel")
+
+	return cmd
+}
+
+func runReport(opts *root.Options, sprintID int, ro reportOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := client.GetSprint(sprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.StartDate == nil {
+		return fmt.Errorf("sprint %d has not started", sprintID)
+	}
+
+	points, err := client.StoryPointsField(ro.PointsField)
+	if err != nil {
+		return err
+	}
+	if points == nil && !opts.DataOutput() {
+		v.Warning("No story points field found; counting issues instead (use --points-field to name it)")
+	}
+	pointsID := ""
+	if points != nil {
+		pointsID = points.ID
+	}
+
+	statuses, err := client.GetStatuses()
+	if err != nil {
+		return err
+	}
+	categories := api.NewStatusCategories(statuses)
+
+	histories, truncated, err := client.SprintHistories(sprint, pointsID, ro.Max, ro.Concurrency)
+	if err != nil {
+		return err
+	}
+	if truncated {
+		v.Warning("Stopped at --max %d issues; the report may be incomplete", ro.Max)
+	}
+
+	end := time.Now()
+	if sprint.CompleteDate != nil {
+		end = *sprint.CompleteDate
+	}
+
+	report := buildSprintReport(sprint, histories, categories, end, v.Location)
+	report.Truncated = truncated
+	if points != nil {
+		report.PointsField = points.Name
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(report)
+	}
+	if v.Format != view.FormatTable {
+		return v.Table(sprintIssueTable(report.Issues))
+	}
+	return renderSprintReport(v, report, points != nil)
+}
+
+// buildSprintReport evaluates every issue at the sprint's start and at end,
+// which is when the sprint was completed or, while it runs, now
+func buildSprintReport(sprint *api.Sprint, histories []*api.SprintHistory, categories api.StatusCategories, end time.Time, loc *time.Location) sprintReport {
+	start := *sprint.StartDate
+	report := sprintReport{Sprint: *sprint, Issues: []sprintIssue{}}
+
+	for _, h := range histories {
+		issue := sprintIssue{
+			Key:       h.Issue.Key,
+			Summary:   h.Issue.Fields.Summary,
+			Committed: h.MemberAt(start),
+		}
+		if s := h.Issue.Fields.Status; s != nil {
+			issue.Status = s.Name
+		}
+		issue.Added = !issue.Committed && h.AddedBetween(start, end)
+		if !issue.Committed && !issue.Added {
+			continue
+		}
+		issue.Removed = !h.MemberAt(end)
+		issue.Completed = !issue.Removed && h.DoneAt(end, categories)
+		issue.Points = h.PointsAt(end)
+
+		if issue.Committed {
+			report.Committed.add(h.PointsAt(start))
+		} else {
+			report.Added.add(issue.Points)
+		}
+		switch {
+		case issue.Removed:
+			report.Removed.add(issue.Points)
+		case issue.Completed:
+			report.Completed.add(issue.Points)
+		default:
+			report.NotCompleted.add(issue.Points)
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	report.Burndown = burndown(sprint, histories, categories, end, report.Committed.Points, loc)
+	return report
+}
+
+// burndown computes the work remaining at the start of the sprint and at the
+// end of each of its days in loc, up to end. The ideal line falls evenly from
+// the committed work to zero at the sprint's end date.
+func burndown(sprint *api.Sprint, histories []*api.SprintHistory, categories api.StatusCategories, end time.Time, committed float64, loc *time.Location) []burndownPoint {
+	start := sprint.StartDate.In(loc)
+	last, length := end, end.Sub(start)
+	if sprint.EndDate != nil {
+		length = sprint.EndDate.Sub(start)
+		if sprint.EndDate.Before(last) {
+			last = *sprint.EndDate
+		}
+	}
+
+	point := func(t time.Time) burndownPoint {
+		var remaining float64
+		for _, h := range histories {
+			if h.MemberAt(t) && !h.DoneAt(t, categories) {
+				remaining += h.PointsAt(t)
+			}
+		}
+		ideal := 0.0
+		if length > 0 {
+			ideal = math.Max(0, committed*(1-float64(t.Sub(start))/float64(length)))
+		}
+		return burndownPoint{Date: t.In(loc).Format("2006-01-02"), Remaining: remaining, Ideal: math.Round(ideal*10) / 10}
+	}
+
+	points := []burndownPoint{point(start)}
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for {
+		day = day.AddDate(0, 0, 1)
+		t := day.Add(-time.Nanosecond)
+		if !t.Before(last) {
+			points = append(points, point(last))
+			break
+		}
+		points = append(points, point(t))
+	}
+	return points
+}
+
+func renderSprintReport(v *view.View, report sprintReport, hasPoints bool) error {
+	s := report.Sprint
+	v.Println("%s (%s)", s.Name, s.State)
+	if s.EndDate != nil {
+		v.Println("%s to %s", s.StartDate.Format("2006-01-02"), s.EndDate.Format("2006-01-02"))
+	}
+	if s.Goal != "" {
+		v.Println("Goal: %s", s.Goal)
+	}
+	v.Println("")
+
+	headers := []string{"", "ISSUES"}
+	if hasPoints {
+		headers = append(headers, "POINTS")
+	}
+	var rows [][]string
+	for _, row := range []struct {
+		label string
+		total scopeTotal
+	}{
+		{"Committed", report.Committed},
+		{"Added", report.Added},
+		{"Removed", report.Removed},
+		{"Completed", report.Completed},
+		{"Not completed", report.NotCompleted},
+	} {
+		cells := []string{row.label, strconv.Itoa(row.total.Issues)}
+		if hasPoints {
+			cells = append(cells, view.Number(row.total.Points))
+		}
+		rows = append(rows, cells)
+	}
+	if err := v.Table(headers, rows); err != nil {
+		return err
+	}
+
+	var added, removed []string
+	for _, issue := range report.Issues {
+		if issue.Added {
+			added = append(added, issue.Key)
+		}
+		if issue.Removed {
+			removed = append(removed, issue.Key)
+		}
+	}
+	if len(added) > 0 {
+		v.Println("\nAdded after start: %s", strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		v.Println("Removed after start: %s", strings.Join(removed, ", "))
+	}
+
+	unit := "issues"
+	if hasPoints {
+		unit = "points"
+	}
+	v.Println("\nBurndown (%s remaining, | marks the ideal)", unit)
+	for _, line := range burndownChart(report.Burndown) {
+		v.Println("%s", line)
+	}
+	return nil
+}
+
+// burndownChart draws the burndown as one bar per day, with the ideal
+// remaining work marked on each
+func burndownChart(points []burndownPoint) []string {
+	max := 0.0
+	for _, p := range points {
+		max = math.Max(max, math.Max(p.Remaining, p.Ideal))
+	}
+
+	scale := func(value float64) int {
+		if max == 0 {
+			return 0
+		}
+		return int(math.Round(value / max * burndownWidth))
+	}
+
+	lines := make([]string, len(points))
+	for i, p := range points {
+		bar := []byte(strings.Repeat("#", scale(p.Remaining)) + strings.Repeat(" ", burndownWidth+1-scale(p.Remaining)))
+		bar[scale(p.Ideal)] = '|'
+		lines[i] = fmt.Sprintf("%s %6s  %s", p.Date, view.Number(p.Remaining), strings.TrimRight(string(bar), " "))
+	}
+	return lines
+}
+
+func sprintIssueTable(issues []sprintIssue) ([]string, [][]string) {
+	headers := []string{"KEY", "SUMMARY", "STATUS", "POINTS", "SCOPE", "REMOVED", "COMPLETED"}
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		scope := "committed"
+		if issue.Added {
+			scope = "added"
+		}
+		rows[i] = []string{
+			issue.Key,
+			issue.Summary,
+			issue.Status,
+			view.Number(issue.Points),
+			scope,
+			strconv.FormatBool(issue.Removed),
+			strconv.FormatBool(issue.Completed),
+		}
+	}
+	return headers, rows
+}
@@ -0,0 +1,170 @@
+package sprints
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+// change renders a changelog entry changing one field
+func change(created, field, fieldID, from, fromString, to, toString string) string {
+	return `{"id":"1","created":"` + created + `","items":[{"field":"` + field + `","fieldId":"` + fieldID +
+		`","from":"` + from + `","fromString":"` + fromString + `","to":"` + to + `","toString":"` + toString + `"}]}`
+}
+
+func issueJSON(key, created, statusID, status string, points float64, changes ...string) string {
+	data, _ := json.Marshal(points)
+	return `{"key":"` + key + `","fields":{"summary":"` + key + ` summary","project":{"key":"PROJ"},` +
+		`"created":"` + created + `","status":{"id":"` + statusID + `","name":"` + status + `"},"customfield_10016":` + string(data) + `},` +
+		`"changelog":{"total":` + string(rune('0'+len(changes))) + `,"histories":[` + strings.Join(changes, ",") + `]}}`
+}
+
+func newReportTestServer(t *testing.T) *httptest.Server {
+	const before = "2024-03-01T00:00:00.000+0000"
+	joined := change("2024-03-01T10:00:00.000+0000", "Sprint", "customfield_10020", "", "", "7", "Sprint 7")
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/sprint/7":
+			w.Write([]byte(`{"id":7,"name":"Sprint 7","state":"closed",
+				"startDate":"2024-03-04T09:00:00.000Z","endDate":"2024-03-08T17:00:00.000Z","completeDate":"2024-03-08T17:00:00.000Z"}`))
+		case "/rest/api/3/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case "/rest/api/3/status":
+			w.Write([]byte(`[
+				{"id":"1","name":"To Do","statusCategory":{"key":"new"}},
+				{"id":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}},
+				{"id":"10","name":"Done","statusCategory":{"key":"done"}}
+			]`))
+		case "/rest/agile/1.0/sprint/7/issue":
+			assert.Equal(t, "changelog", r.URL.Query().Get("expand"))
+			assert.Contains(t, r.URL.Query().Get("fields"), "customfield_10016")
+			issues := []string{
+				issueJSON("PROJ-1", before, "10", "Done", 5, joined,
+					change("2024-03-06T12:00:00.000+0000", "status", "status", "1", "To Do", "10", "Done")),
+				issueJSON("PROJ-2", before, "3", "In Progress", 3, joined,
+					change("2024-03-05T10:00:00.000+0000", "Story Points", "customfield_10016", "", "2", "", "3")),
+				// Created straight into the running sprint
+				issueJSON("PROJ-3", "2024-03-05T10:00:00.000+0000", "10", "Done", 1,
+					change("2024-03-07T10:00:00.000+0000", "status", "status", "1", "To Do", "10", "Done")),
+			}
+			w.Write([]byte(`{"total":3,"issues":[` + strings.Join(issues, ",") + `]}`))
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Contains(t, req.Fields, "customfield_10016")
+			if strings.Contains(req.JQL, "WAS") {
+				// Sites reject WAS on the sprint field
+				assert.Equal(t, `project in ("PROJ") AND sprint WAS 7 AND (sprint is EMPTY OR sprint != 7)`, req.JQL)
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorMessages":["History searches do not support the 'Sprint' field."]}`))
+				return
+			}
+			assert.Equal(t, `project in ("PROJ") AND updated >= "2024-03-03" AND updated <= "2024-03-15" AND (sprint is EMPTY OR sprint != 7)`, req.JQL)
+
+			issues := []string{
+				issueJSON("PROJ-4", before, "1", "To Do", 8, joined,
+					change("2024-03-06T09:00:00.000+0000", "Sprint", "customfield_10020", "7", "Sprint 7", "", "")),
+				issueJSON("PROJ-5", before, "1", "To Do", 2),
+			}
+			w.Write([]byte(`{"total":2,"issues":[` + strings.Join(issues, ",") + `]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunReport_JSON(t *testing.T) {
+	server := newReportTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runReport(opts, 7, reportOptions{Max: 100}))
+
+	var report sprintReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+
+	assert.Equal(t, "Story Points", report.PointsField)
+	assert.Equal(t, scopeTotal{Issues: 3, Points: 15}, report.Committed)
+	assert.Equal(t, scopeTotal{Issues: 1, Points: 1}, report.Added)
+	assert.Equal(t, scopeTotal{Issues: 1, Points: 8}, report.Removed)
+	assert.Equal(t, scopeTotal{Issues: 2, Points: 6}, report.Completed)
+	assert.Equal(t, scopeTotal{Issues: 1, Points: 3}, report.NotCompleted)
+
+	var keys []string
+	for _, issue := range report.Issues {
+		keys = append(keys, issue.Key)
+	}
+	assert.Equal(t, []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4"}, keys)
+	assert.True(t, report.Issues[2].Added)
+	assert.True(t, report.Issues[3].Removed)
+
+	var remaining []float64
+	var dates []string
+	for _, p := range report.Burndown {
+		remaining = append(remaining, p.Remaining)
+		dates = append(dates, p.Date)
+	}
+	assert.Equal(t, []float64{15, 15, 17, 4, 3, 3}, remaining)
+	assert.Equal(t, []string{"2024-03-04", "2024-03-04", "2024-03-05", "2024-03-06", "2024-03-07", "2024-03-08"}, dates)
+	assert.Equal(t, 15.0, report.Burndown[0].Ideal)
+	assert.Equal(t, 0.0, report.Burndown[5].Ideal)
+	assert.False(t, report.Truncated)
+}
+
+func TestRunReport_Truncated(t *testing.T) {
+	server := newReportTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.Output = "json"
+
+	require.NoError(t, runReport(opts, 7, reportOptions{Max: 2, Concurrency: 2}))
+
+	var report sprintReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.True(t, report.Truncated)
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Stopped at --max 2 issues")
+}
+
+func TestRunReport_Table(t *testing.T) {
+	server := newReportTestServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	require.NoError(t, runReport(cmdtest.Options(server, &stdout), 7, reportOptions{Max: 100}))
+
+	out := stdout.String()
+	assert.Contains(t, out, "Sprint 7 (closed)\n2024-03-04 to 2024-03-08\n")
+	assert.Contains(t, out, "Added after start: PROJ-3\n")
+	assert.Contains(t, out, "Removed after start: PROJ-4\n")
+	assert.Contains(t, out, "2024-03-05     17  "+strings.Repeat("#", 22)+"|"+strings.Repeat("#", 17)+"\n")
+	assert.Contains(t, out, "2024-03-08      3  |"+strings.Repeat("#", 6)+"\n")
+}
+
+func TestBurndownChart(t *testing.T) {
+	lines := burndownChart([]burndownPoint{
+		{Date: "2024-03-04", Remaining: 10, Ideal: 10},
+		{Date: "2024-03-05", Remaining: 10, Ideal: 5},
+		{Date: "2024-03-06", Remaining: 2.5, Ideal: 0},
+	})
+	assert.Equal(t, []string{
+		"2024-03-04     10  " + strings.Repeat("#", 40) + "|",
+		"2024-03-05     10  " + strings.Repeat("#", 20) + "|" + strings.Repeat("#", 19),
+		"2024-03-06    2.5  |#########",
+	}, lines)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/view"
 )
 
 // Register registers the sprints commands
@@ -20,6 +21,7 @@ func Register(parent *cobra.Command, opts *root.Options) {
 	cmd.AddCommand(newListCmd(opts))
 	cmd.AddCommand(newCurrentCmd(opts))
 	cmd.AddCommand(newIssuesCmd(opts))
+	cmd.AddCommand(newReportCmd(opts))
 	cmd.AddCommand(newAddCmd(opts))
 
 	parent.AddCommand(cmd)
@@ -72,7 +74,7 @@ func runList(opts *root.Options, boardID int, state string, maxResults int) erro
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(result.Values)
 	}
 
@@ -135,7 +137,7 @@ func runCurrent(opts *root.Options, boardID int) error {
 		return err
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(sprint)
 	}
 
@@ -196,7 +198,7 @@ func runIssues(opts *root.Options, sprintID int, maxResults int) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(result.Issues)
 	}
 
@@ -219,10 +221,7 @@ func runIssues(opts *root.Options, sprintID int, maxResults int) error {
 			issueType = issue.Fields.IssueType.Name
 		}
 
-		summary := issue.Fields.Summary
-		if len(summary) > 50 {
-			summary = summary[:50] + "..."
-		}
+		summary := view.Truncate(issue.Fields.Summary, 50)
 
 		rows = append(rows, []string{
 			issue.Key,
@@ -0,0 +1,227 @@
+package synccmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/progress"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/store"
+)
+
+// commentPageSize is the page size used when reading all comments of an issue
+const commentPageSize = 100
+
+// syncBatchSize is the number of issues written to the store at a time
+const syncBatchSize = 100
+
+// syncOptions holds the flags of sync
+type syncOptions struct {
+	JQL  string
+	Full bool
+	Max  int
+}
+
+// syncResult summarizes a sync
+type syncResult struct {
+	JQL     string     `json:"jql"`
+	Since   *time.Time `json:"since,omitempty"`
+	Fetched int        `json:"fetched"`
+	New     int        `json:"new"`
+	Updated int        `json:"updated"`
+	Stored  int        `json:"stored"`
+	Path    string     `json:"path"`
+	// Truncated is set when --max was reached
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Register registers the sync command
+func Register(parent *cobra.Command, opts *root.Options) {
+	var sync syncOptions
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Copy issues into the local store for offline use",
+		Long: `Copy the issues matching a JQL query, with their comments, into the local
+store used by 'jtk local search' and 'jtk issues get --offline'.
+
+Syncs are incremental: after the first sync of a query, only issues updated
+since the previous sync of the same query are fetched. Use --full to fetch
+everything again. Issues are fetched in the order they were updated, so when
+--max is reached the next sync continues after the last issue fetched. Issues
+are stored in batches of 100, so a sync that fails part way keeps what it
+stored and the next one resumes from there. Issues that are deleted or stop
+matching the query stay in the store.`,
+		Example: `  # Sync your recent work
+  jtk sync --jql "assignee = currentUser() AND updated >= -30d"
+
+  # Sync a project from scratch
+  jtk sync --jql "project = PROJ" --full`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSync(opts, sync)
+		},
+	}
+
+	cmd.Flags().StringVarP(&sync.JQL, "jql", "q", "", "JQL query selecting the issues to sync (required)")
+	cmd.Flags().BoolVar(&sync.Full, "full", false, "Fetch all matching issues, not only those updated since the last sync")
+	cmd.Flags().IntVarP(&sync.Max, "max", "m", 5000, "Maximum number of issues to fetch")
+
+	_ = cmd.MarkFlagRequired("jql")
+
+	parent.AddCommand(cmd)
+}
+
+func runSync(opts *root.Options, sync syncOptions) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	s, err := opts.Store()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	result := syncResult{JQL: sync.JQL, Path: s.Path()}
+
+	started := time.Now()
+	jql := sync.JQL
+	if !sync.Full {
+		last, err := s.LastSync(sync.JQL)
+		if err != nil {
+			return err
+		}
+		if !last.IsZero() {
+			result.Since = &last
+			jql = api.UpdatedWithin(sync.JQL, started.Sub(last))
+		}
+	}
+
+	fields := append(append([]string{}, api.DefaultSearchFields...), "comment")
+	issues, err := client.SearchAllFields(api.OrderJQL(jql, "updated ASC"), sync.Max, fields)
+	if err != nil {
+		return err
+	}
+	result.Fetched = len(issues)
+
+	// Records are written in batches, each advancing the sync mark to the
+	// last issue stored, so an interrupted sync resumes after that issue
+	records := make([]store.Record, 0, syncBatchSize)
+	flush := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		if err := s.Put(records...); err != nil {
+			return err
+		}
+		updated := records[len(records)-1].Issue.Fields.Updated
+		records = records[:0]
+		if updated == nil {
+			return nil
+		}
+		return s.SetLastSync(sync.JQL, updated.Time)
+	}
+
+	bar := progress.NewCount(opts.Stderr, fmt.Sprintf("Syncing %d issue(s)", len(issues)), len(issues))
+	for _, issue := range issues {
+		comments, err := issueComments(client, &issue)
+		if err != nil {
+			bar.Finish()
+			if ferr := flush(); ferr != nil {
+				return ferr
+			}
+			return err
+		}
+
+		known, err := s.Has(issue.Key)
+		if err != nil {
+			bar.Finish()
+			return err
+		}
+		if known {
+			result.Updated++
+		} else {
+			result.New++
+		}
+
+		records = append(records, store.Record{Issue: issue, Comments: comments, SyncedAt: started})
+		if len(records) == syncBatchSize {
+			if err := flush(); err != nil {
+				bar.Finish()
+				return err
+			}
+		}
+		bar.Add(1)
+	}
+	bar.Finish()
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// A complete sync covers everything up to its start; a truncated one
+	// keeps the mark of the last issue fetched, so the next one continues
+	// from there
+	if sync.Max > 0 && len(issues) >= sync.Max {
+		result.Truncated = true
+	} else if err := s.SetLastSync(sync.JQL, started); err != nil {
+		return err
+	}
+	if result.Stored, err = s.Count(); err != nil {
+		return err
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(result)
+	}
+
+	v.Success("Synced %d issue(s): %d new, %d updated", result.Fetched, result.New, result.Updated)
+	if result.Truncated {
+		v.Warning("Stopped at --max %d; run sync again to fetch the remaining issues", sync.Max)
+	}
+	v.Info("%d issue(s) in %s", result.Stored, result.Path)
+	return nil
+}
+
+// issueComments returns all comments of an issue. Search results embed the
+// comment field, which is used when it is complete; the field is then removed
+// from the issue, so comments are stored once.
+func issueComments(client *api.Client, issue *api.Issue) ([]api.Comment, error) {
+	var embedded api.CommentsResponse
+	if raw, ok := issue.Fields.CustomFields["comment"]; ok {
+		delete(issue.Fields.CustomFields, "comment")
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &embedded); err != nil {
+			return nil, fmt.Errorf("failed to parse comments of %s: %w", issue.Key, err)
+		}
+		if embedded.Total <= len(embedded.Comments) {
+			return embedded.Comments, nil
+		}
+	}
+
+	var comments []api.Comment
+	for startAt := 0; ; {
+		page, err := client.GetComments(issue.Key, startAt, commentPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comments of %s: %w", issue.Key, err)
+		}
+		comments = append(comments, page.Comments...)
+
+		startAt += len(page.Comments)
+		if len(page.Comments) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+	return comments, nil
+}
@@ -0,0 +1,247 @@
+package synccmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+func comment(id, text string) string {
+	return `{"id":"` + id + `","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"` + text + `"}]}]}}`
+}
+
+// newSyncTestServer serves PROJ-1 with its single comment embedded, and
+// PROJ-2 with one of its two comments embedded
+func newSyncTestServer(t *testing.T, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Contains(t, req.Fields, "comment")
+			*queries = append(*queries, req.JQL)
+
+			w.Write([]byte(`{"total":2,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"Flaky test","comment":{"total":1,"comments":[` + comment("1", "seen on CI") + `]}}},
+				{"key":"PROJ-2","fields":{"summary":"Slow build","comment":{"total":2,"comments":[` + comment("2", "first") + `]}}}
+			]}`))
+		case "/rest/api/3/issue/PROJ-2/comment":
+			w.Write([]byte(`{"total":2,"comments":[` + comment("2", "first") + `,` + comment("3", "second") + `]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunSync(t *testing.T) {
+	var queries []string
+	server := newSyncTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ ORDER BY key", Max: 100}))
+	assert.Contains(t, stdout.String(), "Synced 2 issue(s): 2 new, 0 updated")
+	assert.Contains(t, stdout.String(), "2 issue(s) in ")
+
+	s, err := opts.Store()
+	require.NoError(t, err)
+
+	r1, err := s.Get("PROJ-1")
+	require.NoError(t, err)
+	require.Len(t, r1.Comments, 1)
+	assert.Equal(t, "1", r1.Comments[0].ID)
+	assert.NotContains(t, r1.Issue.Fields.CustomFields, "comment")
+
+	r2, err := s.Get("PROJ-2")
+	require.NoError(t, err)
+	require.Len(t, r2.Comments, 2)
+	assert.Equal(t, "3", r2.Comments[1].ID)
+
+	last, err := s.LastSync("project = PROJ ORDER BY key")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), last, time.Minute)
+	require.NoError(t, s.Close())
+
+	// The second sync only asks for issues updated since the first
+	stdout.Reset()
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ ORDER BY key", Max: 100}))
+	assert.Contains(t, stdout.String(), "Synced 2 issue(s): 0 new, 2 updated")
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "project = PROJ ORDER BY updated ASC", queries[0])
+	assert.Regexp(t, `^\(project = PROJ\) AND updated >= -\d+m ORDER BY updated ASC$`, queries[1])
+
+	// --full fetches everything again
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ ORDER BY key", Full: true, Max: 100}))
+	assert.Equal(t, "project = PROJ ORDER BY updated ASC", queries[2])
+}
+
+func TestRunSync_Truncated(t *testing.T) {
+	const layout = "2006-01-02T15:04:05.000-0700"
+	now := time.Now()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.SearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		queries = append(queries, req.JQL)
+		w.Write([]byte(`{"total":2,"issues":[
+			{"key":"PROJ-1","fields":{"summary":"Old","updated":"` + now.Add(-10*time.Minute).Format(layout) + `","comment":{"total":0,"comments":[]}}},
+			{"key":"PROJ-2","fields":{"summary":"New","updated":"` + now.Add(-2*time.Minute).Format(layout) + `","comment":{"total":0,"comments":[]}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ", Max: 1}))
+	assert.Contains(t, stdout.String(), "Synced 1 issue(s): 1 new, 0 updated")
+	assert.Contains(t, opts.Stderr.(*bytes.Buffer).String(), "Stopped at --max 1")
+
+	// The sync mark is the update time of the last issue fetched, not the
+	// time of the sync
+	s, err := opts.Store()
+	require.NoError(t, err)
+	last, err := s.LastSync("project = PROJ")
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-10*time.Minute), last, time.Second)
+	require.NoError(t, s.Close())
+
+	// The second sync continues from there and completes
+	stdout.Reset()
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ", Max: 100}))
+	assert.Contains(t, stdout.String(), "Synced 2 issue(s): 1 new, 1 updated")
+	require.Len(t, queries, 2)
+	assert.Regexp(t, `^\(project = PROJ\) AND updated >= -1[12]m ORDER BY updated ASC$`, queries[1])
+
+	s, err = opts.Store()
+	require.NoError(t, err)
+	defer s.Close()
+	last, err = s.LastSync("project = PROJ")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), last, time.Minute)
+}
+
+func TestRunSync_Interrupted(t *testing.T) {
+	const layout = "2006-01-02T15:04:05.000-0700"
+	now := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"total":3,"issues":[
+				{"key":"PROJ-1","fields":{"summary":"One","updated":"` + now.Add(-30*time.Minute).Format(layout) + `","comment":{"total":0,"comments":[]}}},
+				{"key":"PROJ-2","fields":{"summary":"Two","updated":"` + now.Add(-20*time.Minute).Format(layout) + `","comment":{"total":0,"comments":[]}}},
+				{"key":"PROJ-3","fields":{"summary":"Three","updated":"` + now.Add(-10*time.Minute).Format(layout) + `","comment":{"total":1,"comments":[]}}}
+			]}`))
+		case "/rest/api/3/issue/PROJ-3/comment":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := cmdtest.Options(server, &bytes.Buffer{})
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+
+	err := runSync(opts, syncOptions{JQL: "project = PROJ", Max: 100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get comments of PROJ-3")
+
+	// The issues before the failure are stored, and the next sync resumes
+	// after the last of them
+	s, err := opts.Store()
+	require.NoError(t, err)
+	defer s.Close()
+	count, err := s.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	_, err = s.Get("PROJ-2")
+	require.NoError(t, err)
+
+	last, err := s.LastSync("project = PROJ")
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-20*time.Minute), last, time.Second)
+}
+
+func TestRunSync_JSON(t *testing.T) {
+	var queries []string
+	server := newSyncTestServer(t, &queries)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+	opts.Output = "json"
+
+	require.NoError(t, runSync(opts, syncOptions{JQL: "project = PROJ", Max: 100}))
+
+	var result syncResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "project = PROJ", result.JQL)
+	assert.Nil(t, result.Since)
+	assert.Equal(t, 2, result.Fetched)
+	assert.Equal(t, 2, result.New)
+	assert.Equal(t, 2, result.Stored)
+	assert.True(t, strings.HasSuffix(result.Path, "jira.db"))
+}
+
+func TestIssueComments_NotEmbedded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/PROJ-1/comment", r.URL.Path)
+		if r.URL.Query().Get("startAt") == "" {
+			w.Write([]byte(`{"total":2,"comments":[` + comment("1", "one") + `]}`))
+		} else {
+			assert.Equal(t, "1", r.URL.Query().Get("startAt"))
+			w.Write([]byte(`{"total":2,"comments":[` + comment("2", "two") + `]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &api.Client{BaseURL: server.URL + "/rest/api/3", HTTPClient: server.Client()}
+	issue := api.Issue{Key: "PROJ-1"}
+
+	comments, err := issueComments(client, &issue)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "2", comments[1].ID)
+}
+
+func TestRunSync_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages":["Error in the JQL Query"]}`))
+	}))
+	defer server.Close()
+
+	opts := cmdtest.Options(server, &bytes.Buffer{})
+	opts.SetStorePath(filepath.Join(t.TempDir(), "jira.db"))
+	err := runSync(opts, syncOptions{JQL: "project = ", Max: 100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error in the JQL Query")
+
+	// Nothing was recorded, so the next sync is a full one
+	s, err := opts.Store()
+	require.NoError(t, err)
+	defer s.Close()
+	last, err := s.LastSync("project = ")
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+}
@@ -0,0 +1,274 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/issuetemplate"
+)
+
+// Register registers the templates commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:     "templates",
+		Aliases: []string{"template", "tpl"},
+		Short:   "Manage issue templates",
+		Long: `Commands for listing, inspecting and validating issue templates.
+
+Templates are YAML or JSON files in the "templates" directory next to the
+config file. String values may use Go template syntax to refer to variables,
+which are set with --var when creating an issue:
+
+  about: Bug report
+  project: PROJ
+  type: Bug
+  variables:
+    component: {required: true, description: Affected component}
+    title: {required: true}
+  summary: "[{{.component}}] {{.title}}"
+  description: |
+    ## Steps to reproduce
+    ## Expected behaviour
+  labels: [bug, "{{.component}}"]
+  fields:
+    priority: High
+  subtasks:
+    - summary: "Add regression test for {{.title}}"
+
+Use a template with: jtk issues create --from-template bug --var component=api --var title="..."`,
+	}
+
+	cmd.AddCommand(newListCmd(opts))
+	cmd.AddCommand(newShowCmd(opts))
+	cmd.AddCommand(newValidateCmd(opts))
+
+	parent.AddCommand(cmd)
+}
+
+func newListCmd(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List issue templates",
+		Long:  "List the issue templates in the templates directory.",
+		Example: `  jtk templates list
+  jtk templates list -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts)
+		},
+	}
+}
+
+func runList(opts *root.Options) error {
+	v := opts.View()
+
+	list, errs, err := issuetemplate.List()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(errs) {
+		v.Warning("Skipping %s: %v", name, errs[name])
+	}
+
+	if len(list) == 0 {
+		dir, _ := issuetemplate.Dir()
+		v.Info("No templates found in %s", dir)
+		return nil
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(list)
+	}
+
+	headers := []string{"NAME", "PROJECT", "TYPE", "VARIABLES", "ABOUT"}
+	var rows [][]string
+	for _, t := range list {
+		rows = append(rows, []string{t.Name, t.Project, t.Type, formatVariables(t.Variables), t.About})
+	}
+
+	return v.Table(headers, rows)
+}
+
+func newShowCmd(opts *root.Options) *cobra.Command {
+	var vars []string
+	var render bool
+
+	cmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show an issue template",
+		Long: `Show the contents of an issue template.
+
+With --render (or any --var), variables are substituted and the resulting
+issue is shown instead of the raw template.`,
+		Example: `  # Show the template file
+  jtk templates show bug
+
+  # Preview the issue the template produces
+  jtk templates show bug --var component=api --var title="Login fails"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(opts, args[0], vars, render || len(vars) > 0)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Template variable (name=value)")
+	cmd.Flags().BoolVar(&render, "render", false, "Substitute variables and show the result")
+
+	return cmd
+}
+
+func runShow(opts *root.Options, name string, varArgs []string, render bool) error {
+	v := opts.View()
+
+	t, err := issuetemplate.Load(name)
+	if err != nil {
+		return err
+	}
+
+	if render {
+		vars, err := issuetemplate.ParseVars(varArgs)
+		if err != nil {
+			return err
+		}
+		if t, err = t.Render(vars); err != nil {
+			return err
+		}
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(t)
+	}
+
+	if !render {
+		data, err := os.ReadFile(t.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		v.Info("# %s", t.Path)
+		v.Print("%s", data)
+		return nil
+	}
+
+	enc := yaml.NewEncoder(v.Out)
+	enc.SetIndent(2)
+	if err := enc.Encode(t); err != nil {
+		return fmt.Errorf("failed to format template: %w", err)
+	}
+	return enc.Close()
+}
+
+func newValidateCmd(opts *root.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [name...]",
+		Short: "Validate issue templates",
+		Long: `Check that templates parse, have a summary and only refer to declared variables.
+
+Without arguments every template in the templates directory is checked.`,
+		Example: `  jtk templates validate
+  jtk templates validate bug spike`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(opts, args)
+		},
+	}
+}
+
+// validationResult is the outcome of validating one template
+type validationResult struct {
+	Name  string `json:"name"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+func runValidate(opts *root.Options, names []string) error {
+	v := opts.View()
+
+	var results []validationResult
+	if len(names) == 0 {
+		list, errs, err := issuetemplate.List()
+		if err != nil {
+			return err
+		}
+		for _, name := range sortedKeys(errs) {
+			results = append(results, validationResult{Name: name, Error: errs[name].Error()})
+		}
+		for _, t := range list {
+			results = append(results, validate(t))
+		}
+	} else {
+		for _, name := range names {
+			t, err := issuetemplate.Load(name)
+			if err != nil {
+				results = append(results, validationResult{Name: name, Error: err.Error()})
+				continue
+			}
+			results = append(results, validate(t))
+		}
+	}
+
+	if len(results) == 0 {
+		dir, _ := issuetemplate.Dir()
+		v.Info("No templates found in %s", dir)
+		return nil
+	}
+
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+		}
+	}
+
+	if opts.DataOutput() {
+		if err := v.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Valid {
+				v.Success("%s: ok", r.Name)
+			} else {
+				v.Error("%s: %s", r.Name, r.Error)
+			}
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d template(s) invalid", invalid, len(results))
+	}
+	return nil
+}
+
+func validate(t *issuetemplate.Template) validationResult {
+	if err := t.Validate(); err != nil {
+		return validationResult{Name: t.Name, Error: err.Error()}
+	}
+	return validationResult{Name: t.Name, Valid: true}
+}
+
+// formatVariables lists variable names, marking required ones with "*"
+func formatVariables(vars map[string]issuetemplate.Variable) string {
+	names := make([]string, 0, len(vars))
+	for name, variable := range vars {
+		if variable.Required {
+			name += "*"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func sortedKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package transitions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+)
+
+func newToCmd(opts *root.Options) *cobra.Command {
+	var maxHops int
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "to <issue-key> <status>",
+		Short: "Move an issue to a status",
+		Long: `Move an issue to the given status without knowing the transition name.
+
+If no single transition reaches the status, jtk searches the workflow
+breadth-first for the shortest chain of transitions through intermediate
+statuses, up to --max-hops. Transitions out of intermediate statuses are
+discovered from other issues of the same project and type that are currently
+in those statuses.`,
+		Example: `  # Move an issue to Done
+  jtk transitions to PROJ-123 Done
+
+  # Show the planned path without changing anything
+  jtk transitions to PROJ-123 Done --dry-run
+
+  # Allow longer workflow paths
+  jtk transitions to PROJ-123 "Ready for Release" --max-hops 8`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTo(opts, args[0], args[1], maxHops, dryRun)
+		},
+	}
+
+	cmd.Flags().IntVar(&maxHops, "max-hops", 5, "Maximum number of transitions to perform")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the planned path without performing it")
+
+	return cmd
+}
+
+// pathStep is one planned transition, used for output
+type pathStep struct {
+	TransitionID string `json:"transitionId"`
+	Transition   string `json:"transition"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+}
+
+func runTo(opts *root.Options, issueKey, target string, maxHops int, dryRun bool) error {
+	v := opts.View()
+
+	client, err := opts.APIClient()
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.GetIssue(issueKey)
+	if err != nil {
+		return err
+	}
+	if issue.Fields.Status == nil {
+		return fmt.Errorf("could not determine the current status of %s", issueKey)
+	}
+	current := *issue.Fields.Status
+
+	if api.StatusMatches(current, target) {
+		v.Info("%s is already in %s", issueKey, current.Name)
+		return nil
+	}
+
+	initial, err := client.GetTransitions(issueKey)
+	if err != nil {
+		return err
+	}
+
+	lookup := sampleTransitionLookup(client, issue)
+	path, err := api.FindTransitionPath(current, initial, target, maxHops, lookup)
+	if err != nil {
+		return err
+	}
+
+	steps := planSteps(current, path)
+
+	if dryRun {
+		if opts.DataOutput() {
+			return v.JSON(steps)
+		}
+		v.Info("Planned path for %s (%d transition(s)):", issueKey, len(steps))
+		v.Info("  %s", formatPath(current, path))
+		return nil
+	}
+
+	for i, step := range steps {
+		// Re-check against the live issue; conditions may differ from the sampled issues
+		available, err := client.GetTransitions(issueKey)
+		if err != nil {
+			return err
+		}
+		t := findTransition(available, step.TransitionID)
+		if t == nil || !api.StatusMatches(t.To, step.To) {
+			return fmt.Errorf("transition %q to %s is not available on %s (stopped after %d of %d step(s))",
+				step.Transition, step.To, issueKey, i, len(steps))
+		}
+
+		if err := client.DoTransition(issueKey, t.ID, nil); err != nil {
+			return fmt.Errorf("transition %q failed after %d of %d step(s): %w", step.Transition, i, len(steps), err)
+		}
+		if !opts.DataOutput() {
+			v.Success("%s: %s -> %s", issueKey, step.From, step.To)
+		}
+	}
+
+	if opts.DataOutput() {
+		return v.JSON(steps)
+	}
+	return nil
+}
+
+// sampleTransitionLookup discovers the transitions out of a status by looking
+// at another issue of the same project and issue type currently in that status.
+// Results are memoized per status.
+func sampleTransitionLookup(client *api.Client, issue *api.Issue) api.TransitionLookup {
+	cache := make(map[string][]api.Transition)
+
+	return func(status api.Status) ([]api.Transition, error) {
+		if transitions, ok := cache[status.ID]; ok {
+			return transitions, nil
+		}
+
+		var clauses []string
+		if issue.Fields.Project != nil {
+			clauses = append(clauses, fmt.Sprintf("project = %q", issue.Fields.Project.Key))
+		}
+		if issue.Fields.IssueType != nil {
+			clauses = append(clauses, fmt.Sprintf("issuetype = %q", issue.Fields.IssueType.ID))
+		}
+		clauses = append(clauses, fmt.Sprintf("status = %q", status.ID))
+
+		result, err := client.Search(api.SearchOptions{
+			JQL:        strings.Join(clauses, " AND "),
+			MaxResults: 1,
+			Fields:     []string{"status"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var transitions []api.Transition
+		if len(result.Issues) > 0 {
+			transitions, err = client.GetTransitions(result.Issues[0].Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		cache[status.ID] = transitions
+		return transitions, nil
+	}
+}
+
+// planSteps converts a transition path into printable steps
+func planSteps(current api.Status, path []api.Transition) []pathStep {
+	steps := make([]pathStep, len(path))
+	from := current.Name
+	for i, t := range path {
+		steps[i] = pathStep{
+			TransitionID: t.ID,
+			Transition:   t.Name,
+			From:         from,
+			To:           t.To.Name,
+		}
+		from = t.To.Name
+	}
+	return steps
+}
+
+// formatPath renders a path as "To Do -[Start]-> In Progress -[Resolve]-> Done"
+func formatPath(current api.Status, path []api.Transition) string {
+	var b strings.Builder
+	b.WriteString(current.Name)
+	for _, t := range path {
+		fmt.Fprintf(&b, " -[%s]-> %s", t.Name, t.To.Name)
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package transitions
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/cmdtest"
+)
+
+// newWorkflowServer serves a To Do -> In Progress -> Done workflow where
+// PROJ-1 is in To Do and PROJ-2 is a sample issue in In Progress
+func newWorkflowServer(t *testing.T, performed *[]string) *httptest.Server {
+	t.Helper()
+
+	todo := api.Status{ID: "1", Name: "To Do"}
+	inProgress := api.Status{ID: "3", Name: "In Progress"}
+	done := api.Status{ID: "5", Name: "Done"}
+	status := map[string]api.Status{"PROJ-1": todo, "PROJ-2": inProgress}
+	workflow := map[string][]api.Transition{
+		"1": {{ID: "11", Name: "Start", To: inProgress}},
+		"3": {{ID: "31", Name: "Finish", To: done}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1" && r.Method == http.MethodGet:
+			s := status["PROJ-1"]
+			json.NewEncoder(w).Encode(api.Issue{Key: "PROJ-1", Fields: api.IssueFields{
+				Status:    &s,
+				Project:   &api.Project{Key: "PROJ"},
+				IssueType: &api.IssueType{ID: "10001", Name: "Task"},
+			}})
+		case r.URL.Path == "/rest/api/3/search/jql":
+			var req api.SearchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			assert.Contains(t, req.JQL, `project = "PROJ"`)
+			assert.Contains(t, req.JQL, `issuetype = "10001"`)
+			assert.Contains(t, req.JQL, `status = "3"`)
+			json.NewEncoder(w).Encode(api.SearchResult{Total: 1, Issues: []api.Issue{{Key: "PROJ-2"}}})
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions" && r.Method == http.MethodPost:
+			var req api.TransitionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			*performed = append(*performed, req.Transition.ID)
+			for _, tr := range workflow[status["PROJ-1"].ID] {
+				if tr.ID == req.Transition.ID {
+					status["PROJ-1"] = tr.To
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions":
+			json.NewEncoder(w).Encode(api.TransitionsResponse{Transitions: workflow[status["PROJ-1"].ID]})
+		case r.URL.Path == "/rest/api/3/issue/PROJ-2/transitions":
+			json.NewEncoder(w).Encode(api.TransitionsResponse{Transitions: workflow[status["PROJ-2"].ID]})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunTo_DryRun(t *testing.T) {
+	var performed []string
+	server := newWorkflowServer(t, &performed)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runTo(opts, "PROJ-1", "done", 5, true))
+	assert.Contains(t, stdout.String(), "To Do -[Start]-> In Progress -[Finish]-> Done")
+	assert.Empty(t, performed)
+}
+
+func TestRunTo_MultiHop(t *testing.T) {
+	var performed []string
+	server := newWorkflowServer(t, &performed)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	opts := cmdtest.Options(server, &stdout)
+
+	require.NoError(t, runTo(opts, "PROJ-1", "Done", 5, false))
+	assert.Equal(t, []string{"11", "31"}, performed)
+	assert.Contains(t, stdout.String(), "In Progress -> Done")
+}
+
+func TestRunTo_HopLimit(t *testing.T) {
+	var performed []string
+	server := newWorkflowServer(t, &performed)
+	defer server.Close()
+
+	opts := cmdtest.Options(server, &bytes.Buffer{})
+
+	err := runTo(opts, "PROJ-1", "Done", 1, false)
+	assert.ErrorIs(t, err, api.ErrNoTransitionPath)
+	assert.Empty(t, performed)
+}
@@ -2,12 +2,14 @@ package transitions
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/jira-ticket-cli/api"
 	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/prompt"
 )
 
 // Register registers the transitions commands
@@ -21,6 +23,7 @@ func Register(parent *cobra.Command, opts *root.Options) {
 
 	cmd.AddCommand(newListCmd(opts))
 	cmd.AddCommand(newDoCmd(opts))
+	cmd.AddCommand(newToCmd(opts))
 
 	parent.AddCommand(cmd)
 }
@@ -66,7 +69,7 @@ func runList(opts *root.Options, issueKey string, showFields bool) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(transitions)
 	}
 
@@ -106,15 +109,28 @@ func getRequiredFields(t api.Transition) string {
 	return strings.Join(required, ", ")
 }
 
+// doOptions holds the flags for transitions do
+type doOptions struct {
+	Fields      []string
+	Interactive bool
+	Resolution  string
+	Comment     string
+}
+
 func newDoCmd(opts *root.Options) *cobra.Command {
-	var fields []string
+	var do doOptions
 
 	cmd := &cobra.Command{
-		Use:   "do <issue-key> <transition>",
+		Use:   "do <issue-key> [transition]",
 		Short: "Perform a transition",
 		Long: `Perform a workflow transition on an issue. The transition can be specified by name or ID.
 
-Some transitions require additional fields to be set. Use --field to provide them.`,
+Some transitions require additional fields to be set. Use --field to provide them.
+
+With --interactive (or when no transition is given on a terminal), jtk lets you
+pick the transition from a filterable list and then prompts for each required
+field, offering the allowed values as choices. A resolution and a comment can be
+set in the same request.`,
 		Example: `  # Transition by name
   jtk transitions do PROJ-123 "In Progress"
 
@@ -123,51 +139,71 @@ Some transitions require additional fields to be set. Use --field to provide the
 
   # Transition with required fields
   jtk transitions do PROJ-123 "In Progress" --field resolution=Done
-  jtk transitions do PROJ-123 "Done" --field customfield_10001="some value"`,
-		Args: cobra.ExactArgs(2),
+  jtk transitions do PROJ-123 "Done" --field customfield_10001="some value"
+
+  # Resolve and comment in one step
+  jtk transitions do PROJ-123 Done --resolution Fixed --comment "Released in 2.3.0"
+
+  # Pick the transition and fill in required fields interactively
+  jtk transitions do PROJ-123 -i`,
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDo(opts, args[0], args[1], fields)
+			transition := ""
+			if len(args) > 1 {
+				transition = args[1]
+			}
+			return runDo(opts, args[0], transition, do)
 		},
 	}
 
-	cmd.Flags().StringArrayVarP(&fields, "field", "f", nil, "Fields to set during transition (key=value)")
+	cmd.Flags().StringArrayVarP(&do.Fields, "field", "f", nil, "Fields to set during transition (key=value, key+=value, key:=json)")
+	cmd.Flags().BoolVarP(&do.Interactive, "interactive", "i", false, "Choose the transition and fill in required fields interactively")
+	cmd.Flags().StringVar(&do.Resolution, "resolution", "", "Resolution to set (e.g., Fixed, Done)")
+	cmd.Flags().StringVar(&do.Comment, "comment", "", "Comment to add with the transition")
 
 	return cmd
 }
 
-func runDo(opts *root.Options, issueKey, transitionNameOrID string, fieldArgs []string) error {
+func runDo(opts *root.Options, issueKey, transitionNameOrID string, do doOptions) error {
 	v := opts.View()
 
+	interactive := do.Interactive || transitionNameOrID == ""
+	if interactive && !prompt.IsInteractive(opts.Stdin, opts.Stdout) {
+		if transitionNameOrID == "" {
+			return fmt.Errorf("a transition is required when not running in a terminal")
+		}
+		return prompt.ErrNotInteractive
+	}
+
 	client, err := opts.APIClient()
 	if err != nil {
 		return err
 	}
 
-	// Get available transitions
-	transitions, err := client.GetTransitions(issueKey)
+	// Get available transitions; field metadata is only needed for prompting
+	transitions, err := client.GetTransitionsWithFields(issueKey, interactive)
 	if err != nil {
 		return err
 	}
 
-	// Find the transition
-	var transitionID string
+	if len(transitions) == 0 {
+		return fmt.Errorf("no transitions available for %s", issueKey)
+	}
 
-	// First try by ID
-	for _, t := range transitions {
-		if t.ID == transitionNameOrID {
-			transitionID = t.ID
-			break
-		}
+	var p *prompt.Prompter
+	if interactive {
+		p = prompt.New(opts.Stdin, opts.Stdout)
 	}
 
-	// Then try by name
-	if transitionID == "" {
-		if t := api.FindTransitionByName(transitions, transitionNameOrID); t != nil {
-			transitionID = t.ID
+	transition := findTransition(transitions, transitionNameOrID)
+	if transition == nil && interactive {
+		transition, err = pickTransition(p, transitions, transitionNameOrID)
+		if err != nil {
+			return err
 		}
 	}
 
-	if transitionID == "" {
+	if transition == nil {
 		v.Error("Transition '%s' not found", transitionNameOrID)
 		v.Info("Available transitions:")
 		for _, t := range transitions {
@@ -177,46 +213,118 @@ func runDo(opts *root.Options, issueKey, transitionNameOrID string, fieldArgs []
 	}
 
 	// Parse fields if provided
-	var fields map[string]interface{}
-	if len(fieldArgs) > 0 {
-		fields = make(map[string]interface{})
+	changes, err := client.ResolveFieldArgs(do.Fields)
+	if err != nil {
+		return err
+	}
+	fields := changes.Fields
 
-		// Get field metadata for name resolution and type detection
-		allFields, err := client.GetFields()
-		if err != nil {
-			return fmt.Errorf("failed to get field metadata: %w", err)
-		}
+	if do.Resolution != "" {
+		fields["resolution"] = map[string]string{"name": do.Resolution}
+	}
 
-		for _, f := range fieldArgs {
-			parts := strings.SplitN(f, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid field format: %s (expected key=value)", f)
+	comment := do.Comment
+	if interactive {
+		if err := promptTransitionFields(p, transition, fields, client.ResolveUserByEmail); err != nil {
+			return err
+		}
+		if comment == "" {
+			comment, err = p.Input("Comment (optional)", "")
+			if err != nil {
+				return err
 			}
+		}
+	}
 
-			key, value := parts[0], parts[1]
-
-			// Try to resolve field name to ID and get field info
-			var fieldID string
-			var field *api.Field
-			if resolved := api.FindFieldByName(allFields, key); resolved != nil {
-				fieldID = resolved.ID
-				field = resolved
-			} else if resolved := api.FindFieldByID(allFields, key); resolved != nil {
-				fieldID = resolved.ID
-				field = resolved
-			} else {
-				fieldID = key
-			}
+	if comment != "" {
+		changes.AddComment(comment)
+	}
+
+	if err := client.DoTransitionWithUpdate(issueKey, transition.ID, fields, changes.Update); err != nil {
+		return err
+	}
 
-			// Format value based on field type
-			fields[fieldID] = api.FormatFieldValue(field, value)
+	v.Success("Transitioned %s to %s", issueKey, transition.To.Name)
+	return nil
+}
+
+// findTransition finds a transition by ID, then by name (case-insensitive)
+func findTransition(transitions []api.Transition, nameOrID string) *api.Transition {
+	if nameOrID == "" {
+		return nil
+	}
+	for i := range transitions {
+		if transitions[i].ID == nameOrID {
+			return &transitions[i]
 		}
 	}
+	return api.FindTransitionByName(transitions, nameOrID)
+}
 
-	if err := client.DoTransition(issueKey, transitionID, fields); err != nil {
-		return err
+// pickTransition lets the user choose a transition, pre-filtered by query when given
+func pickTransition(p *prompt.Prompter, transitions []api.Transition, query string) (*api.Transition, error) {
+	labels := make([]string, len(transitions))
+	for i, t := range transitions {
+		labels[i] = fmt.Sprintf("%s -> %s", t.Name, t.To.Name)
+	}
+
+	candidates := make([]int, len(transitions))
+	for i := range transitions {
+		candidates[i] = i
+	}
+	if query != "" {
+		if matches := prompt.FuzzyFilter(query, labels); len(matches) > 0 {
+			candidates = matches
+		}
+	}
+	if len(candidates) == 1 {
+		return &transitions[candidates[0]], nil
+	}
+
+	options := make([]string, len(candidates))
+	for i, idx := range candidates {
+		options[i] = labels[idx]
+	}
+
+	choice, err := p.Select("Select a transition:", options, false)
+	if err != nil {
+		return nil, err
+	}
+	return &transitions[candidates[choice]], nil
+}
+
+// promptTransitionFields asks for every required transition field not already
+// present in fields, plus the resolution when the transition screen offers one
+func promptTransitionFields(p *prompt.Prompter, t *api.Transition, fields map[string]interface{}, resolveUser api.UserResolver) error {
+	ids := make([]string, 0, len(t.Fields))
+	for id := range t.Fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		meta := t.Fields[id]
+		if _, set := fields[id]; set {
+			continue
+		}
+		if !meta.Required && id != "resolution" {
+			continue
+		}
+
+		value, ok, err := p.FieldValue(prompt.Field{
+			ID:            id,
+			Name:          meta.Name,
+			Required:      meta.Required,
+			Schema:        meta.Schema,
+			AllowedValues: meta.AllowedValues,
+		}, resolveUser)
+		if err != nil {
+			return err
+		}
+		if ok {
+			fields[id] = value
+		}
 	}
 
-	v.Success("Transitioned %s", issueKey)
 	return nil
 }
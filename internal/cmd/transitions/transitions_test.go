@@ -1,11 +1,16 @@
 package transitions
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/prompt"
 )
 
 func TestFormatFieldValue(t *testing.T) {
@@ -195,3 +200,78 @@ func TestGetRequiredFields(t *testing.T) {
 		})
 	}
 }
+
+func TestFindTransition(t *testing.T) {
+	transitions := []api.Transition{
+		{ID: "11", Name: "Start Progress", To: api.Status{Name: "In Progress"}},
+		{ID: "31", Name: "Done", To: api.Status{Name: "Done"}},
+	}
+
+	assert.Equal(t, "31", findTransition(transitions, "31").ID)
+	assert.Equal(t, "11", findTransition(transitions, "start progress").ID)
+	assert.Nil(t, findTransition(transitions, "missing"))
+	assert.Nil(t, findTransition(transitions, ""))
+}
+
+func TestPromptTransitionFields(t *testing.T) {
+	transition := &api.Transition{
+		ID:   "31",
+		Name: "Resolve",
+		Fields: map[string]api.TransitionField{
+			"resolution": {
+				Required: false,
+				Name:     "Resolution",
+				Schema:   api.FieldSchema{Type: "resolution"},
+				AllowedValues: []api.FieldOption{
+					{ID: "1", Name: "Fixed"},
+					{ID: "2", Name: "Won't Fix"},
+				},
+			},
+			"customfield_10050": {
+				Required: true,
+				Name:     "Root Cause",
+				Schema:   api.FieldSchema{Type: "string"},
+			},
+			"customfield_10060": {
+				Required: true,
+				Name:     "Components Hit",
+				Schema:   api.FieldSchema{Type: "array", Items: "option"},
+				AllowedValues: []api.FieldOption{
+					{ID: "100", Value: "API"},
+					{ID: "101", Value: "UI"},
+				},
+			},
+			"customfield_10070": {
+				Required: true,
+				Name:     "Already Set",
+				Schema:   api.FieldSchema{Type: "string"},
+			},
+		},
+	}
+
+	// Prompts are asked in field ID order: customfield_10050, customfield_10060, resolution
+	input := "Config drift\nui\nfixed\n"
+	p := prompt.New(strings.NewReader(input), &bytes.Buffer{})
+
+	fields := map[string]interface{}{"customfield_10070": "given"}
+	require.NoError(t, promptTransitionFields(p, transition, fields, nil))
+
+	assert.Equal(t, "Config drift", fields["customfield_10050"])
+	assert.Equal(t, []map[string]string{{"id": "101"}}, fields["customfield_10060"])
+	assert.Equal(t, map[string]string{"id": "1"}, fields["resolution"])
+	assert.Equal(t, "given", fields["customfield_10070"])
+}
+
+func TestRunDo_InteractiveRequiresTerminal(t *testing.T) {
+	opts := &root.Options{
+		Stdin:  strings.NewReader(""),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	err := runDo(opts, "PROJ-1", "", doOptions{})
+	assert.ErrorContains(t, err, "transition is required")
+
+	err = runDo(opts, "PROJ-1", "Done", doOptions{Interactive: true})
+	assert.ErrorIs(t, err, prompt.ErrNotInteractive)
+}
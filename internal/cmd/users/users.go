@@ -67,7 +67,7 @@ func runSearch(opts *root.Options, query string, maxResults int) error {
 		return nil
 	}
 
-	if opts.Output == "json" {
+	if opts.DataOutput() {
 		return v.JSON(users)
 	}
 
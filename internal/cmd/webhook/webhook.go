@@ -0,0 +1,348 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cmd/root"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/hook"
+)
+
+// Event types, as named in --on
+const (
+	eventIssueCreated   = "issue_created"
+	eventIssueUpdated   = "issue_updated"
+	eventCommentCreated = "comment_created"
+	eventSprintStarted  = "sprint_started"
+)
+
+// eventTypes maps the webhookEvent names Jira sends to event types
+var eventTypes = map[string]string{
+	"jira:issue_created": eventIssueCreated,
+	"jira:issue_updated": eventIssueUpdated,
+	"comment_created":    eventCommentCreated,
+	"sprint_started":     eventSprintStarted,
+}
+
+// signatureHeader carries the HMAC-SHA256 of the body when the webhook has a secret
+const signatureHeader = "X-Hub-Signature"
+
+// maxPayloadSize bounds the size of a webhook body
+const maxPayloadSize = 10 << 20
+
+// shutdownTimeout is how long in-flight requests get to finish on Ctrl+C
+const shutdownTimeout = 5 * time.Second
+
+// serveOptions holds the flags of webhook serve
+type serveOptions struct {
+	Host   string
+	Port   int
+	Path   string
+	Secret string
+	Hooks  []string
+}
+
+// payload is the part of a Jira webhook body that is decoded
+type payload struct {
+	Timestamp    int64               `json:"timestamp"`
+	WebhookEvent string              `json:"webhookEvent"`
+	User         *api.User           `json:"user"`
+	Issue        *api.Issue          `json:"issue"`
+	Changelog    *api.ChangelogEntry `json:"changelog"`
+	Comment      *api.Comment        `json:"comment"`
+	Sprint       *api.Sprint         `json:"sprint"`
+}
+
+// event is a webhook decoded for output and hooks
+type event struct {
+	Type      string              `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	User      *api.User           `json:"user,omitempty"`
+	Issue     *api.Issue          `json:"issue,omitempty"`
+	Changelog *api.ChangelogEntry `json:"changelog,omitempty"`
+	Comment   *api.Comment        `json:"comment,omitempty"`
+	Sprint    *api.Sprint         `json:"sprint,omitempty"`
+}
+
+// receiver is the HTTP handler for Jira webhooks
+type receiver struct {
+	secret string
+	emit   func(event) error
+	stderr io.Writer
+}
+
+// Register registers the webhook commands
+func Register(parent *cobra.Command, opts *root.Options) {
+	cmd := &cobra.Command{
+		Use:     "webhook",
+		Aliases: []string{"webhooks"},
+		Short:   "Receive Jira webhooks",
+		Long:    "Commands for turning Jira webhooks into local events.",
+	}
+
+	cmd.AddCommand(newServeCmd(opts))
+
+	parent.AddCommand(cmd)
+}
+
+func newServeCmd(opts *root.Options) *cobra.Command {
+	var serve serveOptions
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run a local receiver for Jira webhooks",
+		Long: `Run an HTTP server that receives Jira webhooks for created and updated
+issues, created comments and started sprints. Point a Jira webhook at it,
+through a tunnel when Jira cannot reach your machine.
+
+Each event is written to stdout as one line of JSON with its type, timestamp,
+user and the issue, changelog, comment or sprint it concerns. With --on, a
+command runs for events of a type instead: --on TYPE=COMMAND, where TYPE is
+issue_created, issue_updated, comment_created or sprint_started. The command
+is split into arguments like a shell would, but is not run by one; each
+argument is a Go template over the event, such as {{.Issue.Key}}.
+
+With --secret (or JIRA_WEBHOOK_SECRET), payloads must carry a valid
+X-Hub-Signature, the HMAC-SHA256 Jira computes with the webhook's secret.`,
+		Example: `  # Stream events as NDJSON
+  jtk webhook serve --port 8080 --secret "$SECRET"
+
+  # React to new comments
+  jtk webhook serve --on 'comment_created=notify-send "{{.Issue.Key}}" "{{.Comment.Author.DisplayName}} commented"'
+
+  # Filter the stream with jq
+  jtk webhook serve | jq -r 'select(.type == "issue_created") | .issue.key'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("secret") {
+				serve.Secret = os.Getenv("JIRA_WEBHOOK_SECRET")
+			}
+			return runServe(opts, serve)
+		},
+	}
+
+	cmd.Flags().StringVar(&serve.Host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVarP(&serve.Port, "port", "p", 8080, "Port to listen on")
+	cmd.Flags().StringVar(&serve.Path, "path", "/", "URL path to receive webhooks on")
+	cmd.Flags().StringVar(&serve.Secret, "secret", "", "Webhook secret for validating signatures")
+	cmd.Flags().StringArrayVar(&serve.Hooks, "on", nil, "Command to run for an event type, as TYPE=COMMAND (repeatable)")
+
+	return cmd
+}
+
+func runServe(opts *root.Options, serve serveOptions) error {
+	hooks, err := parseHooks(serve.Hooks)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(serve.Path, "/") {
+		serve.Path = "/" + serve.Path
+	}
+	mux := http.NewServeMux()
+	mux.Handle(serve.Path, &receiver{
+		secret: serve.Secret,
+		emit:   dispatcher(opts.Stdout, opts.Stderr, hooks),
+		stderr: opts.Stderr,
+	})
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(serve.Host, strconv.Itoa(serve.Port)))
+	if err != nil {
+		return err
+	}
+
+	if serve.Secret == "" {
+		fmt.Fprintln(opts.Stderr, "Warning: no --secret given; payloads are not verified")
+	}
+	fmt.Fprintf(opts.Stderr, "Listening for webhooks on http://%s%s; press Ctrl+C to stop\n", listener.Addr(), serve.Path)
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() { errc <- server.Serve(listener) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdown)
+}
+
+// parseHooks parses the --on flags into commands by event type
+func parseHooks(specs []string) (map[string]*hook.Command, error) {
+	hooks := make(map[string]*hook.Command, len(specs))
+	for _, spec := range specs {
+		typ, command, ok := strings.Cut(spec, "=")
+		typ = strings.TrimSpace(typ)
+		if !ok || typ == "" {
+			return nil, fmt.Errorf("invalid --on %q: expected TYPE=COMMAND", spec)
+		}
+		if !knownType(typ) {
+			return nil, fmt.Errorf("invalid --on %q: unknown event type %q", spec, typ)
+		}
+		if _, dup := hooks[typ]; dup {
+			return nil, fmt.Errorf("invalid --on %q: %s already has a command", spec, typ)
+		}
+
+		cmd, err := hook.Parse(command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --on %q: %w", spec, err)
+		}
+		hooks[typ] = cmd
+	}
+	return hooks, nil
+}
+
+func knownType(typ string) bool {
+	for _, t := range eventTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatcher returns the function events are passed to: without hooks it
+// writes each event to stdout as a line of JSON, with hooks it runs the hook
+// for the event's type
+func dispatcher(stdout, stderr io.Writer, hooks map[string]*hook.Command) func(event) error {
+	var mu sync.Mutex
+	enc := json.NewEncoder(stdout)
+
+	return func(e event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(hooks) == 0 {
+			return enc.Encode(e)
+		}
+		cmd, ok := hooks[e.Type]
+		if !ok {
+			return nil
+		}
+		if err := cmd.Run(e, stdout, stderr); err != nil {
+			return fmt.Errorf("--on %s: %w", e.Type, err)
+		}
+		return nil
+	}
+}
+
+// ServeHTTP validates and decodes a webhook and passes it on. Events of other
+// types are acknowledged and ignored.
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	if rc.secret != "" && !validSignature(rc.secret, body, r.Header.Get(signatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	e, err := decodeEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if e == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if err := rc.emit(*e); err != nil {
+		fmt.Fprintf(rc.stderr, "Warning: %v\n", err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validSignature checks a "sha256=<hex>" signature of body
+func validSignature(secret string, body []byte, signature string) bool {
+	algorithm, sum, ok := strings.Cut(signature, "=")
+	if !ok || !strings.EqualFold(algorithm, "sha256") {
+		return false
+	}
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// decodeEvent decodes a webhook body. It returns nil for event types that
+// are not handled, and an error when the body lacks what its type needs.
+func decodeEvent(body []byte) (*event, error) {
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+	if p.WebhookEvent == "" {
+		return nil, fmt.Errorf("payload has no webhookEvent")
+	}
+
+	typ, ok := eventTypes[p.WebhookEvent]
+	if !ok {
+		return nil, nil
+	}
+
+	switch {
+	case typ == eventSprintStarted && p.Sprint == nil:
+		return nil, fmt.Errorf("%s payload has no sprint", p.WebhookEvent)
+	case typ != eventSprintStarted && (p.Issue == nil || p.Issue.Key == ""):
+		return nil, fmt.Errorf("%s payload has no issue", p.WebhookEvent)
+	case typ == eventCommentCreated && p.Comment == nil:
+		return nil, fmt.Errorf("%s payload has no comment", p.WebhookEvent)
+	}
+
+	e := &event{
+		Type:      typ,
+		User:      p.User,
+		Issue:     p.Issue,
+		Changelog: p.Changelog,
+		Comment:   p.Comment,
+		Sprint:    p.Sprint,
+	}
+	if p.Timestamp > 0 {
+		e.Timestamp = time.UnixMilli(p.Timestamp).UTC()
+	}
+	return e, nil
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readPayload(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func post(t *testing.T, url string, body []byte, signature string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(signatureHeader, signature)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestReceiver_StreamsNDJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	server := httptest.NewServer(&receiver{emit: dispatcher(&stdout, &stderr, nil), stderr: &stderr})
+	defer server.Close()
+
+	for _, name := range []string{"issue_updated.json", "comment_created.json", "sprint_started.json"} {
+		resp := post(t, server.URL, readPayload(t, name), "")
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, name)
+	}
+	assert.Empty(t, stderr.String())
+
+	var events []event
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var e event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e), scanner.Text())
+		events = append(events, e)
+	}
+	require.Len(t, events, 3)
+
+	updated := events[0]
+	assert.Equal(t, "issue_updated", updated.Type)
+	assert.Equal(t, time.Date(2024, 3, 7, 10, 0, 0, 0, time.UTC), updated.Timestamp)
+	assert.Equal(t, "Alice Example", updated.User.DisplayName)
+	assert.Equal(t, "PROJ-42", updated.Issue.Key)
+	assert.Equal(t, "In Progress", updated.Issue.Fields.Status.Name)
+	assert.Equal(t, 3.0, updated.Issue.Fields.CustomFields["customfield_10016"])
+	require.Len(t, updated.Changelog.Items, 1)
+	assert.Equal(t, "To Do", updated.Changelog.Items[0].FromString)
+
+	comment := events[1]
+	assert.Equal(t, "comment_created", comment.Type)
+	assert.Equal(t, "PROJ-42", comment.Issue.Key)
+	assert.Equal(t, "Bob Example", comment.Comment.Author.DisplayName)
+	assert.Contains(t, comment.Comment.Body.ToPlainText(), "Reproduced on staging")
+
+	sprint := events[2]
+	assert.Equal(t, "sprint_started", sprint.Type)
+	assert.Equal(t, "Sprint 7", sprint.Sprint.Name)
+	assert.Nil(t, sprint.Issue)
+}
+
+func TestReceiver_Validation(t *testing.T) {
+	var stdout bytes.Buffer
+	server := httptest.NewServer(&receiver{secret: "s3cret", emit: dispatcher(&stdout, &bytes.Buffer{}, nil), stderr: &bytes.Buffer{}})
+	defer server.Close()
+
+	body := readPayload(t, "issue_updated.json")
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		status    int
+	}{
+		{"valid signature", body, sign("s3cret", body), http.StatusNoContent},
+		{"missing signature", body, "", http.StatusUnauthorized},
+		{"wrong secret", body, sign("other", body), http.StatusUnauthorized},
+		{"tampered body", append([]byte(" "), body...), sign("s3cret", body), http.StatusUnauthorized},
+		{"not JSON", []byte("hello"), sign("s3cret", []byte("hello")), http.StatusBadRequest},
+		{"no issue", []byte(`{"webhookEvent":"jira:issue_created"}`), sign("s3cret", []byte(`{"webhookEvent":"jira:issue_created"}`)), http.StatusBadRequest},
+		{"other event", []byte(`{"webhookEvent":"board_created"}`), sign("s3cret", []byte(`{"webhookEvent":"board_created"}`)), http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, server.URL, tt.body, tt.signature)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+
+	assert.Equal(t, 1, strings.Count(stdout.String(), "\n"), "only the valid payload is streamed")
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestParseHooks(t *testing.T) {
+	hooks, err := parseHooks([]string{"issue_created=echo {{.Issue.Key}}", "sprint_started = echo go"})
+	require.NoError(t, err)
+	assert.Len(t, hooks, 2)
+	assert.Contains(t, hooks, "sprint_started")
+
+	for _, spec := range []string{"echo hi", "board_created=echo", "issue_created=", "issue_created={{.Nope"} {
+		_, err := parseHooks([]string{spec})
+		assert.Error(t, err, spec)
+	}
+	_, err = parseHooks([]string{"issue_created=a", "issue_created=b"})
+	assert.Error(t, err)
+}
+
+func TestDispatcher_RunsHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the hook")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"$@\" >> \""+out+"\"\n"), 0o700))
+
+	hooks, err := parseHooks([]string{"comment_created=" + script + " {{.Issue.Key}} {{.Comment.Author.DisplayName}}"})
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	server := httptest.NewServer(&receiver{emit: dispatcher(&stdout, &bytes.Buffer{}, hooks), stderr: &bytes.Buffer{}})
+	defer server.Close()
+
+	post(t, server.URL, readPayload(t, "issue_updated.json"), "")
+	post(t, server.URL, readPayload(t, "comment_created.json"), "")
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-42 Bob Example\n", string(data))
+	assert.Empty(t, stdout.String(), "events are not streamed when hooks handle them")
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -22,15 +23,26 @@ type Config struct {
 	Domain   string `json:"domain,omitempty"` // Deprecated: use URL instead
 	Email    string `json:"email"`
 	APIToken string `json:"api_token"`
+	CacheTTL string `json:"cache_ttl,omitempty"` // Duration such as "30m" or "24h"
+	Columns  string `json:"columns,omitempty"`   // Default issue list columns, comma-separated
 }
 
-// configPath returns the path to the config file
-func configPath() (string, error) {
+// Dir returns the directory holding the config file and other user files such as templates
+func Dir() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get config directory: %w", err)
 	}
-	return filepath.Join(configDir, configDirName, configFileName), nil
+	return filepath.Join(configDir, configDirName), nil
+}
+
+// configPath returns the path to the config file
+func configPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
 }
 
 // Load loads the configuration from file
@@ -178,6 +190,52 @@ func GetAPIToken() string {
 	return cfg.APIToken
 }
 
+// GetCacheTTL returns how long cached metadata stays fresh, or 0 if unset or invalid.
+// Precedence: JIRA_CACHE_TTL → config cache_ttl
+func GetCacheTTL() time.Duration {
+	value := os.Getenv("JIRA_CACHE_TTL")
+	if value == "" {
+		cfg, err := Load()
+		if err != nil {
+			return 0
+		}
+		value = cfg.CacheTTL
+	}
+	if value == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// GetColumns returns the default columns for issue listings, or nil if unset.
+// Precedence: JIRA_COLUMNS → config columns
+func GetColumns() []string {
+	value := os.Getenv("JIRA_COLUMNS")
+	if value == "" {
+		cfg, err := Load()
+		if err != nil {
+			return nil
+		}
+		value = cfg.Columns
+	}
+	return SplitList(value)
+}
+
+// SplitList splits a comma-separated list, dropping empty entries
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // IsConfigured returns true if all required config values are set
 func IsConfigured() bool {
 	return GetURL() != "" && GetEmail() != "" && GetAPIToken() != ""
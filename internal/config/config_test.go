@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,8 @@ func setupTestConfig(t *testing.T) (string, func()) {
 	t.Setenv("ATLASSIAN_URL", "")
 	t.Setenv("ATLASSIAN_EMAIL", "")
 	t.Setenv("ATLASSIAN_API_TOKEN", "")
+	t.Setenv("JIRA_CACHE_TTL", "")
+	t.Setenv("JIRA_COLUMNS", "")
 
 	// Create macOS-style dir as well for fallback
 	libDir := filepath.Join(tempDir, "Library", "Application Support")
@@ -418,3 +421,34 @@ func TestGetURL_FullPrecedenceChain(t *testing.T) {
 	t.Setenv("JIRA_URL", "https://jira-url.atlassian.net")
 	assert.Equal(t, "https://jira-url.atlassian.net", GetURL())
 }
+
+func TestGetCacheTTL(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	// Unset returns zero so callers fall back to their default
+	assert.Equal(t, time.Duration(0), GetCacheTTL())
+
+	require.NoError(t, Save(&Config{CacheTTL: "30m"}))
+	assert.Equal(t, 30*time.Minute, GetCacheTTL())
+
+	// Env takes precedence over config
+	t.Setenv("JIRA_CACHE_TTL", "2h")
+	assert.Equal(t, 2*time.Hour, GetCacheTTL())
+
+	// Invalid values are ignored
+	t.Setenv("JIRA_CACHE_TTL", "soon")
+	assert.Equal(t, time.Duration(0), GetCacheTTL())
+}
+
+func TestGetColumns(t *testing.T) {
+	setupTestConfig(t)
+
+	assert.Nil(t, GetColumns())
+
+	require.NoError(t, Save(&Config{Columns: "key, summary,,status"}))
+	assert.Equal(t, []string{"key", "summary", "status"}, GetColumns())
+
+	t.Setenv("JIRA_COLUMNS", "key,priority")
+	assert.Equal(t, []string{"key", "priority"}, GetColumns())
+}
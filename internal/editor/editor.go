@@ -0,0 +1,37 @@
+package editor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the editor command line to run, from JIRA_EDITOR, VISUAL or
+// EDITOR, falling back to a platform default
+func Command() []string {
+	for _, env := range []string{"JIRA_EDITOR", "VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return strings.Fields(v)
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// Open runs the editor on path and waits for it to exit
+func Open(path string, stdin io.Reader, stdout, stderr io.Writer) error {
+	command := Command()
+	cmd := exec.Command(command[0], append(command[1:], path)...) //nolint:gosec // the editor is chosen by the user
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", strings.Join(command, " "), err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package editor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand_Precedence(t *testing.T) {
+	t.Setenv("JIRA_EDITOR", "")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, []string{"nano"}, Command())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, Command())
+
+	t.Setenv("JIRA_EDITOR", "hx")
+	assert.Equal(t, []string{"hx"}, Command())
+}
+
+func TestOpen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "edit.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho edited >> \"$1\"\n"), 0o700))
+	file := filepath.Join(dir, "doc.md")
+	require.NoError(t, os.WriteFile(file, []byte("original\n"), 0o600))
+
+	t.Setenv("JIRA_EDITOR", script)
+	require.NoError(t, Open(file, nil, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "original\nedited\n", string(data))
+
+	t.Setenv("JIRA_EDITOR", filepath.Join(dir, "missing"))
+	assert.Error(t, Open(file, nil, &bytes.Buffer{}, &bytes.Buffer{}))
+}
@@ -0,0 +1,166 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrNotRepository is returned when a directory is not inside a git work tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// keyPattern matches issue keys that are not part of a longer word
+var keyPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9])([A-Z][A-Z0-9_]+-[1-9][0-9]*)`)
+
+// Repo runs the git binary in a work tree
+type Repo struct {
+	// Dir is the top-level directory of the work tree
+	Dir string
+}
+
+// Open returns the repository containing dir; an empty dir means the
+// current directory
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git is not installed: %w", err)
+	}
+
+	top, err := (&Repo{Dir: dir}).run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, ErrNotRepository
+	}
+	return &Repo{Dir: top}, nil
+}
+
+// CurrentBranch returns the checked-out branch, or "" when HEAD is detached
+func (r *Repo) CurrentBranch() (string, error) {
+	branch, err := r.run("symbolic-ref", "--quiet", "--short", "HEAD")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	return branch, err
+}
+
+// BranchExists reports whether a local branch exists
+func (r *Repo) BranchExists(name string) bool {
+	_, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// CreateBranch creates a branch from HEAD, checking it out when checkout is set
+func (r *Repo) CreateBranch(name string, checkout bool) error {
+	var err error
+	if checkout {
+		_, err = r.run("checkout", "-b", name)
+	} else {
+		_, err = r.run("branch", name)
+	}
+	return err
+}
+
+// Checkout checks out an existing branch
+func (r *Repo) Checkout(name string) error {
+	_, err := r.run("checkout", name)
+	return err
+}
+
+// RecentMessages returns the messages of the last n commits on HEAD, newest
+// first. A repository without commits has none.
+func (r *Repo) RecentMessages(n int) ([]string, error) {
+	if _, err := r.run("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil
+	}
+
+	out, err := r.run("log", fmt.Sprintf("--max-count=%d", n), "--format=%B%x00")
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath
+func (r *Repo) HooksDir() (string, error) {
+	dir, err := r.run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Dir, dir)
+	}
+	return dir, nil
+}
+
+// AddTrailer adds a "token: value" trailer to a commit message file unless
+// the message already has a trailer with that token
+func (r *Repo) AddTrailer(path, token, value string) error {
+	_, err := r.run("interpret-trailers", "--in-place", "--if-exists", "doNothing",
+		"--trailer", token+": "+value, path)
+	return err
+}
+
+// run runs git with args and returns its trimmed output
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s: %w", args[0], msg, err)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// IssueKeys returns the distinct issue keys in text, such as a branch name or
+// commit message, in order of appearance. Words such as UTF-8 or SHA-256 look
+// like keys too, so callers check that the issues exist.
+func IssueKeys(text string) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, m := range keyPattern.FindAllStringSubmatch(text, -1) {
+		if key := m[1]; !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Slug turns text into lower-case words of ASCII letters and digits joined by
+// hyphens, cut at a word boundary to at most maxLen characters
+func Slug(text string, maxLen int) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		if b.Len() > 0 && b.Len()+1+len(w) > maxLen {
+			break
+		}
+		if b.Len() == 0 && len(w) > maxLen {
+			return w[:maxLen]
+		}
+		if b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
@@ -0,0 +1,176 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates a repository with an identity for committing
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	r := &Repo{Dir: dir}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		_, err := r.run(args...)
+		require.NoError(t, err)
+	}
+	return r
+}
+
+func commit(t *testing.T, r *Repo, message string) {
+	t.Helper()
+	_, err := r.run("commit", "--quiet", "--allow-empty", "--no-verify", "-m", message)
+	require.NoError(t, err)
+}
+
+func TestOpen(t *testing.T) {
+	r := newTestRepo(t)
+	sub := filepath.Join(r.Dir, "sub")
+	require.NoError(t, os.Mkdir(sub, 0o755))
+
+	opened, err := Open(sub)
+	require.NoError(t, err)
+	want, err := filepath.EvalSymlinks(r.Dir)
+	require.NoError(t, err)
+	got, err := filepath.EvalSymlinks(opened.Dir)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = Open(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotRepository)
+}
+
+func TestBranches(t *testing.T) {
+	r := newTestRepo(t)
+
+	branch, err := r.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	commit(t, r, "Initial commit")
+
+	require.NoError(t, r.CreateBranch("feature/PROJ-1-login", false))
+	assert.True(t, r.BranchExists("feature/PROJ-1-login"))
+	assert.False(t, r.BranchExists("feature/PROJ-2"))
+	branch, err = r.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	require.NoError(t, r.CreateBranch("feature/PROJ-2-logout", true))
+	branch, err = r.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "feature/PROJ-2-logout", branch)
+
+	require.NoError(t, r.Checkout("feature/PROJ-1-login"))
+	branch, err = r.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "feature/PROJ-1-login", branch)
+
+	err = r.CreateBranch("feature/PROJ-1-login", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	_, err = r.run("checkout", "--quiet", "--detach")
+	require.NoError(t, err)
+	branch, err = r.CurrentBranch()
+	require.NoError(t, err)
+	assert.Empty(t, branch)
+}
+
+func TestRecentMessages(t *testing.T) {
+	r := newTestRepo(t)
+
+	messages, err := r.RecentMessages(5)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	commit(t, r, "First")
+	commit(t, r, "PROJ-7 Second\n\nWith a body")
+	commit(t, r, "Third")
+
+	messages, err = r.RecentMessages(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Third", "PROJ-7 Second\n\nWith a body"}, messages)
+}
+
+func TestHooksDir(t *testing.T) {
+	r := newTestRepo(t)
+
+	dir, err := r.HooksDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(r.Dir, ".git", "hooks"), dir)
+
+	_, err = r.run("config", "core.hooksPath", ".githooks")
+	require.NoError(t, err)
+	dir, err = r.HooksDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(r.Dir, ".githooks"), dir)
+}
+
+func TestAddTrailer(t *testing.T) {
+	r := newTestRepo(t)
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+
+	require.NoError(t, os.WriteFile(path, []byte("Fix login\n"), 0o644))
+	require.NoError(t, r.AddTrailer(path, "Jira", "PROJ-1"))
+	require.NoError(t, r.AddTrailer(path, "Jira", "PROJ-2"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login\n\nJira: PROJ-1\n", string(data))
+}
+
+func TestIssueKeys(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"feature/PROJ-123-fix-login", []string{"PROJ-123"}},
+		{"PROJ-1: fix\n\nRelates to OPS-22 and PROJ-1", []string{"PROJ-1", "OPS-22"}},
+		{"bugfix/AB_2-9", []string{"AB_2-9"}},
+		{"main", nil},
+		{"proj-123 lower case", nil},
+		// Indistinguishable from a key of project UTF
+		{"UTF-8 handling", []string{"UTF-8"}},
+		{"XPROJ-0 and aPROJ-5", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, IssueKeys(tt.text))
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		text   string
+		maxLen int
+		want   string
+	}{
+		{"Fix login redirect", 50, "fix-login-redirect"},
+		{"  [API] Don't crash on empty input!  ", 50, "api-don-t-crash-on-empty-input"},
+		{"Handle timeouts in the payment gateway client", 30, "handle-timeouts-in-the-payment"},
+		{"Supercalifragilisticexpialidocious", 10, "supercalif"},
+		{"Ünïcode only ✓", 50, "n-code-only"},
+		{"", 50, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slug(tt.text, tt.maxLen))
+		})
+	}
+}
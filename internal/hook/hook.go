@@ -0,0 +1,109 @@
+package hook
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"text/template"
+)
+
+// Command is a command line run for events. Every argument is a Go template
+// over the event; the command does not go through a shell, so event text
+// cannot inject commands.
+type Command struct {
+	args []*template.Template
+}
+
+// Parse splits a command line with Split and parses each argument as a template
+func Parse(command string) (*Command, error) {
+	args, err := Split(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command")
+	}
+
+	c := &Command{args: make([]*template.Template, len(args))}
+	for i, arg := range args {
+		if c.args[i], err = template.New("hook").Option("missingkey=error").Parse(arg); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Run expands the arguments with data and runs the command
+func (c *Command) Run(data interface{}, stdout, stderr io.Writer) error {
+	argv := make([]string, len(c.args))
+	for i, tmpl := range c.args {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return err
+		}
+		argv[i] = sb.String()
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...) //nolint:gosec // the command is chosen by the user
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", argv[0], err)
+	}
+	return nil
+}
+
+// Split splits a command line into arguments the way a POSIX shell does for
+// words: on spaces, honoring single and double quotes and backslash escapes.
+// Nothing else is interpreted.
+func Split(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && quote == 0 || r == '\\' && quote == '"' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			current.WriteRune(runes[i])
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
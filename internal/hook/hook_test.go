@@ -0,0 +1,58 @@
+package hook
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{`notify {{.Key}}`, []string{"notify", "{{.Key}}"}},
+		{`say "{{.Type}}: {{.To}}"  'it''s'`, []string{"say", "{{.Type}}: {{.To}}", "its"}},
+		{`a\ b "c\"d" 'e\f'`, []string{"a b", `c"d`, `e\f`}},
+		{`empty ""`, []string{"empty", ""}},
+	}
+	for _, tt := range tests {
+		got, err := Split(tt.command)
+		require.NoError(t, err, tt.command)
+		assert.Equal(t, tt.want, got, tt.command)
+	}
+
+	_, err := Split(`say "unterminated`)
+	assert.Error(t, err)
+}
+
+func TestCommand_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the command")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s|' \"$@\" > \""+out+"\"\n"), 0o700))
+
+	cmd, err := Parse(script + ` {{.Key}} "{{.Type}}: {{.Summary}}"`)
+	require.NoError(t, err)
+	require.NoError(t, cmd.Run(map[string]string{"Key": "PROJ-1", "Type": "new", "Summary": "Crash; rm -rf $HOME"}, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-1|new: Crash; rm -rf $HOME|", string(data))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, command := range []string{"", "  ", `say {{.Nope`, `say "open`} {
+		_, err := Parse(command)
+		assert.Error(t, err, command)
+	}
+}
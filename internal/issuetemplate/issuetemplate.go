@@ -0,0 +1,361 @@
+package issuetemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/config"
+)
+
+const templatesDirName = "templates"
+
+// extensions are the file extensions recognized as templates, in lookup order
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// ErrNotFound is returned when no template with the requested name exists
+var ErrNotFound = errors.New("template not found")
+
+// Template describes an issue to create. String values may use Go template
+// syntax referring to variables, e.g. "{{.component}}".
+type Template struct {
+	Name      string              `yaml:"-" json:"name"`
+	Path      string              `yaml:"-" json:"path,omitempty"`
+	About     string              `yaml:"about,omitempty" json:"about,omitempty"`
+	Project   string              `yaml:"project,omitempty" json:"project,omitempty"`
+	Type      string              `yaml:"type,omitempty" json:"type,omitempty"`
+	Variables map[string]Variable `yaml:"variables,omitempty" json:"variables,omitempty"`
+
+	Summary     string                 `yaml:"summary" json:"summary"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Labels      []string               `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Fields      map[string]interface{} `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Subtasks    []Subtask              `yaml:"subtasks,omitempty" json:"subtasks,omitempty"`
+}
+
+// Variable declares a template variable
+type Variable struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// Subtask is an issue created under the issue described by a template
+type Subtask struct {
+	Summary     string                 `yaml:"summary" json:"summary"`
+	Type        string                 `yaml:"type,omitempty" json:"type,omitempty"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Labels      []string               `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Fields      map[string]interface{} `yaml:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// Dir returns the directory templates are loaded from
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, templatesDirName), nil
+}
+
+// List loads every template in the templates directory, sorted by name.
+// Templates that fail to parse are returned with their error in errs.
+func List() (templates []*Template, errs map[string]error, err error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	errs = make(map[string]error)
+	for _, e := range entries {
+		if e.IsDir() || !isTemplateFile(e.Name()) {
+			continue
+		}
+		t, err := LoadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs[e.Name()] = err
+			continue
+		}
+		templates = append(templates, t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, errs, nil
+}
+
+// Load loads a template by name from the templates directory. A path to a
+// template file is accepted as well.
+func Load(name string) (*Template, error) {
+	if strings.ContainsRune(name, os.PathSeparator) || isTemplateFile(name) {
+		if _, err := os.Stat(name); err == nil {
+			return LoadFile(name)
+		}
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	for _, ext := range extensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return LoadFile(path)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s (looked in %s)", ErrNotFound, name, dir)
+}
+
+// LoadFile parses a YAML or JSON template file
+func LoadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	t.Path = path
+	return t, nil
+}
+
+// Parse parses template data. JSON is accepted as a subset of YAML.
+func Parse(data []byte) (*Template, error) {
+	var t Template
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &t, nil
+}
+
+// isTemplateFile reports whether name has a template file extension
+func isTemplateFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseVars parses name=value variable arguments
+func ParseVars(args []string) (map[string]string, error) {
+	vars := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable format: %s (expected name=value)", arg)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// Values merges vars with the template's variable defaults and checks that
+// every required variable has a value
+func (t *Template) Values(vars map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(t.Variables)+len(vars))
+	for name, v := range t.Variables {
+		values[name] = v.Default
+	}
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	var missing []string
+	for name, v := range t.Variables {
+		if v.Required && values[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing template variable(s): %s (use --var name=value)", strings.Join(missing, ", "))
+	}
+
+	return values, nil
+}
+
+// Render returns a copy of the template with variables substituted in every
+// string value. Referring to a variable that has no value is an error.
+func (t *Template) Render(vars map[string]string) (*Template, error) {
+	values, err := t.Values(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &renderer{values: values}
+	out := &Template{
+		Name:        t.Name,
+		Path:        t.Path,
+		About:       t.About,
+		Variables:   t.Variables,
+		Project:     r.string("project", t.Project),
+		Type:        r.string("type", t.Type),
+		Summary:     r.string("summary", t.Summary),
+		Description: r.string("description", t.Description),
+		Labels:      r.strings("labels", t.Labels),
+		Fields:      r.fields("fields", t.Fields),
+	}
+	for i, st := range t.Subtasks {
+		prefix := fmt.Sprintf("subtasks[%d].", i)
+		out.Subtasks = append(out.Subtasks, Subtask{
+			Summary:     r.string(prefix+"summary", st.Summary),
+			Type:        r.string(prefix+"type", st.Type),
+			Description: r.string(prefix+"description", st.Description),
+			Labels:      r.strings(prefix+"labels", st.Labels),
+			Fields:      r.fields(prefix+"fields", st.Fields),
+		})
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return out, nil
+}
+
+// Validate checks that the template has the required keys and that every
+// string parses and only refers to declared variables
+func (t *Template) Validate() error {
+	var problems []string
+
+	if strings.TrimSpace(t.Summary) == "" {
+		problems = append(problems, "summary is required")
+	}
+	for i, st := range t.Subtasks {
+		if strings.TrimSpace(st.Summary) == "" {
+			problems = append(problems, fmt.Sprintf("subtasks[%d]: summary is required", i))
+		}
+	}
+
+	// Render with a placeholder for every declared variable so that
+	// references to undeclared variables are reported
+	placeholders := make(map[string]string, len(t.Variables))
+	for name := range t.Variables {
+		placeholders[name] = "<" + name + ">"
+	}
+	if _, err := t.Render(placeholders); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// FieldArgs converts labels and custom fields to field arguments understood by
+// api.BuildFieldChanges
+func FieldArgs(labels []string, fields map[string]interface{}) ([]string, error) {
+	var args []string
+	if len(labels) > 0 {
+		args = append(args, "labels="+strings.Join(labels, ","))
+	}
+
+	fieldArgs, err := api.FieldArgsFromValues(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, fieldArgs...), nil
+}
+
+// funcs are the helper functions available in templates
+var funcs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"today": func() string { return time.Now().Format("2006-01-02") },
+}
+
+// renderer substitutes variables, keeping the first error encountered
+type renderer struct {
+	values map[string]string
+	err    error
+}
+
+func (r *renderer) string(where, s string) string {
+	if r.err != nil || !strings.Contains(s, "{{") {
+		return s
+	}
+
+	tmpl, err := template.New(where).Funcs(funcs).Option("missingkey=error").Parse(s)
+	if err != nil {
+		r.err = fmt.Errorf("%s: %w", where, err)
+		return s
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r.values); err != nil {
+		r.err = fmt.Errorf("%s: %w", where, err)
+		return s
+	}
+	return buf.String()
+}
+
+func (r *renderer) strings(where string, list []string) []string {
+	if list == nil {
+		return nil
+	}
+	out := make([]string, 0, len(list))
+	for i, s := range list {
+		s = r.string(fmt.Sprintf("%s[%d]", where, i), s)
+		// A label that renders empty, e.g. from an unset optional variable, is dropped
+		if strings.TrimSpace(s) != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (r *renderer) fields(where string, fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = r.value(where+"."+k, v)
+	}
+	return out
+}
+
+func (r *renderer) value(where string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return r.string(where, val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = r.value(fmt.Sprintf("%s[%d]", where, i), item)
+		}
+		return out
+	case map[string]interface{}:
+		return r.fields(where, val)
+	default:
+		return v
+	}
+}
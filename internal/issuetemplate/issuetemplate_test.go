@@ -0,0 +1,148 @@
+package issuetemplate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bugTemplate = `about: Bug report
+project: PROJ
+type: Bug
+variables:
+  component:
+    required: true
+  title:
+    required: true
+  severity:
+    default: Medium
+  team: {}
+summary: "[{{.component}}] {{.title}}"
+description: |
+  Severity: {{.severity}}
+labels: [bug, "{{.component}}", "{{.team}}"]
+fields:
+  priority: "{{.severity}}"
+  Story Points: 3
+  components: ["{{.component}}", web]
+  customfield_10050: {value: "{{.component | upper}}"}
+subtasks:
+  - summary: "Regression test for {{.title}}"
+    labels: [test]
+`
+
+// setupTemplatesDir points the config directory at a temporary directory and
+// returns the templates directory inside it
+func setupTemplatesDir(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	dir, err := Dir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	return dir
+}
+
+func TestLoadAndList(t *testing.T) {
+	dir := setupTemplatesDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bug.yaml"), []byte(bugTemplate), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spike.json"), []byte(`{"summary":"Spike: {{.topic}}","type":"Task"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("summary: [unclosed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	bug, err := Load("bug")
+	require.NoError(t, err)
+	assert.Equal(t, "bug", bug.Name)
+	assert.Equal(t, "Bug", bug.Type)
+	assert.True(t, bug.Variables["component"].Required)
+
+	spike, err := Load("spike")
+	require.NoError(t, err)
+	assert.Equal(t, "Task", spike.Type)
+
+	_, err = Load("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	list, errs, err := List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "bug", list[0].Name)
+	assert.Equal(t, "spike", list[1].Name)
+	assert.Contains(t, errs, "broken.yml")
+}
+
+func TestParse_UnknownKey(t *testing.T) {
+	_, err := Parse([]byte("summary: x\nsumary: y\n"))
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := Parse([]byte(bugTemplate))
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(map[string]string{"component": "api"})
+	assert.ErrorContains(t, err, "missing template variable(s): title")
+
+	out, err := tmpl.Render(map[string]string{"component": "api", "title": "Login fails"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[api] Login fails", out.Summary)
+	assert.Equal(t, "Severity: Medium\n", out.Description)
+	assert.Equal(t, []string{"bug", "api"}, out.Labels, "labels rendering empty are dropped")
+	assert.Equal(t, "Medium", out.Fields["priority"])
+	assert.Equal(t, []interface{}{"api", "web"}, out.Fields["components"])
+	assert.Equal(t, map[string]interface{}{"value": "API"}, out.Fields["customfield_10050"])
+	require.Len(t, out.Subtasks, 1)
+	assert.Equal(t, "Regression test for Login fails", out.Subtasks[0].Summary)
+
+	// The original template is left untouched
+	assert.Equal(t, "[{{.component}}] {{.title}}", tmpl.Summary)
+}
+
+func TestValidate(t *testing.T) {
+	tmpl, err := Parse([]byte(bugTemplate))
+	require.NoError(t, err)
+	assert.NoError(t, tmpl.Validate())
+
+	tmpl, err = Parse([]byte("summary: \"{{.undeclared}}\"\nsubtasks:\n  - type: Sub-task\n"))
+	require.NoError(t, err)
+	err = tmpl.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subtasks[0]: summary is required")
+	assert.Contains(t, err.Error(), "undeclared")
+
+	tmpl, err = Parse([]byte("summary: \"{{.x\"\n"))
+	require.NoError(t, err)
+	assert.ErrorContains(t, tmpl.Validate(), "summary:")
+}
+
+func TestFieldArgs(t *testing.T) {
+	args, err := FieldArgs([]string{"bug", "api"}, map[string]interface{}{
+		"priority":          "High",
+		"Story Points":      3,
+		"components":        []interface{}{"api", "web"},
+		"customfield_10050": map[string]interface{}{"value": "API"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"labels=bug,api",
+		"Story Points=3",
+		"components=api,web",
+		`customfield_10050:={"value":"API"}`,
+		"priority=High",
+	}, args)
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"component=api", "title=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"component": "api", "title": "a=b", "empty": ""}, vars)
+
+	_, err = ParseVars([]string{"novalue"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Notifier delivers a notification
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// Func adapts a function to a Notifier
+type Func func(title, message string) error
+
+// Notify calls f
+func (f Func) Notify(title, message string) error {
+	return f(title, message)
+}
+
+// Desktop returns a notifier that shows desktop notifications with the
+// platform's tool: notify-send on Linux and the BSDs, osascript on macOS
+func Desktop() (Notifier, error) {
+	var name string
+	var args func(title, message string) []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		name = "osascript"
+		args = func(title, message string) []string {
+			return []string{"-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))}
+		}
+	case "windows":
+		return nil, fmt.Errorf("desktop notifications are not supported on Windows")
+	default:
+		name = "notify-send"
+		args = func(title, message string) []string {
+			return []string{"--app-name=jtk", "--", title, message}
+		}
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("desktop notifications need %s: %w", name, err)
+	}
+
+	return Func(func(title, message string) error {
+		cmd := exec.Command(path, args(title, message)...) //nolint:gosec // fixed tool, arguments are not interpreted by a shell
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %w: %s", name, err, out)
+		}
+		return nil
+	}), nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunc(t *testing.T) {
+	var got []string
+	var n Notifier = Func(func(title, message string) error {
+		got = append(got, title, message)
+		return nil
+	})
+
+	require.NoError(t, n.Notify("PROJ-1", "Done"))
+	assert.Equal(t, []string{"PROJ-1", "Done"}, got)
+}
+
+func TestAppleScriptString(t *testing.T) {
+	assert.Equal(t, `"plain"`, appleScriptString("plain"))
+	assert.Equal(t, `"say \"hi\" \\ bye"`, appleScriptString(`say "hi" \ bye`))
+	assert.Equal(t, `"Größe"`, appleScriptString("Größe"))
+}
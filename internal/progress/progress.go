@@ -0,0 +1,159 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+const (
+	barWidth       = 30
+	redrawInterval = 100 * time.Millisecond
+)
+
+// Bar renders a progress bar on a single terminal line, counting bytes or items.
+// It is safe for concurrent use, so several transfers can report into one bar.
+// When the destination is not a terminal, nothing is drawn.
+type Bar struct {
+	mu       sync.Mutex
+	out      io.Writer
+	enabled  bool
+	label    string
+	total    int64
+	current  int64
+	format   func(int64) string
+	lastDraw time.Time
+}
+
+// New creates a progress bar that draws to out. The bar is only drawn when out
+// is a terminal, so redirected stderr stays free of control characters.
+func New(out io.Writer, label string, total int64) *Bar {
+	return &Bar{
+		out:     out,
+		enabled: IsTerminal(out),
+		label:   label,
+		total:   total,
+		format:  api.FormatFileSize,
+	}
+}
+
+// NewCount creates a progress bar that counts items rather than bytes
+func NewCount(out io.Writer, label string, total int) *Bar {
+	b := New(out, label, int64(total))
+	b.format = func(n int64) string { return strconv.FormatInt(n, 10) }
+	return b
+}
+
+// IsTerminal reports whether w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// SetLabel changes the text shown before the bar
+func (b *Bar) SetLabel(label string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.label = label
+	b.draw(false)
+}
+
+// Add advances the bar by n bytes or items
+func (b *Bar) Add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current += n
+	b.draw(false)
+}
+
+// Current returns the number of bytes or items recorded so far
+func (b *Bar) Current() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// Finish draws the final state and moves to the next line
+func (b *Bar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.draw(true)
+	if b.enabled {
+		fmt.Fprintln(b.out)
+	}
+}
+
+// Writer wraps w so that every write advances the bar
+func (b *Bar) Writer(w io.Writer) io.Writer {
+	return &countingWriter{w: w, bar: b}
+}
+
+// Reader wraps r so that every read advances the bar
+func (b *Bar) Reader(r io.Reader) io.Reader {
+	return &countingReader{r: r, bar: b}
+}
+
+// draw renders the bar; callers must hold the lock
+func (b *Bar) draw(force bool) {
+	if !b.enabled {
+		return
+	}
+	now := time.Now()
+	if !force && now.Sub(b.lastDraw) < redrawInterval {
+		return
+	}
+	b.lastDraw = now
+
+	var line string
+	if b.total > 0 {
+		ratio := float64(b.current) / float64(b.total)
+		if ratio > 1 {
+			ratio = 1
+		}
+		filled := int(ratio * barWidth)
+		bar := strings.Repeat("=", filled)
+		if filled < barWidth {
+			bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+		}
+		line = fmt.Sprintf("%s [%s] %3.0f%% %s / %s", b.label, bar, ratio*100,
+			b.format(b.current), b.format(b.total))
+	} else {
+		line = fmt.Sprintf("%s %s", b.label, b.format(b.current))
+	}
+
+	// \r returns to the start of the line, \033[K clears leftovers from a longer previous line
+	fmt.Fprintf(b.out, "\r%s\033[K", line)
+}
+
+type countingWriter struct {
+	w   io.Writer
+	bar *Bar
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.bar.Add(int64(n))
+	return n, err
+}
+
+type countingReader struct {
+	r   io.Reader
+	bar *Bar
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.bar.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBar_CountsReadsAndWrites(t *testing.T) {
+	var out bytes.Buffer
+	bar := New(&out, "test", 10)
+
+	var dst bytes.Buffer
+	_, err := io.Copy(bar.Writer(&dst), strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), bar.Current())
+
+	_, err = io.ReadAll(bar.Reader(strings.NewReader("world")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), bar.Current())
+
+	bar.Finish()
+	assert.Equal(t, "hello", dst.String())
+}
+
+func TestBar_NoOutputWhenNotTerminal(t *testing.T) {
+	var out bytes.Buffer
+	bar := New(&out, "test", 100)
+	bar.Add(50)
+	bar.Finish()
+
+	assert.Empty(t, out.String())
+}
+
+func TestBar_DrawsWhenEnabled(t *testing.T) {
+	var out bytes.Buffer
+	bar := New(&out, "file.log", 100)
+	bar.enabled = true
+
+	bar.Add(50)
+	bar.Finish()
+
+	output := out.String()
+	assert.Contains(t, output, "file.log")
+	assert.Contains(t, output, "50%")
+	assert.Contains(t, output, "50 B / 100 B")
+}
+
+func TestNewCount_DrawsItems(t *testing.T) {
+	var out bytes.Buffer
+	bar := NewCount(&out, "Updating", 4)
+	bar.enabled = true
+
+	bar.Add(1)
+	bar.Finish()
+
+	assert.Contains(t, out.String(), "25% 1 / 4")
+}
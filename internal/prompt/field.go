@@ -0,0 +1,80 @@
+package prompt
+
+import (
+	"fmt"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+// Field describes a Jira field to ask a value for
+type Field struct {
+	ID            string
+	Name          string
+	Required      bool
+	Schema        api.FieldSchema
+	AllowedValues []api.FieldOption
+}
+
+// FieldValue asks for a value of f in the shape the Jira API expects. Fields
+// with allowed values are offered as a choice; other values are typed and
+// converted according to the field schema, asking again if conversion fails.
+// It returns false when an optional field was skipped.
+func (p *Prompter) FieldValue(f Field, resolveUser api.UserResolver) (interface{}, bool, error) {
+	label := f.Name
+	if label == "" {
+		label = f.ID
+	}
+
+	if len(f.AllowedValues) > 0 {
+		options := make([]string, len(f.AllowedValues))
+		for i, av := range f.AllowedValues {
+			options[i] = av.Label()
+		}
+
+		choice, err := p.Select(label+":", options, !f.Required)
+		if err != nil {
+			return nil, false, err
+		}
+		if choice < 0 {
+			return nil, false, nil
+		}
+		return allowedValueRef(f, f.AllowedValues[choice]), true, nil
+	}
+
+	field := &api.Field{ID: f.ID, Name: f.Name, Schema: f.Schema}
+	for {
+		var value string
+		var err error
+		if f.Required {
+			value, err = p.Required(label)
+		} else {
+			value, err = p.Input(label+" (optional)", "")
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if value == "" {
+			return nil, false, nil
+		}
+
+		converted, err := api.ConvertFieldValue(field, value, resolveUser)
+		if err != nil {
+			fmt.Fprintf(p.out, "  %s\n", err)
+			continue
+		}
+		return converted, true, nil
+	}
+}
+
+// allowedValueRef references a chosen allowed value by ID, which works for
+// options, resolutions, priorities, versions and components alike
+func allowedValueRef(f Field, opt api.FieldOption) interface{} {
+	ref := map[string]string{"id": opt.ID}
+	if opt.ID == "" {
+		ref = map[string]string{"name": opt.Label()}
+	}
+	if f.Schema.Type == "array" {
+		return []map[string]string{ref}
+	}
+	return ref
+}
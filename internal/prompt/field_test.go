@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+)
+
+func TestPrompter_FieldValue(t *testing.T) {
+	t.Run("allowed values", func(t *testing.T) {
+		p := New(strings.NewReader("2\n"), &bytes.Buffer{})
+		value, ok, err := p.FieldValue(Field{
+			ID:            "components",
+			Name:          "Components",
+			Required:      true,
+			Schema:        api.FieldSchema{Type: "array", Items: "component"},
+			AllowedValues: []api.FieldOption{{ID: "1", Name: "API"}, {ID: "2", Name: "UI"}},
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []map[string]string{{"id": "2"}}, value)
+	})
+
+	t.Run("converts typed values and retries invalid input", func(t *testing.T) {
+		var out bytes.Buffer
+		p := New(strings.NewReader("lots\n5\n"), &out)
+		value, ok, err := p.FieldValue(Field{
+			ID:       "customfield_10016",
+			Name:     "Story Points",
+			Required: true,
+			Schema:   api.FieldSchema{Type: "number"},
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, float64(5), value)
+		assert.Contains(t, out.String(), `invalid number "lots"`)
+	})
+
+	t.Run("optional field skipped", func(t *testing.T) {
+		p := New(strings.NewReader("\n"), &bytes.Buffer{})
+		_, ok, err := p.FieldValue(Field{ID: "environment", Name: "Environment"}, nil)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
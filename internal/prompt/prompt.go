@@ -0,0 +1,240 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ErrNotInteractive is returned when a prompt is needed but stdin or stdout is not a terminal
+var ErrNotInteractive = errors.New("interactive input requires a terminal")
+
+// Prompter asks questions on a line-oriented terminal
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New creates a Prompter reading answers from in and writing questions to out
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// IsInteractive reports whether both in and out are terminals
+func IsInteractive(in io.Reader, out io.Writer) bool {
+	return isTerminal(in) && isTerminal(out)
+}
+
+func isTerminal(v interface{}) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// readLine reads one trimmed line of input
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", fmt.Errorf("input closed")
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Input asks for free text. An empty answer returns def.
+func (p *Prompter) Input(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// Required asks for free text until a non-empty answer is given
+func (p *Prompter) Required(label string) (string, error) {
+	for {
+		answer, err := p.Input(label, "")
+		if err != nil {
+			return "", err
+		}
+		if answer != "" {
+			return answer, nil
+		}
+		fmt.Fprintln(p.out, "  A value is required.")
+	}
+}
+
+// Confirm asks a yes/no question
+func (p *Prompter) Confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// Select asks the user to choose one of options and returns its index.
+// The answer may be the option's number or any text, which narrows the list
+// by fuzzy matching until a single option remains. If optional is true an
+// empty answer returns -1.
+func (p *Prompter) Select(label string, options []string, optional bool) (int, error) {
+	if len(options) == 0 {
+		return -1, fmt.Errorf("no options to choose from")
+	}
+
+	candidates := make([]int, len(options))
+	for i := range options {
+		candidates[i] = i
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s\n", label)
+		for n, idx := range candidates {
+			fmt.Fprintf(p.out, "  %d) %s\n", n+1, options[idx])
+		}
+		if optional {
+			fmt.Fprint(p.out, "Choose a number or type to filter (empty to skip): ")
+		} else {
+			fmt.Fprint(p.out, "Choose a number or type to filter: ")
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return -1, err
+		}
+
+		if answer == "" {
+			if optional {
+				return -1, nil
+			}
+			if len(candidates) == 1 {
+				return candidates[0], nil
+			}
+			// Reset a narrowed list so the user can start over
+			candidates = candidates[:0]
+			for i := range options {
+				candidates = append(candidates, i)
+			}
+			continue
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n >= 1 && n <= len(candidates) {
+				return candidates[n-1], nil
+			}
+			fmt.Fprintf(p.out, "  %d is not a valid choice.\n", n)
+			continue
+		}
+
+		matches := FuzzyFilter(answer, options)
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(p.out, "  Nothing matches %q.\n", answer)
+		case 1:
+			return matches[0], nil
+		default:
+			candidates = matches
+		}
+	}
+}
+
+// FuzzyFilter returns the indexes of options matching pattern, best match first.
+// An exact (case-insensitive) match always wins outright.
+func FuzzyFilter(pattern string, options []string) []int {
+	type scored struct {
+		idx   int
+		score int
+	}
+
+	var matches []scored
+	for i, opt := range options {
+		if strings.EqualFold(opt, pattern) {
+			return []int{i}
+		}
+		if score, ok := FuzzyMatch(pattern, opt); ok {
+			matches = append(matches, scored{idx: i, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]int, len(matches))
+	for i, m := range matches {
+		result[i] = m.idx
+	}
+	return result
+}
+
+// FuzzyMatch reports whether every character of pattern appears in s in order
+// (case-insensitive). The score rewards contiguous runs and matches at the
+// start of words, so "ip" ranks "In Progress" above "Ship it".
+func FuzzyMatch(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	r := []rune(strings.ToLower(s))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	pi := 0
+	prevMatched := false
+	for i := 0; i < len(r) && pi < len(p); i++ {
+		if r[i] != p[pi] {
+			prevMatched = false
+			continue
+		}
+		score++
+		if prevMatched {
+			score += 2
+		}
+		if i == 0 || r[i-1] == ' ' || r[i-1] == '-' || r[i-1] == '_' {
+			score += 3
+		}
+		prevMatched = true
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
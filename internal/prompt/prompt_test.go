@@ -0,0 +1,106 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		ok      bool
+	}{
+		{"ip", "In Progress", true},
+		{"done", "Done", true},
+		{"dn", "Done", true},
+		{"xyz", "Done", false},
+		{"", "anything", true},
+		{"prog", "In Progress", true},
+		{"gorp", "In Progress", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.s, func(t *testing.T) {
+			_, ok := FuzzyMatch(tt.pattern, tt.s)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestFuzzyFilter_Ranking(t *testing.T) {
+	options := []string{"Ship it", "In Progress", "Done"}
+
+	matches := FuzzyFilter("ip", options)
+	require.Len(t, matches, 2)
+	assert.Equal(t, 1, matches[0], "word-start matches should rank first")
+
+	assert.Equal(t, []int{2}, FuzzyFilter("done", options))
+}
+
+func TestPrompter_Select(t *testing.T) {
+	options := []string{"To Do", "In Progress", "In Review", "Done"}
+
+	t.Run("by number", func(t *testing.T) {
+		p := New(strings.NewReader("2\n"), &bytes.Buffer{})
+		idx, err := p.Select("Pick", options, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, idx)
+	})
+
+	t.Run("unique filter", func(t *testing.T) {
+		p := New(strings.NewReader("done\n"), &bytes.Buffer{})
+		idx, err := p.Select("Pick", options, false)
+		require.NoError(t, err)
+		assert.Equal(t, 3, idx)
+	})
+
+	t.Run("narrow then number", func(t *testing.T) {
+		var out bytes.Buffer
+		p := New(strings.NewReader("in\n2\n"), &out)
+		idx, err := p.Select("Pick", options, false)
+		require.NoError(t, err)
+		assert.Equal(t, "In Review", options[idx])
+	})
+
+	t.Run("optional skip", func(t *testing.T) {
+		p := New(strings.NewReader("\n"), &bytes.Buffer{})
+		idx, err := p.Select("Pick", options, true)
+		require.NoError(t, err)
+		assert.Equal(t, -1, idx)
+	})
+
+	t.Run("input closed", func(t *testing.T) {
+		p := New(strings.NewReader(""), &bytes.Buffer{})
+		_, err := p.Select("Pick", options, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestPrompter_InputAndConfirm(t *testing.T) {
+	p := New(strings.NewReader("\nvalue\n\nmaybe\ny\n"), &bytes.Buffer{})
+
+	got, err := p.Input("Name", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "default", got)
+
+	got, err = p.Required("Name")
+	require.NoError(t, err)
+	assert.Equal(t, "value", got)
+
+	ok, err := p.Confirm("Sure?", false)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = p.Confirm("Sure?", false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestIsInteractive_NonTerminal(t *testing.T) {
+	assert.False(t, IsInteractive(strings.NewReader(""), &bytes.Buffer{}))
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters: k1 limits how much repeating a term counts, b how much
+// long issues are penalized
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// phraseBoost multiplies the score of issues containing the whole query
+const phraseBoost = 1.5
+
+// keyBoost is added when the query names the issue's key
+const keyBoost = 100
+
+// searchFields are the parts of an issue that are searched, with the weight
+// of a term found in each
+var searchFields = []struct {
+	name   string
+	weight float64
+	text   func(Record) string
+}{
+	{"summary", 3, func(r Record) string { return r.Issue.Fields.Summary }},
+	{"labels", 2, func(r Record) string { return strings.Join(r.Issue.Fields.Labels, " ") }},
+	{"description", 1, func(r Record) string { return r.Issue.Fields.Description.ToPlainText() }},
+	{"comments", 1, func(r Record) string {
+		texts := make([]string, len(r.Comments))
+		for i, c := range r.Comments {
+			texts[i] = c.Body.ToPlainText()
+		}
+		return strings.Join(texts, "\n")
+	}},
+}
+
+// SearchOptions narrows a local search
+type SearchOptions struct {
+	Project string // only issues of this project key
+	Limit   int    // at most this many results; all when zero
+}
+
+// Result is an issue matching a local search
+type Result struct {
+	Record
+	Score float64 `json:"score"`
+	// Matched lists the fields a query term was found in
+	Matched []string `json:"matched"`
+}
+
+// document is a record prepared for scoring
+type document struct {
+	record  Record
+	terms   []map[string]int // term counts per search field
+	texts   []string         // lower-case text per search field
+	length  float64          // weighted number of terms
+	matched map[string]bool
+}
+
+// Search ranks stored issues against a free-text query with BM25, counting
+// terms in the summary and labels more than in the description and comments.
+// Issues match when they contain any query term.
+func (s *Store) Search(query string, opts SearchOptions) ([]Result, error) {
+	queryTerms := uniqueTerms(tokenize(query))
+	if len(queryTerms) == 0 {
+		return []Result{}, nil
+	}
+
+	var docs []*document
+	var totalLength float64
+	err := s.ForEach(func(r Record) error {
+		if opts.Project != "" && (r.Issue.Fields.Project == nil || !strings.EqualFold(r.Issue.Fields.Project.Key, opts.Project)) {
+			return nil
+		}
+		d := newDocument(r)
+		docs = append(docs, d)
+		totalLength += d.length
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return []Result{}, nil
+	}
+	avgLength := totalLength / float64(len(docs))
+
+	// Inverse document frequency of each query term
+	idf := make(map[string]float64, len(queryTerms))
+	for _, term := range queryTerms {
+		n := 0
+		for _, d := range docs {
+			if d.contains(term) {
+				n++
+			}
+		}
+		idf[term] = math.Log(1 + (float64(len(docs))-float64(n)+0.5)/(float64(n)+0.5))
+	}
+
+	phrase := strings.Join(tokenize(query), " ")
+	results := []Result{}
+	for _, d := range docs {
+		score := 0.0
+		for _, term := range queryTerms {
+			tf := d.weightedCount(term)
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - bm25B + bm25B*d.length/avgLength
+			score += idf[term] * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+		if score == 0 && !strings.EqualFold(strings.TrimSpace(query), d.record.Issue.Key) {
+			continue
+		}
+
+		if len(queryTerms) > 1 && d.containsPhrase(phrase) {
+			score *= phraseBoost
+		}
+		for _, word := range strings.Fields(query) {
+			if strings.EqualFold(word, d.record.Issue.Key) {
+				score += keyBoost
+				d.matched["key"] = true
+			}
+		}
+
+		results = append(results, Result{Record: d.record, Score: math.Round(score*1000) / 1000, Matched: d.matchedFields()})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Issue.Key < results[j].Issue.Key
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results, nil
+}
+
+func newDocument(r Record) *document {
+	d := &document{
+		record:  r,
+		terms:   make([]map[string]int, len(searchFields)),
+		texts:   make([]string, len(searchFields)),
+		matched: map[string]bool{},
+	}
+	for i, f := range searchFields {
+		tokens := tokenize(f.text(r))
+		d.texts[i] = " " + strings.Join(tokens, " ") + " "
+		d.terms[i] = make(map[string]int, len(tokens))
+		for _, t := range tokens {
+			d.terms[i][t]++
+		}
+		d.length += f.weight * float64(len(tokens))
+	}
+	return d
+}
+
+func (d *document) contains(term string) bool {
+	for _, counts := range d.terms {
+		if counts[term] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// weightedCount counts a term across fields, weighting each field, and
+// notes the fields it was found in
+func (d *document) weightedCount(term string) float64 {
+	var tf float64
+	for i, f := range searchFields {
+		if n := d.terms[i][term]; n > 0 {
+			tf += f.weight * float64(n)
+			d.matched[f.name] = true
+		}
+	}
+	return tf
+}
+
+func (d *document) containsPhrase(phrase string) bool {
+	for _, text := range d.texts {
+		if strings.Contains(text, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *document) matchedFields() []string {
+	fields := []string{}
+	if d.matched["key"] {
+		fields = append(fields, "key")
+	}
+	for _, f := range searchFields {
+		if d.matched[f.name] {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// tokenize splits text into lower-case words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var unique []string
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cache"
+)
+
+const (
+	dirMode  = 0700
+	fileMode = 0600
+
+	// dataDirName is the directory of jtk's data within the user's data directory
+	dataDirName = "jira-ticket-cli"
+
+	// lockTimeout is how long Open waits for another jtk process to release the store
+	lockTimeout = 2 * time.Second
+)
+
+var (
+	issuesBucket = []byte("issues")
+	syncBucket   = []byte("sync")
+)
+
+// ErrNotFound is returned when an issue is not in the store
+var ErrNotFound = errors.New("issue not in the local store")
+
+// Store is a local database of synced issues and their comments
+type Store struct {
+	db   *bolt.DB
+	path string
+}
+
+// Record is an issue as stored locally
+type Record struct {
+	Issue    api.Issue     `json:"issue"`
+	Comments []api.Comment `json:"comments"`
+	SyncedAt time.Time     `json:"syncedAt"`
+}
+
+// DefaultPath returns the store location for a Jira instance: a file in the
+// user's data directory, so clearing the metadata cache never removes synced
+// issues
+func DefaultPath(instanceURL string) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dataDirName, cache.InstanceName(instanceURL)+".db"), nil
+}
+
+// dataDir returns the user's data directory: $XDG_DATA_HOME, or
+// ~/.local/share on Unix systems and the config directory on macOS and
+// Windows
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir, nil
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get data directory: %w", err)
+		}
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get data directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// Open opens the store at path, creating it when it does not exist
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, fileMode, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, fmt.Errorf("local store %s is in use by another jtk process", path)
+		}
+		return nil, fmt.Errorf("failed to open local store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{issuesBucket, syncBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize local store: %w", err)
+	}
+
+	return &Store{db: db, path: path}, nil
+}
+
+// Path returns the file the store is kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the store
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put saves records, replacing stored versions of the same issues
+func (s *Store) Put(records ...Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(issuesBucket)
+		for _, r := range records {
+			data, err := json.Marshal(r)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", r.Issue.Key, err)
+			}
+			if err := b.Put([]byte(r.Issue.Key), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Get returns the stored record of an issue, or ErrNotFound
+func (s *Store) Get(key string) (*Record, error) {
+	var record *Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(issuesBucket).Get([]byte(key))
+		if data == nil {
+			return ErrNotFound
+		}
+		record = &Record{}
+		return decodeRecord(key, data, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Has reports whether an issue is stored
+func (s *Store) Has(key string) (bool, error) {
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		found = tx.Bucket(issuesBucket).Get([]byte(key)) != nil
+		return nil
+	})
+	return found, err
+}
+
+// ForEach calls fn for every stored record, in key order
+func (s *Store) ForEach(fn func(Record) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(issuesBucket).ForEach(func(k, data []byte) error {
+			var r Record
+			if err := decodeRecord(string(k), data, &r); err != nil {
+				return err
+			}
+			return fn(r)
+		})
+	})
+}
+
+// Count returns the number of stored issues
+func (s *Store) Count() (int, error) {
+	var n int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(issuesBucket).Stats().KeyN
+		return nil
+	})
+	return n, err
+}
+
+// LastSync returns when a query was last synced, or the zero time
+func (s *Store) LastSync(jql string) (time.Time, error) {
+	var t time.Time
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(syncBucket).Get([]byte(jql))
+		if data == nil {
+			return nil
+		}
+		return t.UnmarshalText(data)
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last sync time: %w", err)
+	}
+	return t, nil
+}
+
+// SetLastSync records when a query was synced
+func (s *Store) SetLastSync(jql string, t time.Time) error {
+	data, err := t.UTC().MarshalText()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(syncBucket).Put([]byte(jql), data)
+	})
+}
+
+func decodeRecord(key string, data []byte, r *Record) error {
+	if err := json.Unmarshal(data, r); err != nil {
+		return fmt.Errorf("failed to decode stored %s: %w", key, err)
+	}
+	return nil
+}
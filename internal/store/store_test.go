@@ -0,0 +1,170 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/jira-ticket-cli/api"
+	"github.com/open-cli-collective/jira-ticket-cli/internal/cache"
+)
+
+func openTestStore(t *testing.T) *Store {
+	s, err := Open(filepath.Join(t.TempDir(), "sub", "issues.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func record(key, project, summary, description string, comments ...string) Record {
+	r := Record{Issue: api.Issue{Key: key, Fields: api.IssueFields{
+		Summary: summary,
+		Project: &api.Project{Key: project},
+	}}}
+	if description != "" {
+		r.Issue.Fields.Description = &api.Description{Text: description}
+	}
+	for _, c := range comments {
+		r.Comments = append(r.Comments, api.Comment{Body: api.NewADFDocument(c)})
+	}
+	return r
+}
+
+func TestStore_PutGet(t *testing.T) {
+	s := openTestStore(t)
+
+	_, err := s.Get("PROJ-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	synced := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := record("PROJ-1", "PROJ", "Crash on start", "Stack trace attached", "Seen again")
+	r.Issue.Fields.Updated = &api.Time{Time: synced.Add(-time.Hour)}
+	r.SyncedAt = synced
+	require.NoError(t, s.Put(r, record("PROJ-2", "PROJ", "Other", "")))
+
+	got, err := s.Get("PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Crash on start", got.Issue.Fields.Summary)
+	assert.Equal(t, "Stack trace attached\n", got.Issue.Fields.Description.ToPlainText())
+	assert.True(t, got.Issue.Fields.Updated.Equal(synced.Add(-time.Hour)))
+	require.Len(t, got.Comments, 1)
+	assert.Equal(t, "Seen again\n", got.Comments[0].Body.ToPlainText())
+	assert.True(t, got.SyncedAt.Equal(synced))
+
+	n, err := s.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	found, err := s.Has("PROJ-2")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	// Putting an issue again replaces it
+	require.NoError(t, s.Put(record("PROJ-1", "PROJ", "Crash on start (fixed)", "")))
+	got, err = s.Get("PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Crash on start (fixed)", got.Issue.Fields.Summary)
+	assert.Empty(t, got.Comments)
+}
+
+func TestStore_LastSync(t *testing.T) {
+	s := openTestStore(t)
+
+	last, err := s.LastSync("project = PROJ")
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	require.NoError(t, s.SetLastSync("project = PROJ", at))
+
+	last, err = s.LastSync("project = PROJ")
+	require.NoError(t, err)
+	assert.True(t, last.Equal(at))
+
+	last, err = s.LastSync("project = OTHER")
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+}
+
+func TestStore_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.db")
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(record("PROJ-1", "PROJ", "Kept", "")))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	defer s.Close()
+	got, err := s.Get("PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Kept", got.Issue.Fields.Summary)
+}
+
+func TestStore_Search(t *testing.T) {
+	s := openTestStore(t)
+	require.NoError(t, s.Put(
+		record("PROJ-1", "PROJ", "Flaky test in checkout suite", "The test fails one run in ten"),
+		record("PROJ-2", "PROJ", "Upgrade database driver", "Tests pass locally", "This looks like a flaky network"),
+		record("PROJ-3", "PROJ", "Document the release process", ""),
+		record("OPS-4", "OPS", "Test environment is flaky", ""),
+	))
+
+	results, err := s.Search("flaky test", SearchOptions{})
+	require.NoError(t, err)
+
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.Issue.Key)
+	}
+	// The exact phrase in the summary ranks first; a single term in a comment last
+	assert.Equal(t, []string{"PROJ-1", "OPS-4", "PROJ-2"}, keys)
+	assert.Equal(t, []string{"summary", "description"}, results[0].Matched)
+	assert.Equal(t, []string{"comments"}, results[2].Matched)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	results, err = s.Search("FLAKY", SearchOptions{Project: "ops"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "OPS-4", results[0].Issue.Key)
+
+	results, err = s.Search("flaky", SearchOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	results, err = s.Search("PROJ-3", SearchOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "PROJ-3", results[0].Issue.Key)
+	assert.Contains(t, results[0].Matched, "key")
+
+	results, err = s.Search("kubernetes", SearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = s.Search("  !! ", SearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestDefaultPath_OutsideCache(t *testing.T) {
+	data, cacheDir := t.TempDir(), t.TempDir()
+	t.Setenv("XDG_DATA_HOME", data)
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := DefaultPath("https://example.atlassian.net")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(data, dataDirName), filepath.Dir(path))
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// Clearing every cache keeps the store
+	require.NoError(t, cache.ClearAll())
+	assert.FileExists(t, path)
+}
@@ -0,0 +1,98 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket: it holds up to burst tokens and refills at rate
+// tokens per second. Each request takes a token, waiting for one to be added
+// when the bucket is empty. It is safe for concurrent use.
+type Bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	now func() time.Time
+}
+
+// NewBucket creates a full bucket
+func NewBucket(rate float64, burst int) *Bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Reserve takes a token and returns how long to wait before it may be used
+func (b *Bucket) Reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Wait takes a token, waiting until it may be used or ctx is done
+func (b *Bucket) Wait(ctx context.Context) error {
+	d := b.Reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Transport is an http.RoundTripper that takes a token from Bucket before
+// each request
+type Transport struct {
+	Base   http.RoundTripper // http.DefaultTransport when nil
+	Bucket *Bucket
+}
+
+// RoundTrip waits for a token, then sends the request
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Bucket.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// Client returns a copy of client whose requests are throttled by bucket
+func Client(client *http.Client, bucket *Bucket) *http.Client {
+	throttled := &http.Client{}
+	if client != nil {
+		*throttled = *client
+	}
+	throttled.Transport = &Transport{Base: throttled.Transport, Bucket: bucket}
+	return throttled
+}
@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket_Reserve(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	b := NewBucket(2, 3)
+	b.now = func() time.Time { return now }
+
+	// The burst is available at once
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, b.Reserve())
+	}
+	// Then tokens come at the rate of two a second
+	assert.Equal(t, 500*time.Millisecond, b.Reserve())
+	assert.Equal(t, time.Second, b.Reserve())
+
+	// Refilled tokens pay off the debt first and never exceed the burst
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, b.Reserve())
+	}
+	assert.Equal(t, 500*time.Millisecond, b.Reserve())
+}
+
+func TestBucket_WaitCanceled(t *testing.T) {
+	b := NewBucket(0.001, 1)
+	require.NoError(t, b.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, b.Wait(ctx), context.Canceled)
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	b := NewBucket(1000, 2)
+	b.now = func() time.Time { return now }
+
+	base := server.Client()
+	client := Client(base, b)
+	assert.NotSame(t, base, client)
+	assert.Equal(t, base.Transport, client.Transport.(*Transport).Base)
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// Two requests used the burst, the third waited for a refill
+	assert.Equal(t, 2*time.Millisecond, b.Reserve())
+}
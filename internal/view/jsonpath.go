@@ -0,0 +1,545 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath evaluates JSONPath expressions against JSON data. Expressions are
+// written either bare ($.fields.summary, .key) or kubectl-style as text with
+// {expression} placeholders ("{.key}: {.fields.summary}").
+//
+// Supported syntax: $ (root), @ (current), .name, ['name'], [n] (negative
+// counts from the end), [start:end], [*] and .* (wildcard), any bracket after
+// a dot as in .[*] (kubectl style), ..name (recursive
+// descent) and [?(@.path == 'value')] filters with ==, !=, <, <=, >, >= or a
+// bare path to test for presence.
+type JSONPath struct {
+	parts    []pathPart
+	template bool
+}
+
+// pathPart is literal text or an expression of a template
+type pathPart struct {
+	text  string
+	steps []pathStep // nil for literal text
+}
+
+// pathStep is one step of an expression
+type pathStep struct {
+	kind   stepKind
+	name   string
+	index  int
+	start  *int
+	end    *int
+	filter *pathFilter
+}
+
+type stepKind int
+
+const (
+	stepChild stepKind = iota
+	stepIndex
+	stepSlice
+	stepWildcard
+	stepDescend // recursive descent; name is empty for ..*
+	stepFilter
+)
+
+// pathFilter is a [?(...)] predicate
+type pathFilter struct {
+	left  []pathStep
+	op    string // empty to test for presence
+	right interface{}
+}
+
+// ParseJSONPath parses an expression or a template of {expressions}
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty JSONPath expression")
+	}
+
+	p := &JSONPath{}
+	if !strings.Contains(expr, "{") {
+		steps, err := parseSteps(expr)
+		if err != nil {
+			return nil, err
+		}
+		p.parts = []pathPart{{steps: steps}}
+		return p, nil
+	}
+
+	p.template = true
+	for expr != "" {
+		open := strings.Index(expr, "{")
+		if open < 0 {
+			p.parts = append(p.parts, pathPart{text: unescapeText(expr)})
+			break
+		}
+		if open > 0 {
+			p.parts = append(p.parts, pathPart{text: unescapeText(expr[:open])})
+		}
+		end := matchingBrace(expr, open)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed { in JSONPath template")
+		}
+		steps, err := parseSteps(expr[open+1 : end])
+		if err != nil {
+			return nil, err
+		}
+		p.parts = append(p.parts, pathPart{steps: steps})
+		expr = expr[end+1:]
+	}
+	return p, nil
+}
+
+// Execute evaluates the path against data, which must be JSON-compatible
+// (as produced by json.Unmarshal into interface{}). A bare expression yields
+// one line per match; a template joins the matches of each placeholder with
+// spaces.
+func (p *JSONPath) Execute(data interface{}) (string, error) {
+	if !p.template {
+		values := evalSteps(p.parts[0].steps, []interface{}{data}, data)
+		lines := make([]string, len(values))
+		for i, v := range values {
+			lines[i] = pathValueString(v)
+		}
+		if len(lines) == 0 {
+			return "", nil
+		}
+		return strings.Join(lines, "\n") + "\n", nil
+	}
+
+	var sb strings.Builder
+	for _, part := range p.parts {
+		if part.steps == nil {
+			sb.WriteString(part.text)
+			continue
+		}
+		values := evalSteps(part.steps, []interface{}{data}, data)
+		texts := make([]string, len(values))
+		for i, v := range values {
+			texts[i] = pathValueString(v)
+		}
+		sb.WriteString(strings.Join(texts, " "))
+	}
+	return sb.String(), nil
+}
+
+// pathValueString renders strings as-is and anything else as compact JSON
+func pathValueString(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t").Replace(s)
+}
+
+func matchingBrace(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseSteps parses an expression such as $.a.b[0]['c d'][*]..e
+func parseSteps(expr string) ([]pathStep, error) {
+	expr = strings.TrimSpace(expr)
+	expr = strings.TrimPrefix(expr, "$")
+	expr = strings.TrimPrefix(expr, "@")
+
+	steps := []pathStep{}
+	for i := 0; i < len(expr); {
+		switch {
+		case strings.HasPrefix(expr[i:], ".."):
+			i += 2
+			name, n := readName(expr[i:])
+			if name == "*" || name == "" && strings.HasPrefix(expr[i:], "[") {
+				steps = append(steps, pathStep{kind: stepDescend})
+				if name == "*" {
+					i += n
+				}
+				continue
+			}
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: expected a name after ..", expr)
+			}
+			steps = append(steps, pathStep{kind: stepDescend, name: name})
+			i += n
+
+		case expr[i] == '.':
+			i++
+			name, n := readName(expr[i:])
+			if name == "" && strings.HasPrefix(expr[i:], "[") {
+				// A bracket after the dot, as in {.[*].key}
+				continue
+			}
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: expected a name after .", expr)
+			}
+			if name == "*" {
+				steps = append(steps, pathStep{kind: stepWildcard})
+			} else {
+				steps = append(steps, pathStep{kind: stepChild, name: name})
+			}
+			i += n
+
+		case expr[i] == '[':
+			end := matchingBracket(expr, i)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unclosed [", expr)
+			}
+			step, err := parseBracket(expr[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+			}
+			steps = append(steps, step)
+			i = end + 1
+
+		default:
+			// A leading name without a dot, as in {fields.summary}
+			if len(steps) > 0 || i > 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, expr[i])
+			}
+			name, n := readName(expr)
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q", expr)
+			}
+			steps = append(steps, pathStep{kind: stepChild, name: name})
+			i += n
+		}
+	}
+	return steps, nil
+}
+
+// readName reads a member name up to the next . or [
+func readName(s string) (string, int) {
+	n := 0
+	for n < len(s) && s[n] != '.' && s[n] != '[' && s[n] != ' ' {
+		n++
+	}
+	return s[:n], n
+}
+
+func matchingBracket(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseBracket(inner string) (pathStep, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "*":
+		return pathStep{kind: stepWildcard}, nil
+
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return pathStep{kind: stepChild, name: inner[1 : len(inner)-1]}, nil
+
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		f, err := parseFilter(inner[2 : len(inner)-1])
+		if err != nil {
+			return pathStep{}, err
+		}
+		return pathStep{kind: stepFilter, filter: f}, nil
+
+	case strings.Contains(inner, ":"):
+		bounds := strings.SplitN(inner, ":", 2)
+		step := pathStep{kind: stepSlice}
+		for i, b := range bounds {
+			b = strings.TrimSpace(b)
+			if b == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b)
+			if err != nil {
+				return pathStep{}, fmt.Errorf("invalid slice bound %q", b)
+			}
+			if i == 0 {
+				step.start = &n
+			} else {
+				step.end = &n
+			}
+		}
+		return step, nil
+
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return pathStep{}, fmt.Errorf("invalid subscript %q", inner)
+		}
+		return pathStep{kind: stepIndex, index: n}, nil
+	}
+}
+
+var filterOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(expr string) (*pathFilter, error) {
+	expr = strings.TrimSpace(expr)
+	for _, op := range filterOps {
+		idx := strings.Index(expr, op)
+		if idx < 0 {
+			continue
+		}
+		left, err := parseSteps(strings.TrimSpace(expr[:idx]))
+		if err != nil {
+			return nil, err
+		}
+		right, err := parseLiteral(strings.TrimSpace(expr[idx+len(op):]))
+		if err != nil {
+			return nil, err
+		}
+		return &pathFilter{left: left, op: op, right: right}, nil
+	}
+
+	left, err := parseSteps(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &pathFilter{left: left}, nil
+}
+
+func parseLiteral(s string) (interface{}, error) {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], nil
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid filter value %q", s)
+	}
+	return v, nil
+}
+
+// evalSteps applies steps to every current value
+func evalSteps(steps []pathStep, current []interface{}, root interface{}) []interface{} {
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range current {
+			next = append(next, evalStep(step, v, root)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func evalStep(step pathStep, v interface{}, root interface{}) []interface{} {
+	switch step.kind {
+	case stepChild:
+		if m, ok := v.(map[string]interface{}); ok {
+			if child, ok := m[step.name]; ok {
+				return []interface{}{child}
+			}
+		}
+		return nil
+
+	case stepIndex:
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		i := step.index
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return nil
+		}
+		return []interface{}{list[i]}
+
+	case stepSlice:
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		start, end := 0, len(list)
+		if step.start != nil {
+			start = clampIndex(*step.start, len(list))
+		}
+		if step.end != nil {
+			end = clampIndex(*step.end, len(list))
+		}
+		if start >= end {
+			return nil
+		}
+		return append([]interface{}{}, list[start:end]...)
+
+	case stepWildcard:
+		return children(v)
+
+	case stepDescend:
+		var out []interface{}
+		descend(v, func(node interface{}) {
+			if step.name == "" {
+				out = append(out, children(node)...)
+				return
+			}
+			if m, ok := node.(map[string]interface{}); ok {
+				if child, ok := m[step.name]; ok {
+					out = append(out, child)
+				}
+			}
+		})
+		return out
+
+	case stepFilter:
+		var out []interface{}
+		for _, child := range children(v) {
+			if step.filter.matches(child, root) {
+				out = append(out, child)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// children returns the elements of a list or the values of an object in key order
+func children(v interface{}) []interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		return val
+	case map[string]interface{}:
+		keys := sortedMapKeys(val)
+		out := make([]interface{}, len(keys))
+		for i, k := range keys {
+			out[i] = val[k]
+		}
+		return out
+	}
+	return nil
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// descend visits v and everything below it
+func descend(v interface{}, visit func(interface{})) {
+	visit(v)
+	for _, child := range children(v) {
+		descend(child, visit)
+	}
+}
+
+func (f *pathFilter) matches(v interface{}, root interface{}) bool {
+	values := evalSteps(f.left, []interface{}{v}, root)
+	if f.op == "" {
+		return len(values) > 0 && values[0] != nil
+	}
+	if len(values) == 0 {
+		return f.op == "!="
+	}
+
+	cmp := compareValues(values[0], f.right)
+	switch f.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compareValues compares numbers numerically and everything else as text
+func compareValues(a, b interface{}) int {
+	an, aNum := toFloat(a)
+	bn, bNum := toFloat(b)
+	if aNum && bNum {
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(pathValueString(a), pathValueString(b))
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
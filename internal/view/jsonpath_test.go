@@ -0,0 +1,57 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath_Execute(t *testing.T) {
+	var doc interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"key": "PROJ-1", "fields": {"summary": "First", "status": {"name": "Open"}, "labels": ["a", "b"], "customfield_10016": 3}},
+		{"key": "PROJ-2", "fields": {"summary": "Second", "status": {"name": "Done"}, "labels": [], "customfield_10016": 8}}
+	]`), &doc))
+
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"wildcard", "$[*].key", "PROJ-1\nPROJ-2\n"},
+		{"without root", "[0].fields.summary", "First\n"},
+		{"dot before bracket", ".[0].key", "PROJ-1\n"},
+		{"root dot before bracket", "$.[1].fields['summary']", "Second\n"},
+		{"negative index", "$[-1].key", "PROJ-2\n"},
+		{"slice", "$[0:1].key", "PROJ-1\n"},
+		{"bracket name", "$[0]['fields']['status'].name", "Open\n"},
+		{"recursive", "$..name", "Open\nDone\n"},
+		{"object value", "$[0].fields.labels", `["a","b"]` + "\n"},
+		{"filter equals", "$[?(@.fields.status.name == 'Done')].key", "PROJ-2\n"},
+		{"filter number", "$[?(@.fields.customfield_10016 > 5)].key", "PROJ-2\n"},
+		{"filter presence", "$[?(@.fields.labels[0])].key", "PROJ-1\n"},
+		{"no match", "$[5].key", ""},
+		{"template", "{[0].key}: {[0].fields.summary}\\n", "PROJ-1: First\n"},
+		{"template list", "keys={[*].key}", "keys=PROJ-1 PROJ-2"},
+		{"template dot before bracket", "{.[*].key}", "PROJ-1 PROJ-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ParseJSONPath(tt.expr)
+			require.NoError(t, err)
+			out, err := path.Execute(doc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestParseJSONPath_Errors(t *testing.T) {
+	for _, expr := range []string{"", "$[", "$[abc]", "{.key", "$..", "$.", ".."} {
+		_, err := ParseJSONPath(expr)
+		assert.Error(t, err, expr)
+	}
+}
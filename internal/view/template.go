@@ -0,0 +1,161 @@
+package view
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// timeLayouts are the timestamp formats the date helper accepts
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02",
+}
+
+// templateFuncs returns the helper functions available to output templates.
+// Values come last so helpers work in pipelines: {{.Fields.Summary | truncate 40}}.
+func (v *View) templateFuncs() template.FuncMap {
+	funcs := template.FuncMap{
+		"color":    v.colorize,
+		"truncate": truncateText,
+		"date":     formatDate,
+		"join":     joinValues,
+		"field": func(name string, data interface{}) (string, error) {
+			return "", fmt.Errorf("field %q: field lookup is not available", name)
+		},
+	}
+	for name, fn := range v.TemplateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// executeTemplate renders data with a Go template. A slice is rendered one
+// element at a time, each on its own line.
+func (v *View) executeTemplate(data interface{}) error {
+	if v.Template == "" {
+		return fmt.Errorf("no template given: use --template or -o template=...")
+	}
+
+	tmpl, err := template.New("output").Funcs(v.templateFuncs()).Option("missingkey=zero").Parse(v.Template)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	items := []interface{}{data}
+	if rv := reflect.ValueOf(data); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items = make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, item); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		out := sb.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := fmt.Fprint(v.Out, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var templateColors = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+	"bold":    color.Bold,
+	"faint":   color.Faint,
+}
+
+// colorize wraps text in a color such as "red" or "bold green"
+func (v *View) colorize(name string, text interface{}) (string, error) {
+	var attrs []color.Attribute
+	for _, part := range strings.Fields(name) {
+		attr, ok := templateColors[strings.ToLower(part)]
+		if !ok {
+			return "", fmt.Errorf("unknown color %q", part)
+		}
+		attrs = append(attrs, attr)
+	}
+
+	c := color.New(attrs...)
+	if v.NoColor {
+		c.DisableColor()
+	}
+	return c.Sprint(toText(text)), nil
+}
+
+// truncateText shortens text to at most max characters, ending in "..."
+func truncateText(max int, text interface{}) string {
+	return Truncate(toText(text), max)
+}
+
+// formatDate formats a Jira timestamp with a Go layout such as "2006-01-02".
+// Values that are not timestamps are returned unchanged.
+func formatDate(layout string, value interface{}) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	}
+
+	s := toText(value)
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t.Format(layout)
+		}
+	}
+	return s
+}
+
+// joinValues joins the elements of a list with sep
+func joinValues(sep string, list interface{}) string {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return toText(list)
+	}
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = toText(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// toText renders a template value as text; nil pointers render empty
+func toText(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		v = rv.Elem().Interface()
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,165 @@
+package view
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date formats accepted by View.DateFormat besides Go layouts
+const (
+	DateRelative = "relative"
+	DateISO      = "iso"
+)
+
+// ParseLocation resolves a --tz value: an IANA zone name such as
+// "Europe/Berlin", "UTC" or "Local". Empty means the local zone.
+func ParseLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Time renders a timestamp for output. Tables show it relative to now ("3h
+// ago") unless DateFormat asks for "iso" or a Go layout; other formats use
+// RFC 3339 so the value can be read back by other tools. The zero time
+// renders empty.
+func (v *View) Time(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	t = t.In(v.location())
+
+	switch v.dateFormat() {
+	case DateRelative:
+		return RelativeTime(t, v.now())
+	case DateISO:
+		return t.Format(time.RFC3339)
+	default:
+		return t.Format(v.DateFormat)
+	}
+}
+
+// Date renders a calendar date such as a due date. Tables show it relative
+// to today ("in 3d", "today") unless DateFormat asks otherwise; other formats
+// use YYYY-MM-DD. Dates have no time zone, so --tz does not shift them.
+func (v *View) Date(d time.Time) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	switch v.dateFormat() {
+	case DateRelative:
+		now := v.now().In(v.location())
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+		return RelativeDays(int(day.Sub(today).Hours() / 24))
+	case DateISO:
+		return d.Format("2006-01-02")
+	default:
+		return d.Format(v.DateFormat)
+	}
+}
+
+// dateFormat returns the effective date format: relative for tables and ISO
+// for everything else, unless one was set explicitly
+func (v *View) dateFormat() string {
+	if v.DateFormat != "" {
+		return v.DateFormat
+	}
+	if v.Format == FormatTable || v.Format == FormatPlain || v.Format == "" {
+		return DateRelative
+	}
+	return DateISO
+}
+
+func (v *View) location() *time.Location {
+	if v.Location == nil {
+		return time.Local
+	}
+	return v.Location
+}
+
+func (v *View) now() time.Time {
+	if v.Now == nil {
+		return time.Now()
+	}
+	return v.Now()
+}
+
+// RelativeTime describes t relative to now, such as "5m ago", "3h ago" or
+// "in 2d". Differences under a minute read "just now".
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		amount = fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		amount = fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	default:
+		amount = fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// RelativeDays describes a day offset from today, such as "today",
+// "tomorrow", "in 3d" or "2d ago"
+func RelativeDays(days int) string {
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 0:
+		return fmt.Sprintf("in %dd", days)
+	default:
+		return fmt.Sprintf("%dd ago", -days)
+	}
+}
+
+// Duration renders a duration compactly in its two largest units, such as
+// "3d 4h", "2h 15m" or "45m". Durations under a minute render as "<1m".
+func Duration(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -1,12 +1,15 @@
 package view
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -15,28 +18,74 @@ import (
 type Format string
 
 const (
-	FormatTable Format = "table"
-	FormatJSON  Format = "json"
-	FormatPlain Format = "plain"
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatPlain    Format = "plain"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatMarkdown Format = "markdown"
+	FormatNDJSON   Format = "ndjson"
+	FormatTemplate Format = "template"
+	FormatJSONPath Format = "jsonpath"
 )
 
+// ParseFormat splits an output flag value into its format and argument, as
+// in "jsonpath={.key}" or "template={{.Key}}"
+func ParseFormat(output string) (Format, string) {
+	name, arg, _ := strings.Cut(output, "=")
+	return Format(name), arg
+}
+
+// IsData reports whether the format renders the data given to JSON rather
+// than table rows
+func (f Format) IsData() bool {
+	switch f {
+	case FormatJSON, FormatNDJSON, FormatTemplate, FormatJSONPath:
+		return true
+	}
+	return false
+}
+
 // View handles output formatting
 type View struct {
 	Format  Format
 	NoColor bool
 	Out     io.Writer
 	Err     io.Writer
+
+	// Template is the Go template for FormatTemplate
+	Template string
+	// JSONPath is the expression for FormatJSONPath
+	JSONPath string
+	// TemplateFuncs adds or overrides helper functions available to templates
+	TemplateFuncs map[string]interface{}
+	// MaxWidth limits the width of tables; columns are truncated to fit. 0 means unlimited.
+	MaxWidth int
+	// Location is the time zone timestamps are shown in; nil means local time
+	Location *time.Location
+	// DateFormat is "relative", "iso" or a Go layout; empty picks by format
+	DateFormat string
+	// Now returns the current time for relative dates; nil means time.Now
+	Now func() time.Time
 }
 
 // New creates a new View with the given format
 func New(format string, noColor bool) *View {
+	f, arg := ParseFormat(format)
 	v := &View{
-		Format:  Format(format),
+		Format:  f,
 		NoColor: noColor,
 		Out:     os.Stdout,
 		Err:     os.Stderr,
 	}
 
+	switch f {
+	case FormatTemplate:
+		v.Template = arg
+	case FormatJSONPath:
+		v.JSONPath = arg
+	}
+
 	if noColor {
 		color.NoColor = true
 	}
@@ -50,29 +99,193 @@ func (v *View) Table(headers []string, rows [][]string) error {
 		return fmt.Errorf("use JSON method for JSON output")
 	}
 
-	if v.Format == FormatPlain {
+	switch v.Format {
+	case FormatPlain:
 		return v.Plain(rows)
+	case FormatCSV:
+		return v.delimited(',', headers, rows)
+	case FormatTSV:
+		return v.delimited('\t', headers, rows)
+	case FormatMarkdown:
+		return v.markdown(headers, rows)
+	case FormatNDJSON:
+		return v.ndjsonRows(headers, rows)
+	case FormatTemplate, FormatJSONPath:
+		return v.JSON(rowMaps(headers, rows))
 	}
 
-	w := tabwriter.NewWriter(v.Out, 0, 0, 2, ' ', 0)
+	// Columns are aligned here rather than with text/tabwriter, which would
+	// count color escape sequences as part of the width
+	const gap = 2
+	widths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if i < len(widths) && visibleWidth(cell) > widths[i] {
+				widths[i] = visibleWidth(cell)
+			}
+		}
+	}
+	if v.MaxWidth > 0 {
+		fitWidths(widths, gap, v.MaxWidth)
+	}
 
-	// Print headers
-	headerLine := strings.Join(headers, "\t")
-	fmt.Fprintln(w, color.New(color.Bold).Sprint(headerLine))
+	writeLine := func(cells []string, style func(string) string) {
+		var sb strings.Builder
+		for i, cell := range cells {
+			if i >= len(widths) {
+				break
+			}
+			cell = fitCell(cell, widths[i])
+			pad := widths[i] - visibleWidth(cell)
+			sb.WriteString(style(cell))
+			if i < len(cells)-1 && i < len(widths)-1 {
+				sb.WriteString(strings.Repeat(" ", pad+gap))
+			}
+		}
+		fmt.Fprintln(v.Out, sb.String())
+	}
 
-	// Print rows
+	bold := color.New(color.Bold)
+	writeLine(headers, func(s string) string { return bold.Sprint(s) })
 	for _, row := range rows {
-		fmt.Fprintln(w, strings.Join(row, "\t"))
+		writeLine(row, func(s string) string { return s })
 	}
-
-	return w.Flush()
+	return nil
 }
 
-// JSON renders data as JSON
+// JSON renders data as JSON. In NDJSON format a slice is written as one
+// compact object per line; the template and jsonpath formats render the data
+// with the configured template or expression instead.
 func (v *View) JSON(data interface{}) error {
+	switch v.Format {
+	case FormatTemplate:
+		return v.executeTemplate(data)
+	case FormatJSONPath:
+		return v.executeJSONPath(data)
+	}
+
 	enc := json.NewEncoder(v.Out)
-	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	if v.Format != FormatNDJSON {
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return enc.Encode(data)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delimited renders rows as CSV or TSV with a header row. Fields containing
+// the separator, quotes or line breaks are quoted, which spreadsheet
+// applications read back as a single cell.
+func (v *View) delimited(comma rune, headers []string, rows [][]string) error {
+	w := csv.NewWriter(v.Out)
+	w.Comma = comma
+
+	if err := w.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// markdown renders rows as a GitHub-flavored Markdown table
+func (v *View) markdown(headers []string, rows [][]string) error {
+	writeRow := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = markdownCell(cell)
+		}
+		fmt.Fprintf(v.Out, "| %s |\n", strings.Join(escaped, " | "))
+	}
+
+	writeRow(headers)
+	sep := make([]string, len(headers))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	fmt.Fprintf(v.Out, "| %s |\n", strings.Join(sep, " | "))
+
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return nil
+}
+
+// markdownCell escapes a value for use in a Markdown table cell, which
+// cannot contain pipes or line breaks
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// ndjsonRows renders each row as a JSON object keyed by header
+func (v *View) ndjsonRows(headers []string, rows [][]string) error {
+	enc := json.NewEncoder(v.Out)
+	for _, obj := range rowMaps(headers, rows) {
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowMaps converts table rows to objects keyed by header
+func rowMaps(headers []string, rows [][]string) []map[string]string {
+	objs := make([]map[string]string, len(rows))
+	for r, row := range rows {
+		obj := make(map[string]string, len(headers))
+		for i, h := range headers {
+			if i < len(row) {
+				obj[h] = row[i]
+			}
+		}
+		objs[r] = obj
+	}
+	return objs
+}
+
+// executeJSONPath renders the JSON form of data through the JSONPath expression
+func (v *View) executeJSONPath(data interface{}) error {
+	if v.JSONPath == "" {
+		return fmt.Errorf("no JSONPath expression given: use -o jsonpath=<expression>")
+	}
+
+	path, err := ParseJSONPath(v.JSONPath)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	out, err := path.Execute(doc)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(v.Out, out)
+	return err
 }
 
 // Plain renders rows as tab-separated values without headers
@@ -85,10 +298,10 @@ func (v *View) Plain(rows [][]string) error {
 
 // Render renders data based on the current format
 func (v *View) Render(headers []string, rows [][]string, jsonData interface{}) error {
-	switch v.Format {
-	case FormatJSON:
+	switch {
+	case v.Format.IsData():
 		return v.JSON(jsonData)
-	case FormatPlain:
+	case v.Format == FormatPlain:
 		return v.Plain(rows)
 	default:
 		return v.Table(headers, rows)
@@ -128,3 +341,8 @@ func (v *View) Print(format string, args ...interface{}) {
 func (v *View) Println(format string, args ...interface{}) {
 	fmt.Fprintln(v.Out, fmt.Sprintf(format, args...))
 }
+
+// Number renders a number without trailing zeros, such as "3" or "2.5"
+func Number(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
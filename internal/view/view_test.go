@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -238,3 +239,247 @@ func TestFormat_Constants(t *testing.T) {
 	assert.Equal(t, Format("json"), FormatJSON)
 	assert.Equal(t, Format("plain"), FormatPlain)
 }
+
+func TestView_Table_ExportFormats(t *testing.T) {
+	headers := []string{"KEY", "SUMMARY"}
+	rows := [][]string{
+		{"PROJ-1", `Fix "quoted", comma`},
+		{"PROJ-2", "Line one\nline | two"},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want:   "KEY,SUMMARY\nPROJ-1,\"Fix \"\"quoted\"\", comma\"\nPROJ-2,\"Line one\nline | two\"\n",
+		},
+		{
+			format: FormatTSV,
+			want:   "KEY\tSUMMARY\nPROJ-1\t\"Fix \"\"quoted\"\", comma\"\nPROJ-2\t\"Line one\nline | two\"\n",
+		},
+		{
+			format: FormatMarkdown,
+			want: "| KEY | SUMMARY |\n| --- | --- |\n" +
+				"| PROJ-1 | Fix \"quoted\", comma |\n" +
+				"| PROJ-2 | Line one<br>line \\| two |\n",
+		},
+		{
+			format: FormatNDJSON,
+			want: `{"KEY":"PROJ-1","SUMMARY":"Fix \"quoted\", comma"}` + "\n" +
+				`{"KEY":"PROJ-2","SUMMARY":"Line one\nline | two"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			v := &View{Format: tt.format, NoColor: true, Out: &buf}
+
+			require.NoError(t, v.Table(headers, rows))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestView_JSON_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	v := &View{Format: FormatNDJSON, Out: &buf}
+
+	err := v.Render(nil, nil, []map[string]string{{"key": "PROJ-1"}, {"key": "PROJ-2"}})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"key\":\"PROJ-1\"}\n{\"key\":\"PROJ-2\"}\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, v.JSON(map[string]string{"key": "PROJ-3"}))
+	assert.Equal(t, "{\"key\":\"PROJ-3\"}\n", buf.String())
+}
+
+func TestView_Template(t *testing.T) {
+	type issue struct {
+		Key     string
+		Summary string
+		Labels  []string
+		Created string
+	}
+	issues := []issue{
+		{Key: "PROJ-1", Summary: "A rather long summary", Labels: []string{"a", "b"}, Created: "2024-03-05T10:00:00.000+0000"},
+		{Key: "PROJ-2", Summary: "Short", Created: "not a date"},
+	}
+
+	var buf bytes.Buffer
+	v := New("template", true)
+	v.Out = &buf
+	v.Template = `{{.Key}} {{.Summary | truncate 10}} [{{join "," .Labels}}] {{date "2006-01-02" .Created}} {{color "red" "x"}}`
+
+	require.NoError(t, v.JSON(issues))
+	assert.Equal(t, "PROJ-1 A rathe... [a,b] 2024-03-05 x\nPROJ-2 Short [] not a date x\n", buf.String())
+}
+
+func TestView_Template_FromFormat(t *testing.T) {
+	var buf bytes.Buffer
+	v := New("template={{.key}}={{.value}}", true)
+	v.Out = &buf
+
+	require.NoError(t, v.Table([]string{"key", "value"}, [][]string{{"a", "1"}, {"b", "2"}}))
+	assert.Equal(t, "a=1\nb=2\n", buf.String())
+}
+
+func TestView_Template_Errors(t *testing.T) {
+	v := New("template", true)
+	v.Out = &bytes.Buffer{}
+	assert.ErrorContains(t, v.JSON(1), "no template given")
+
+	v.Template = "{{.Key"
+	assert.ErrorContains(t, v.JSON(1), "invalid template")
+
+	v.Template = `{{color "plaid" "x"}}`
+	assert.ErrorContains(t, v.JSON(1), "unknown color")
+}
+
+func TestView_JSONPath(t *testing.T) {
+	var buf bytes.Buffer
+	v := New("jsonpath={[*].key}", true)
+	v.Out = &buf
+
+	require.NoError(t, v.Render(nil, nil, []map[string]string{{"key": "PROJ-1"}, {"key": "PROJ-2"}}))
+	assert.Equal(t, "PROJ-1 PROJ-2", buf.String())
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "exactly10!", Truncate("exactly10!", 10))
+	assert.Equal(t, "a long ...", Truncate("a long summary", 10))
+	// Wide characters take two cells and are never split
+	assert.Equal(t, "日本...", Truncate("日本語テキストの要約", 8))
+	assert.Equal(t, "日本語...", Truncate("日本語テキストの要約", 9))
+	assert.Equal(t, "Café c...", Truncate("Café crème brûlée", 9))
+}
+
+func TestView_Table_MaxWidth(t *testing.T) {
+	var buf bytes.Buffer
+	v := &View{Format: FormatTable, NoColor: true, Out: &buf, MaxWidth: 40}
+
+	err := v.Table([]string{"KEY", "SUMMARY", "STATUS"}, [][]string{
+		{"PROJ-1", "A summary far too long to fit in a narrow terminal", "Open"},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "PROJ-1  A summary far too lon...  Open", lines[1])
+	for _, line := range lines {
+		assert.LessOrEqual(t, len([]rune(line)), 40)
+	}
+}
+
+func TestView_Table_WideCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	v := &View{Format: FormatTable, NoColor: true, Out: &buf, MaxWidth: 24}
+
+	require.NoError(t, v.Table([]string{"KEY", "SUMMARY", "STATUS"}, [][]string{
+		{"PROJ-1", "ログイン失敗🔥", "Open"},
+		{"PROJ-2", "日本語テキストの要約です", "Done"},
+	}))
+
+	assert.Equal(t, "KEY     SUMMARY   STATUS\n"+
+		"PROJ-1  ログ...   Open\n"+
+		"PROJ-2  日本...   Done\n", buf.String())
+}
+
+func TestView_Table_ColorAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	v := &View{Format: FormatTable, NoColor: true, Out: &buf}
+
+	green := "\x1b[32mDone\x1b[0m"
+	require.NoError(t, v.Table([]string{"STATUS", "KEY"}, [][]string{
+		{green, "PROJ-1"},
+		{"In Progress", "PROJ-2"},
+	}))
+
+	assert.Equal(t, "STATUS       KEY\n"+
+		green+"         PROJ-1\n"+
+		"In Progress  PROJ-2\n", buf.String())
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-20 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		{now.AddDate(0, -2, 0), "2mo ago"},
+		{now.AddDate(-3, 0, 0), "3y ago"},
+		{now.Add(90 * time.Minute), "in 1h"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, RelativeTime(tt.t, now))
+	}
+}
+
+func TestView_Time(t *testing.T) {
+	berlin, err := ParseLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	ts := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	v := &View{Format: FormatTable, Location: berlin, Now: func() time.Time { return now }}
+
+	assert.Equal(t, "3h ago", v.Time(ts))
+	assert.Equal(t, "", v.Time(time.Time{}))
+
+	v.DateFormat = DateISO
+	assert.Equal(t, "2024-03-10T10:00:00+01:00", v.Time(ts))
+
+	v.DateFormat = "02 Jan 15:04"
+	assert.Equal(t, "10 Mar 10:00", v.Time(ts))
+
+	// Exports default to ISO timestamps
+	v = &View{Format: FormatCSV, Location: time.UTC}
+	assert.Equal(t, "2024-03-10T09:00:00Z", v.Time(ts))
+
+	_, err = ParseLocation("Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestView_Date(t *testing.T) {
+	now := time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC)
+	v := &View{Format: FormatTable, Location: time.UTC, Now: func() time.Time { return now }}
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, "today", v.Date(day(10)))
+	assert.Equal(t, "tomorrow", v.Date(day(11)))
+	assert.Equal(t, "yesterday", v.Date(day(9)))
+	assert.Equal(t, "in 5d", v.Date(day(15)))
+	assert.Equal(t, "3d ago", v.Date(day(7)))
+
+	// Today is already the 11th in Tokyo
+	tokyo, err := ParseLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	v.Location = tokyo
+	assert.Equal(t, "today", v.Date(day(11)))
+
+	v = &View{Format: FormatJSON}
+	assert.Equal(t, "2024-03-15", v.Date(day(15)))
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "<1m", Duration(30*time.Second))
+	assert.Equal(t, "45m", Duration(45*time.Minute))
+	assert.Equal(t, "2h", Duration(2*time.Hour))
+	assert.Equal(t, "2h 15m", Duration(2*time.Hour+15*time.Minute))
+	assert.Equal(t, "3d", Duration(72*time.Hour+10*time.Minute))
+	assert.Equal(t, "3d 4h", Duration(76*time.Hour))
+}
+
+func TestNumber(t *testing.T) {
+	assert.Equal(t, "3", Number(3))
+	assert.Equal(t, "2.5", Number(2.5))
+	assert.Equal(t, "0", Number(0))
+}
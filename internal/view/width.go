@@ -0,0 +1,80 @@
+package view
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting a table
+const minColumnWidth = 8
+
+// ansiPattern matches terminal escape sequences such as colors
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// TerminalWidth returns the width of the terminal w writes to. When w is not
+// a terminal it falls back to $COLUMNS, and to 0 (unlimited) without it.
+func TerminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// Truncate shortens s to at most max terminal cells, ending in "...". Wide
+// characters such as CJK and emoji take two cells and are never split.
+func Truncate(s string, max int) string {
+	if max <= 3 || runewidth.StringWidth(s) <= max {
+		return s
+	}
+	return runewidth.Truncate(s, max, "...")
+}
+
+// visibleWidth is the number of terminal cells s occupies, ignoring colors
+func visibleWidth(s string) int {
+	return runewidth.StringWidth(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// fitCell truncates a cell to width. Colored cells that need truncating lose
+// their color, since cutting through an escape sequence would garble the line.
+func fitCell(s string, width int) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+	return Truncate(ansiPattern.ReplaceAllString(s, ""), width)
+}
+
+// fitWidths shrinks column widths until a table with gap spaces between
+// columns fits in max characters. The widest column gives way first, so short
+// columns such as keys and statuses stay intact while summaries are cut.
+func fitWidths(widths []int, gap, max int) {
+	total := func() int {
+		sum := gap * (len(widths) - 1)
+		for _, w := range widths {
+			sum += w
+		}
+		return sum
+	}
+
+	for total() > max {
+		widest := -1
+		for i, w := range widths {
+			if w > minColumnWidth && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+	}
+}